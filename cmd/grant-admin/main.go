@@ -0,0 +1,64 @@
+// Command grant-admin makes an existing user an administrator. Roles above
+// organizer cannot be self-assigned, so this is how the first administrators
+// are appointed; they appoint the others through the API.
+//
+//	go run ./cmd/grant-admin -email admin@example.com
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/repositories"
+
+	"go.uber.org/fx"
+)
+
+func main() {
+	email := flag.String("email", "", "email of the user to make an administrator")
+	flag.Parse()
+
+	if *email == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	app := fx.New(
+		fx.NopLogger,
+		config.Module,
+		logger.Module,
+		database.Module,
+		repositories.Module,
+		fx.Invoke(func(authRepository ports.AuthRepository, userRepository ports.UserRepository) error {
+			user, err := authRepository.GetUserByEmail(*email)
+			if err != nil {
+				return fmt.Errorf("%s: %w", *email, err)
+			}
+
+			if _, err := userRepository.UpdateUserRole(user.ID, constants.UserRoleAdmin); err != nil {
+				return err
+			}
+
+			fmt.Printf("%s is now an administrator\n", user.Email)
+			return nil
+		}),
+	)
+
+	if err := app.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := app.Stop(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
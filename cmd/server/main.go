@@ -6,6 +6,8 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Venue timezones must resolve in containers without system tzdata.
+	_ "time/tzdata"
 
 	"github.com/EventFlow-Project/backend/internal/config"
 	"github.com/EventFlow-Project/backend/internal/core/ports"
@@ -14,7 +16,16 @@ import (
 	"github.com/EventFlow-Project/backend/internal/infrastructure/api"
 	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
 	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/mail"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/messaging"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/oidc"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/passwords"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/payments"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/ratelimit"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/realtime"
 	"github.com/EventFlow-Project/backend/internal/infrastructure/repositories"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/scheduler"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/screening"
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -28,7 +39,18 @@ func main() {
 		services.Module,
 		ports.Module,
 		repositories.Module,
+		payments.Module,
+		messaging.Module,
+		mail.Module,
+		passwords.Module,
+		oidc.Module,
+		ratelimit.Module,
+		scheduler.Module,
+		screening.Module,
 		api.Module,
+		// After the API, so that realtime streams close before the server
+		// waits for its connections on shutdown.
+		realtime.Module,
 	)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
@@ -15,6 +17,17 @@ type DatabaseConfig struct {
 	Name     string `env:"DB_NAME"`
 }
 
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		c.Host,
+		c.Port,
+		c.User,
+		c.Password,
+		c.Name,
+	)
+}
+
 type MinioConfig struct {
 	Endpoint        string `env:"MINIO_ENDPOINT"`
 	PublicEndpoint  string `env:"MINIO_PUBLIC_ENDPOINT"`
@@ -25,16 +38,238 @@ type MinioConfig struct {
 	Port            int    `env:"MINIO_PORT"`
 }
 type JWTConfig struct {
-	Secret string `env:"JWT_SECRET"`
+	Secret          string        `env:"JWT_SECRET"`
+	AccessTokenTTL  time.Duration `env:"JWT_ACCESS_TOKEN_TTL" envDefault:"15m"`
+	RefreshTokenTTL time.Duration `env:"JWT_REFRESH_TOKEN_TTL" envDefault:"720h"`
+}
+
+func (c JWTConfig) Validate() error {
+	return requireSecret("JWT_SECRET", c.Secret)
+}
+
+type AuthConfig struct {
+	EmailVerificationTTL time.Duration `env:"AUTH_EMAIL_VERIFICATION_TTL" envDefault:"48h"`
+	PasswordResetTTL     time.Duration `env:"AUTH_PASSWORD_RESET_TTL" envDefault:"1h"`
+	EmailChangeTTL       time.Duration `env:"AUTH_EMAIL_CHANGE_TTL" envDefault:"24h"`
+	// BcryptCost applies to new password hashes; older hashes with a lower
+	// cost are upgraded when their owners log in.
+	BcryptCost        int `env:"AUTH_BCRYPT_COST" envDefault:"12"`
+	PasswordMinLength int `env:"AUTH_PASSWORD_MIN_LENGTH" envDefault:"8"`
+	// PasswordDenylistFile replaces the built-in list of breached passwords
+	// when set.
+	PasswordDenylistFile string `env:"AUTH_PASSWORD_DENYLIST_FILE"`
+	// MFAIssuer names the service in authenticator apps.
+	MFAIssuer string `env:"AUTH_MFA_ISSUER" envDefault:"EventFlow"`
+	// MFAChallengeTTL is how long a user has to enter the two-factor code
+	// after the password.
+	MFAChallengeTTL time.Duration `env:"AUTH_MFA_CHALLENGE_TTL" envDefault:"5m"`
+	// MFAEncryptionKey encrypts two-factor secrets at rest. It must differ
+	// from JWT_SECRET.
+	MFAEncryptionKey string `env:"AUTH_MFA_ENCRYPTION_KEY"`
+}
+
+func (c AuthConfig) Validate() error {
+	return requireSecret("AUTH_MFA_ENCRYPTION_KEY", c.MFAEncryptionKey)
+}
+
+type LoginConfig struct {
+	// Failed logins are counted per account and per IP over FailureWindow.
+	FailureWindow time.Duration `env:"LOGIN_FAILURE_WINDOW" envDefault:"1h"`
+	// After the free attempts, each failure doubles the wait before the
+	// next attempt, starting at BackoffBase, up to BackoffMax.
+	AccountFreeAttempts int           `env:"LOGIN_ACCOUNT_FREE_ATTEMPTS" envDefault:"3"`
+	IPFreeAttempts      int           `env:"LOGIN_IP_FREE_ATTEMPTS" envDefault:"10"`
+	BackoffBase         time.Duration `env:"LOGIN_BACKOFF_BASE" envDefault:"1s"`
+	BackoffMax          time.Duration `env:"LOGIN_BACKOFF_MAX" envDefault:"1m"`
+	// An account or IP reaching its threshold of failures is locked out for
+	// LockoutDuration.
+	AccountLockoutThreshold int           `env:"LOGIN_ACCOUNT_LOCKOUT_THRESHOLD" envDefault:"10"`
+	IPLockoutThreshold      int           `env:"LOGIN_IP_LOCKOUT_THRESHOLD" envDefault:"50"`
+	LockoutDuration         time.Duration `env:"LOGIN_LOCKOUT_DURATION" envDefault:"15m"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+	// Store is "memory" for a single replica, or "postgres" to share
+	// counters, and login failures, between replicas.
+	Store string `env:"RATE_LIMIT_STORE" envDefault:"memory"`
+	// Budgets are written as requests per period, e.g. "300/1m". Default
+	// applies to routes that Routes does not match.
+	Default string `env:"RATE_LIMIT_DEFAULT" envDefault:"300/1m"`
+	// Routes maps paths to budgets; a path ending with /* matches all paths
+	// below it. The most specific match wins.
+	Routes        map[string]string `env:"RATE_LIMIT_ROUTES" envDefault:"/auth/*:30/1m,/users/uploadAvatar:10/1m,/events/uploadImage:10/1m"`
+	PruneInterval time.Duration     `env:"RATE_LIMIT_PRUNE_INTERVAL" envDefault:"1m"`
+}
+
+// OIDCProviderConfig configures an OpenID Connect provider. The provider is
+// enabled when ClientID is set.
+type OIDCProviderConfig struct {
+	ClientID     string `env:"CLIENT_ID"`
+	ClientSecret string `env:"CLIENT_SECRET"`
+	// Issuer must match the iss claim of ID tokens. The endpoints are read
+	// from its discovery document unless they are set.
+	Issuer   string   `env:"ISSUER"`
+	AuthURL  string   `env:"AUTH_URL"`
+	TokenURL string   `env:"TOKEN_URL"`
+	JWKSURL  string   `env:"JWKS_URL"`
+	Scopes   []string `env:"SCOPES" envDefault:"openid,email,profile"`
+}
+
+type OIDCConfig struct {
+	// StateTTL is how long a user has to log in at the provider.
+	StateTTL time.Duration      `env:"OIDC_STATE_TTL" envDefault:"10m"`
+	Google   OIDCProviderConfig `envPrefix:"OIDC_GOOGLE_"`
+	VK       OIDCProviderConfig `envPrefix:"OIDC_VK_"`
+	Yandex   OIDCProviderConfig `envPrefix:"OIDC_YANDEX_"`
+	// MockAddress starts a built-in provider, named "mock", that logs in
+	// anyone without asking. It is only allowed in development and tests.
+	MockAddress string `env:"OIDC_MOCK_ADDRESS"`
+}
+
+type TicketConfig struct {
+	SigningSecret string `env:"TICKET_SIGNING_SECRET"`
+}
+
+// Validate checks that ticket codes cannot be forged.
+func (c TicketConfig) Validate() error {
+	return requireSecret("TICKET_SIGNING_SECRET", c.SigningSecret)
+}
+
+type PaymentConfig struct {
+	// Provider has no default: the fake provider approves every payment,
+	// so it must be chosen explicitly.
+	Provider      string `env:"PAYMENT_PROVIDER"`
+	WebhookSecret string `env:"PAYMENT_WEBHOOK_SECRET"`
+	// RefundRetryInterval is how often refunds that failed after a ticket
+	// could not be issued are retried.
+	RefundRetryInterval time.Duration `env:"PAYMENT_REFUND_RETRY_INTERVAL" envDefault:"5m"`
+}
+
+// Validate checks that a provider is chosen and payment webhooks cannot be
+// forged.
+func (c PaymentConfig) Validate() error {
+	if c.Provider == "" {
+		return errors.New("PAYMENT_PROVIDER must be set")
+	}
+
+	return requireSecret("PAYMENT_WEBHOOK_SECRET", c.WebhookSecret)
+}
+
+type SchedulerConfig struct {
+	EventStatusInterval   time.Duration `env:"EVENT_STATUS_SYNC_INTERVAL" envDefault:"1m"`
+	EventReminderInterval time.Duration `env:"EVENT_REMINDER_INTERVAL" envDefault:"5m"`
+	// EventReminderLead is how long before an event attendees are reminded
+	// of it.
+	EventReminderLead time.Duration `env:"EVENT_REMINDER_LEAD" envDefault:"24h"`
+}
+
+type ScreeningConfig struct {
+	Enabled bool `env:"SCREENING_ENABLED" envDefault:"true"`
+	// Banned word lists replace the built-in ones when set.
+	BannedWordsRUFile string        `env:"SCREENING_BANNED_WORDS_RU_FILE"`
+	BannedWordsENFile string        `env:"SCREENING_BANNED_WORDS_EN_FILE"`
+	MaxLinks          int           `env:"SCREENING_MAX_LINKS" envDefault:"2"`
+	DuplicateWindow   time.Duration `env:"SCREENING_DUPLICATE_WINDOW" envDefault:"720h"`
+	// Events scoring at least RejectScore are rejected outright; trusted
+	// organizers' events scoring below ReviewScore are approved outright.
+	RejectScore float64 `env:"SCREENING_REJECT_SCORE" envDefault:"1"`
+	ReviewScore float64 `env:"SCREENING_REVIEW_SCORE" envDefault:"0.3"`
+	// Organizers are trusted once TrustedMinApproved of their events were
+	// approved by moderators and none was rejected within TrustWindow.
+	TrustedMinApproved int           `env:"SCREENING_TRUSTED_MIN_APPROVED" envDefault:"3"`
+	TrustWindow        time.Duration `env:"SCREENING_TRUST_WINDOW" envDefault:"2160h"`
+}
+
+type ReportsConfig struct {
+	// HideThreshold is the number of distinct reporters after which reported
+	// content is hidden until a moderator handles the case; 0 disables it.
+	HideThreshold int `env:"REPORTS_HIDE_THRESHOLD" envDefault:"5"`
+}
+
+type RealtimeConfig struct {
+	// Fanout is "postgres" to relay messages between replicas over
+	// LISTEN/NOTIFY, or "memory" for a single replica.
+	Fanout            string        `env:"REALTIME_FANOUT" envDefault:"postgres"`
+	HeartbeatInterval time.Duration `env:"REALTIME_HEARTBEAT_INTERVAL" envDefault:"20s"`
+	// BufferSize is the number of undelivered messages after which a slow
+	// client is disconnected.
+	BufferSize int `env:"REALTIME_BUFFER_SIZE" envDefault:"64"`
+	// StreamTicketTTL is how long a ticket for opening a stream is valid.
+	StreamTicketTTL time.Duration `env:"REALTIME_STREAM_TICKET_TTL" envDefault:"30s"`
+}
+
+type MailConfig struct {
+	// Driver is "smtp", or "file" to write emails to FileDir, or "memory" to
+	// keep them in memory, for development.
+	Driver       string `env:"MAIL_DRIVER" envDefault:"file"`
+	From         string `env:"MAIL_FROM" envDefault:"EventFlow <no-reply@eventflow.local>"`
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     int    `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	// SMTPTLS is "starttls", "tls" for implicit TLS, usually on port 465, or
+	// "none".
+	SMTPTLS string `env:"SMTP_TLS" envDefault:"starttls"`
+	FileDir string `env:"MAIL_FILE_DIR" envDefault:"tmp/mail"`
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"5s"`
+	BatchSize    int           `env:"OUTBOX_BATCH_SIZE" envDefault:"20"`
+	// MaxAttempts is the number of failed deliveries after which a message
+	// is given up.
+	MaxAttempts int `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"8"`
 }
 
 type Config struct {
 	ServerAddress string `env:"SERVER_ADDRESS"`
 	ServerPort    int    `env:"SERVER_PORT"`
+	// Behind a reverse proxy, client IPs are read from ProxyHeader, e.g.
+	// X-Forwarded-For, on requests coming from TrustedProxies.
+	ProxyHeader    string   `env:"SERVER_PROXY_HEADER"`
+	TrustedProxies []string `env:"SERVER_TRUSTED_PROXIES"`
+	// AppURL is the address of the frontend that emails link to.
+	AppURL string `env:"APP_URL" envDefault:"http://localhost:3000"`
+	// Environment is "production", "development" or "test". Helpers that
+	// must never run in production, such as the mock OIDC provider, refuse
+	// to start unless it is "development" or "test".
+	Environment string `env:"APP_ENV" envDefault:"production"`
+
+	Database  DatabaseConfig
+	Minio     MinioConfig
+	JWT       JWTConfig
+	Auth      AuthConfig
+	Login     LoginConfig
+	OIDC      OIDCConfig
+	RateLimit RateLimitConfig
+	Tickets   TicketConfig
+	Payments  PaymentConfig
+	Scheduler SchedulerConfig
+	Screening ScreeningConfig
+	Reports   ReportsConfig
+	Realtime  RealtimeConfig
+	Mail      MailConfig
+	Outbox    OutboxConfig
+}
+
+// IsDevelopment reports whether the server runs for development or tests.
+func (c *Config) IsDevelopment() bool {
+	return c.Environment == "development" || c.Environment == "test"
+}
+
+// placeholderSecret is the value secrets have in .env.example.
+const placeholderSecret = "change-me"
+
+// requireSecret fails when the secret named by the environment variable is
+// unset or still the placeholder from .env.example, which would let anyone
+// forge what it signs.
+func requireSecret(name, value string) error {
+	if value == "" || value == placeholderSecret {
+		return fmt.Errorf("%s must be set to a random secret", name)
+	}
 
-	Database DatabaseConfig
-	Minio    MinioConfig
-	JWT      JWTConfig
+	return nil
 }
 
 func LoadConfig() (*Config, error) {
@@ -0,0 +1,16 @@
+package constants
+
+// AuthTokenPurpose tells what a single-use token sent by email allows.
+type AuthTokenPurpose string
+
+const (
+	AuthTokenEmailVerification AuthTokenPurpose = "email_verification"
+	AuthTokenPasswordReset     AuthTokenPurpose = "password_reset"
+	AuthTokenEmailChange       AuthTokenPurpose = "email_change"
+	// AuthTokenMFAChallenge lets a user who entered the right password
+	// finish logging in with a second factor.
+	AuthTokenMFAChallenge AuthTokenPurpose = "mfa_challenge"
+	// AuthTokenStreamTicket lets a browser open an event stream, which
+	// cannot carry the Authorization header.
+	AuthTokenStreamTicket AuthTokenPurpose = "stream_ticket"
+)
@@ -0,0 +1,34 @@
+package constants
+
+// EmailTemplate names a transactional email. Each has a Russian and an
+// English version.
+type EmailTemplate string
+
+const (
+	EmailTemplateVerifyEmail        EmailTemplate = "verify_email"
+	EmailTemplateWelcome            EmailTemplate = "welcome"
+	EmailTemplatePasswordReset      EmailTemplate = "password_reset"
+	EmailTemplateConfirmEmailChange EmailTemplate = "confirm_email_change"
+	EmailTemplateEmailChanged       EmailTemplate = "email_changed"
+	EmailTemplateEventApproved      EmailTemplate = "event_approved"
+	EmailTemplateEventRejected      EmailTemplate = "event_rejected"
+	EmailTemplateEventReminder      EmailTemplate = "event_reminder"
+)
+
+// Locale is the language users receive emails in.
+type Locale string
+
+const (
+	LocaleRU Locale = "ru"
+	LocaleEN Locale = "en"
+
+	DefaultLocale = LocaleRU
+)
+
+func (l Locale) IsValid() bool {
+	switch l {
+	case LocaleRU, LocaleEN:
+		return true
+	}
+	return false
+}
@@ -14,6 +14,9 @@ const (
 	EventModerationStatusPending  EventModerationStatus = "На модерации"
 	EventModerationStatusApproved EventModerationStatus = "Одобрено"
 	EventModerationStatusRejected EventModerationStatus = "Отклонено"
+	// EventModerationStatusHidden marks an approved event taken down while
+	// reports about it are handled.
+	EventModerationStatusHidden EventModerationStatus = "Скрыто"
 )
 
 type EventTag string
@@ -28,3 +31,19 @@ const (
 	EventTagOnline     EventTag = "Онлайн"
 	EventTagOffline    EventTag = "Оффлайн"
 )
+
+type EventSort string
+
+const (
+	EventSortDateAsc     EventSort = "date_asc"
+	EventSortDateDesc    EventSort = "date_desc"
+	EventSortCreatedDesc EventSort = "created_desc"
+)
+
+func (s EventSort) IsValid() bool {
+	switch s {
+	case EventSortDateAsc, EventSortDateDesc, EventSortCreatedDesc:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package constants
+
+// ModerationActorSystem is the actor of decisions made by automated
+// screening.
+const ModerationActorSystem = "system"
+
+type ModerationAction string
+
+const (
+	ModerationActionSubmitted   ModerationAction = "submitted"
+	ModerationActionApproved    ModerationAction = "approved"
+	ModerationActionRejected    ModerationAction = "rejected"
+	ModerationActionResubmitted ModerationAction = "resubmitted"
+	// ModerationActionEdited records that an approved event went back to
+	// moderation because its content changed.
+	ModerationActionEdited ModerationAction = "edited"
+	// ModerationActionFlagged records that automated screening found something
+	// for moderators to look at.
+	ModerationActionFlagged ModerationAction = "flagged"
+	// ModerationActionHidden and ModerationActionRestored record an event
+	// taken down and put back because of reports.
+	ModerationActionHidden   ModerationAction = "hidden"
+	ModerationActionRestored ModerationAction = "restored"
+)
@@ -0,0 +1,50 @@
+package constants
+
+// NotificationCategory groups notification types users can switch off
+// together.
+type NotificationCategory string
+
+const (
+	NotificationCategoryFriends    NotificationCategory = "friends"
+	NotificationCategoryModeration NotificationCategory = "moderation"
+	NotificationCategoryEvents     NotificationCategory = "events"
+)
+
+var NotificationCategories = []NotificationCategory{
+	NotificationCategoryFriends,
+	NotificationCategoryModeration,
+	NotificationCategoryEvents,
+}
+
+func (c NotificationCategory) IsValid() bool {
+	for _, category := range NotificationCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+type NotificationType string
+
+const (
+	NotificationTypeFriendRequest         NotificationType = "friend_request"
+	NotificationTypeFriendRequestAccepted NotificationType = "friend_request_accepted"
+	NotificationTypeEventApproved         NotificationType = "event_approved"
+	NotificationTypeEventRejected         NotificationType = "event_rejected"
+	NotificationTypeEventHidden           NotificationType = "event_hidden"
+	NotificationTypeEventChanged          NotificationType = "event_changed"
+	NotificationTypeEventCancelled        NotificationType = "event_cancelled"
+	NotificationTypeEventStarted          NotificationType = "event_started"
+)
+
+func (t NotificationType) Category() NotificationCategory {
+	switch t {
+	case NotificationTypeFriendRequest, NotificationTypeFriendRequestAccepted:
+		return NotificationCategoryFriends
+	case NotificationTypeEventApproved, NotificationTypeEventRejected, NotificationTypeEventHidden:
+		return NotificationCategoryModeration
+	default:
+		return NotificationCategoryEvents
+	}
+}
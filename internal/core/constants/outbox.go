@@ -0,0 +1,8 @@
+package constants
+
+// OutboxKind tells the outbox worker how to deliver a message.
+type OutboxKind string
+
+const (
+	OutboxKindEmail OutboxKind = "email"
+)
@@ -0,0 +1,30 @@
+package constants
+
+type OrderStatus string
+
+const (
+	OrderStatusPending  OrderStatus = "pending"
+	OrderStatusPaid     OrderStatus = "paid"
+	OrderStatusFailed   OrderStatus = "failed"
+	OrderStatusRefunded OrderStatus = "refunded"
+	// OrderStatusRefundPending marks a payment that succeeded but could not
+	// be fulfilled; its refund is retried until the provider accepts it.
+	OrderStatusRefundPending OrderStatus = "refund_pending"
+)
+
+type PaymentIntentStatus string
+
+const (
+	PaymentIntentStatusRequiresConfirmation PaymentIntentStatus = "requires_confirmation"
+	PaymentIntentStatusSucceeded            PaymentIntentStatus = "succeeded"
+	PaymentIntentStatusFailed               PaymentIntentStatus = "failed"
+	PaymentIntentStatusRefunded             PaymentIntentStatus = "refunded"
+)
+
+type PaymentEventType string
+
+const (
+	PaymentEventSucceeded PaymentEventType = "payment.succeeded"
+	PaymentEventFailed    PaymentEventType = "payment.failed"
+	PaymentEventRefunded  PaymentEventType = "payment.refunded"
+)
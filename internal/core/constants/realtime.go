@@ -0,0 +1,20 @@
+package constants
+
+// RealtimeMessageType tells clients of the realtime stream what a message
+// carries.
+type RealtimeMessageType string
+
+const (
+	RealtimeMessageFriendRequest         RealtimeMessageType = "friend_request"
+	RealtimeMessageFriendRequestAnswered RealtimeMessageType = "friend_request_answered"
+	RealtimeMessageNotification          RealtimeMessageType = "notification"
+	RealtimeMessageEventStatus           RealtimeMessageType = "event_status"
+	RealtimeMessageEventChanged          RealtimeMessageType = "event_changed"
+	RealtimeMessageEventCancelled        RealtimeMessageType = "event_cancelled"
+)
+
+// Realtime channels are named "<kind>:<id>", e.g. "user:42" or "event:7".
+const (
+	RealtimeChannelUser  = "user"
+	RealtimeChannelEvent = "event"
+)
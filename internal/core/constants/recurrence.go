@@ -0,0 +1,19 @@
+package constants
+
+// RecurrenceScope tells which occurrences of a recurring event an edit
+// applies to.
+type RecurrenceScope string
+
+const (
+	RecurrenceScopeThis      RecurrenceScope = "this"
+	RecurrenceScopeFollowing RecurrenceScope = "following"
+	RecurrenceScopeAll       RecurrenceScope = "all"
+)
+
+func (s RecurrenceScope) IsValid() bool {
+	switch s {
+	case RecurrenceScopeThis, RecurrenceScopeFollowing, RecurrenceScopeAll:
+		return true
+	}
+	return false
+}
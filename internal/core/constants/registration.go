@@ -0,0 +1,20 @@
+package constants
+
+type RegistrationStatus string
+
+const (
+	RegistrationStatusGoing      RegistrationStatus = "going"
+	RegistrationStatusMaybe      RegistrationStatus = "maybe"
+	RegistrationStatusDeclined   RegistrationStatus = "declined"
+	RegistrationStatusWaitlisted RegistrationStatus = "waitlisted"
+)
+
+// IsRSVP reports whether the status can be requested by a participant.
+// Waitlisted is assigned by the server when an event is full.
+func (s RegistrationStatus) IsRSVP() bool {
+	switch s {
+	case RegistrationStatusGoing, RegistrationStatusMaybe, RegistrationStatusDeclined:
+		return true
+	}
+	return false
+}
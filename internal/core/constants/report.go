@@ -0,0 +1,86 @@
+package constants
+
+type ReportTargetType string
+
+const (
+	ReportTargetEvent ReportTargetType = "event"
+	ReportTargetUser  ReportTargetType = "user"
+	// ReportTargetComment is reserved for comments, which cannot be reported
+	// yet.
+	ReportTargetComment ReportTargetType = "comment"
+)
+
+func (t ReportTargetType) IsValid() bool {
+	switch t {
+	case ReportTargetEvent, ReportTargetUser, ReportTargetComment:
+		return true
+	}
+	return false
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "spam"
+	ReportReasonFraud          ReportReason = "fraud"
+	ReportReasonOffensive      ReportReason = "offensive"
+	ReportReasonHarassment     ReportReason = "harassment"
+	ReportReasonMisinformation ReportReason = "misinformation"
+	ReportReasonDangerous      ReportReason = "dangerous"
+	ReportReasonOther          ReportReason = "other"
+)
+
+func (r ReportReason) IsValid() bool {
+	switch r {
+	case ReportReasonSpam, ReportReasonFraud, ReportReasonOffensive, ReportReasonHarassment,
+		ReportReasonMisinformation, ReportReasonDangerous, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+type AbuseCaseStatus string
+
+const (
+	AbuseCaseStatusOpen          AbuseCaseStatus = "open"
+	AbuseCaseStatusInvestigating AbuseCaseStatus = "investigating"
+	AbuseCaseStatusResolved      AbuseCaseStatus = "resolved"
+	AbuseCaseStatusDismissed     AbuseCaseStatus = "dismissed"
+)
+
+func (s AbuseCaseStatus) IsValid() bool {
+	switch s {
+	case AbuseCaseStatusOpen, AbuseCaseStatusInvestigating, AbuseCaseStatusResolved, AbuseCaseStatusDismissed:
+		return true
+	}
+	return false
+}
+
+// IsClosed reports whether the case is finished. Reports about the same
+// target open a new case.
+func (s AbuseCaseStatus) IsClosed() bool {
+	return s == AbuseCaseStatusResolved || s == AbuseCaseStatusDismissed
+}
+
+// AbuseCaseResolution is what a moderator did about the reported content
+// when resolving a case.
+type AbuseCaseResolution string
+
+const (
+	AbuseCaseResolutionHide AbuseCaseResolution = "hide"
+	// AbuseCaseResolutionReject rejects a reported event; its organizer sees
+	// the note as the rejection reason.
+	AbuseCaseResolutionReject AbuseCaseResolution = "reject"
+	// AbuseCaseResolutionRestore keeps the content after all, making it
+	// visible again if it was hidden.
+	AbuseCaseResolutionRestore AbuseCaseResolution = "restore"
+)
+
+type AbuseCaseAction string
+
+const (
+	AbuseCaseActionStatusChanged   AbuseCaseAction = "status_changed"
+	AbuseCaseActionContentHidden   AbuseCaseAction = "content_hidden"
+	AbuseCaseActionContentRestored AbuseCaseAction = "content_restored"
+	AbuseCaseActionEventRejected   AbuseCaseAction = "event_rejected"
+)
@@ -0,0 +1,11 @@
+package constants
+
+// ScreeningVerdict is what automated screening decided about an event.
+type ScreeningVerdict string
+
+const (
+	ScreeningVerdictApprove ScreeningVerdict = "approve"
+	ScreeningVerdictReject  ScreeningVerdict = "reject"
+	// ScreeningVerdictReview leaves the event to human moderators.
+	ScreeningVerdictReview ScreeningVerdict = "review"
+)
@@ -0,0 +1,35 @@
+package constants
+
+// SecurityEventType names an entry in the security event log.
+type SecurityEventType string
+
+const (
+	// SecurityEventLoginFailed is a login with a wrong email or password.
+	SecurityEventLoginFailed SecurityEventType = "login_failed"
+	// SecurityEventLoginThrottled is a login refused without checking the
+	// password because of earlier failures.
+	SecurityEventLoginThrottled SecurityEventType = "login_throttled"
+	SecurityEventAccountLocked  SecurityEventType = "account_locked"
+	SecurityEventIPLocked       SecurityEventType = "ip_locked"
+	// SecurityEventReauthFailed is a wrong password given by a signed-in
+	// user to confirm a sensitive change.
+	SecurityEventReauthFailed SecurityEventType = "reauth_failed"
+	// SecurityEventMFAFailed is a wrong two-factor code after the right
+	// password.
+	SecurityEventMFAFailed          SecurityEventType = "mfa_failed"
+	SecurityEventMFAEnabled         SecurityEventType = "mfa_enabled"
+	SecurityEventMFADisabled        SecurityEventType = "mfa_disabled"
+	SecurityEventRecoveryCodeUsed   SecurityEventType = "recovery_code_used"
+	SecurityEventRecoveryCodesReset SecurityEventType = "recovery_codes_reset"
+)
+
+func (t SecurityEventType) IsValid() bool {
+	switch t {
+	case SecurityEventLoginFailed, SecurityEventLoginThrottled, SecurityEventAccountLocked, SecurityEventIPLocked,
+		SecurityEventReauthFailed,
+		SecurityEventMFAFailed, SecurityEventMFAEnabled, SecurityEventMFADisabled,
+		SecurityEventRecoveryCodeUsed, SecurityEventRecoveryCodesReset:
+		return true
+	}
+	return false
+}
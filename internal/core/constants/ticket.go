@@ -0,0 +1,8 @@
+package constants
+
+type TicketStatus string
+
+const (
+	TicketStatusValid     TicketStatus = "valid"
+	TicketStatusCancelled TicketStatus = "cancelled"
+)
@@ -0,0 +1,18 @@
+package constants
+
+// Topic names a kind of message published on the message bus.
+type Topic string
+
+const (
+	TopicEventStatusChanged Topic = "event.status_changed"
+	TopicEventModerated     Topic = "event.moderated"
+	// TopicEventChanged is published when the time or place of an event
+	// changes.
+	TopicEventChanged   Topic = "event.changed"
+	TopicEventCancelled Topic = "event.cancelled"
+
+	TopicFriendRequestSent     Topic = "friend.request_sent"
+	TopicFriendRequestAnswered Topic = "friend.request_answered"
+
+	TopicNotificationCreated Topic = "notification.created"
+)
@@ -0,0 +1,64 @@
+package constants
+
+type UserRole string
+
+const (
+	UserRoleParticipant UserRole = "participant"
+	UserRoleOrganizer   UserRole = "organizer"
+	UserRoleModerator   UserRole = "moderator"
+	UserRoleAdmin       UserRole = "admin"
+)
+
+var UserRoles = []UserRole{UserRoleParticipant, UserRoleOrganizer, UserRoleModerator, UserRoleAdmin}
+
+type Permission string
+
+const (
+	PermissionCreateEvent    Permission = "event:create"
+	PermissionManageAnyEvent Permission = "event:manage_any"
+	PermissionModerateEvents Permission = "event:moderate"
+	PermissionManageRoles    Permission = "user:manage_roles"
+	PermissionHandleReports  Permission = "report:handle"
+	PermissionViewSecurity   Permission = "security:view"
+)
+
+var rolePermissions = map[UserRole][]Permission{
+	UserRoleParticipant: {},
+	UserRoleOrganizer: {
+		PermissionCreateEvent,
+	},
+	UserRoleModerator: {
+		PermissionCreateEvent,
+		PermissionManageAnyEvent,
+		PermissionModerateEvents,
+		PermissionHandleReports,
+	},
+	UserRoleAdmin: {
+		PermissionCreateEvent,
+		PermissionManageAnyEvent,
+		PermissionModerateEvents,
+		PermissionHandleReports,
+		PermissionManageRoles,
+		PermissionViewSecurity,
+	},
+}
+
+func (r UserRole) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// IsSelfAssignable reports whether the role may be picked by the user at
+// registration. Privileged roles can only be granted by an admin.
+func (r UserRole) IsSelfAssignable() bool {
+	return r == UserRoleParticipant || r == UserRoleOrganizer
+}
+
+func (r UserRole) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,20 @@
 package models
 
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
 type RegistrationCredentials struct {
-	Email        string `json:"email" validate:"required,email"`
-	Password     string `json:"password" validate:"required,min=8"`
-	Name         string `json:"name" validate:"required"`
-	Role         string `json:"role" validate:"required"`
-	Description  string `json:"description" validate:"required"`
-	ActivityArea string `json:"activity_area" validate:"required"`
+	Email        string             `json:"email" validate:"required,email"`
+	Password     string             `json:"password" validate:"required,min=8"`
+	Name         string             `json:"name" validate:"required"`
+	Role         constants.UserRole `json:"role"`
+	Description  string             `json:"description" validate:"required"`
+	ActivityArea string             `json:"activity_area" validate:"required"`
+	// Locale defaults to Russian.
+	Locale constants.Locale `json:"locale"`
 }
 
 type LoginCredentials struct {
@@ -14,10 +22,37 @@ type LoginCredentials struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// AuthResponse carries the tokens of a new session. When the user has
+// two-factor authentication enabled, login returns MFARequired and an
+// MFAToken to finish logging in with instead.
 type AuthResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
 }
 
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	ID        string             `json:"id"`
+	Email     string             `json:"email"`
+	Role      constants.UserRole `json:"role"`
+	SessionID string             `json:"session_id"`
+	// EmailVerified is false until the user confirms their email address.
+	EmailVerified bool `json:"email_verified"`
+	// MFASetupRequired is set while the user's role requires two-factor
+	// authentication that the user has not enabled. The role grants no
+	// permissions until they do.
+	MFASetupRequired bool `json:"mfa_setup_required"`
+	// ExpiresAt is when the access token the request was authenticated
+	// with expires.
+	ExpiresAt time.Time `json:"-"`
+}
+
+func (p *Principal) Can(permission constants.Permission) bool {
+	return p != nil && !p.MFASetupRequired && p.Role.Can(permission)
+}
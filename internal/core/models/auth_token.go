@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// AuthToken is a single-use token emailed to a user. Only its hash is
+// stored.
+type AuthToken struct {
+	ID        string                     `gorm:"primaryKey"`
+	UserID    string                     `gorm:"not null"`
+	Purpose   constants.AuthTokenPurpose `gorm:"not null"`
+	TokenHash string                     `gorm:"not null"`
+	// NewEmail is the address an email change token confirms.
+	NewEmail  *string
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+
+	// SessionID and AccessExpiresAt are the session and the access token
+	// expiry a stream ticket stands in for.
+	SessionID       *string
+	AccessExpiresAt *time.Time
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type ChangeEmailRequest struct {
+	Password string `json:"password"`
+	NewEmail string `json:"new_email"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
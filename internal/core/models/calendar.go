@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// CalendarToken is the secret that authorizes a user's calendar feed. Only
+// its hash is stored.
+type CalendarToken struct {
+	UserID    string    `json:"userId" gorm:"primaryKey"`
+	TokenHash string    `json:"-" gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CalendarTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// Calendar is a rendered iCalendar document and the entity tag of its
+// content.
+type Calendar struct {
+	Body []byte
+	ETag string
+}
@@ -0,0 +1,19 @@
+package models
+
+import "github.com/EventFlow-Project/backend/internal/core/constants"
+
+// EmailMessage is an email waiting in the outbox to be rendered and sent.
+type EmailMessage struct {
+	To       string                  `json:"to"`
+	Template constants.EmailTemplate `json:"template"`
+	Locale   constants.Locale        `json:"locale"`
+	Data     map[string]string       `json:"data"`
+}
+
+// Email is a rendered email ready to be sent.
+type Email struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
@@ -3,45 +3,69 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"gorm.io/gorm"
 )
 
 type Event struct {
-	ID               string                    `json:"id" gorm:"primaryKey"`
-	Title            string                    `json:"title" gorm:"not null"`
-	Description      string                    `json:"description"`
-	Date             string                    `json:"date" gorm:"not null"`
-	Duration         string                    `json:"duration" gorm:"not null"`
-	Organizer        string                    `json:"organizer" gorm:"not null"`
-	Status           constants.EventStatus     `json:"status" gorm:"not null"`
-	ModerationStatus constants.EventModerationStatus `json:"moderationStatus" gorm:"not null"`
-	Location         Location                  `json:"location" gorm:"embedded"`
-	Tags             Tags                      `json:"tags" gorm:"type:jsonb;not null;default:'[]'"`
-	Image            *string                   `json:"image,omitempty"`
-	CreatedAt        time.Time                 `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt        time.Time                 `json:"updatedAt" gorm:"autoUpdateTime"`
+	ID                   string                          `json:"id" gorm:"primaryKey"`
+	Title                string                          `json:"title" gorm:"not null"`
+	Description          string                          `json:"description"`
+	Date                 time.Time                       `json:"date" gorm:"not null"`
+	EndDate              time.Time                       `json:"endDate" gorm:"not null"`
+	Timezone             string                          `json:"timezone" gorm:"not null;default:'UTC'"`
+	LocalDate            string                          `json:"localDate" gorm:"-"`
+	LocalEndDate         string                          `json:"localEndDate" gorm:"-"`
+	Recurrence           string                          `json:"recurrence,omitempty" gorm:"not null;default:''"`
+	RecurrenceExceptions Times                           `json:"recurrenceExceptions,omitempty" gorm:"type:jsonb;not null;default:'[]'"`
+	RecurrenceEnd        *time.Time                      `json:"recurrenceEnd,omitempty"`
+	Organizer            string                          `json:"organizer" gorm:"not null"`
+	CoOrganizers         UserIDs                         `json:"coOrganizers" gorm:"type:jsonb;not null;default:'[]'"`
+	Status               constants.EventStatus           `json:"status" gorm:"not null"`
+	ModerationStatus     constants.EventModerationStatus `json:"moderationStatus" gorm:"not null"`
+	ModerationReason     string                          `json:"moderationReason,omitempty"`
+	SubmittedAt          time.Time                       `json:"submittedAt" gorm:"not null"`
+	ScreeningScore       float64                         `json:"-" gorm:"not null;default:0"`
+	ScreeningFindings    ScreeningFindings               `json:"-" gorm:"type:jsonb;not null;default:'[]'"`
+	Location             Location                        `json:"location" gorm:"embedded"`
+	Tags                 Tags                            `json:"tags" gorm:"type:jsonb;not null;default:'[]'"`
+	Capacity             *int                            `json:"capacity,omitempty"`
+	Image                *string                         `json:"image,omitempty" gorm:"column:event_image"`
+	CreatedAt            time.Time                       `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time                       `json:"updatedAt" gorm:"autoUpdateTime"`
 }
 
 type EventRequest struct {
-	Title            string                    `json:"title" gorm:"not null"`
-	Description      string                    `json:"description"`
-	Date             string                    `json:"date" gorm:"not null"`
-	Duration         string                    `json:"duration" gorm:"not null"`
-	Organizer        string                    `json:"organizer" gorm:"not null"`
-	Status           constants.EventStatus     `json:"status" gorm:"not null"`
-	ModerationStatus constants.EventModerationStatus `json:"moderationStatus" gorm:"not null"`
-	Location         Location                  `json:"location" gorm:"embedded"`
-	Tags             Tags                      `json:"tags" gorm:"type:jsonb;not null;default:'[]'"`
-	Image            *string                   `json:"image,omitempty"`
+	ID                   string                          `json:"-" gorm:"-"`
+	Title                string                          `json:"title" gorm:"not null"`
+	Description          string                          `json:"description"`
+	Date                 string                          `json:"date" gorm:"not null"`
+	EndDate              string                          `json:"endDate"`
+	Duration             string                          `json:"duration,omitempty"`
+	Timezone             string                          `json:"timezone"`
+	Recurrence           string                          `json:"recurrence,omitempty"`
+	RecurrenceExceptions Times                           `json:"recurrenceExceptions,omitempty"`
+	CoOrganizers         UserIDs                         `json:"coOrganizers"`
+	Status               constants.EventStatus           `json:"status" gorm:"not null"`
+	ModerationStatus     constants.EventModerationStatus `json:"moderationStatus" gorm:"not null"`
+	Location             Location                        `json:"location" gorm:"embedded"`
+	Tags                 Tags                            `json:"tags" gorm:"type:jsonb;not null;default:'[]'"`
+	Capacity             *int                            `json:"capacity,omitempty"`
+	Image                *string                         `json:"image,omitempty"`
 }
 
 type Location struct {
 	Lat     float64 `json:"lat" gorm:"not null"`
 	Lng     float64 `json:"lng" gorm:"not null"`
 	Address string  `json:"address" gorm:"not null"`
-	Image   *string `json:"image,omitempty"`
+	Image   *string `json:"image,omitempty" gorm:"column:location_image"`
 }
 
 type Tag struct {
@@ -65,7 +89,7 @@ func (t *Tags) Scan(value interface{}) error {
 		*t = Tags{}
 		return nil
 	}
-	
+
 	var bytes []byte
 	switch v := value.(type) {
 	case []byte:
@@ -75,6 +99,159 @@ func (t *Tags) Scan(value interface{}) error {
 	default:
 		return nil
 	}
-	
+
 	return json.Unmarshal(bytes, t)
-}
\ No newline at end of file
+}
+
+type UserIDs []string
+
+func (u UserIDs) Value() (driver.Value, error) {
+	if u == nil {
+		return "[]", nil
+	}
+	return json.Marshal(u)
+}
+
+func (u *UserIDs) Scan(value interface{}) error {
+	if value == nil {
+		*u = UserIDs{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return nil
+	}
+
+	return json.Unmarshal(bytes, u)
+}
+
+func (u UserIDs) Contains(userID string) bool {
+	for _, id := range u {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Event) IsOrganizedBy(userID string) bool {
+	return e.Organizer == userID
+}
+
+func (e *Event) IsCoOrganizedBy(userID string) bool {
+	return e.CoOrganizers.Contains(userID)
+}
+
+// CanBeManagedBy reports whether the user organizes the event, either as its
+// owner or as a co-organizer.
+func (e *Event) CanBeManagedBy(userID string) bool {
+	return e.IsOrganizedBy(userID) || e.IsCoOrganizedBy(userID)
+}
+
+// AfterFind fills the venue-local times of loaded events.
+func (e *Event) AfterFind(tx *gorm.DB) error {
+	e.Localize()
+	return nil
+}
+
+// Localize normalizes the event times to UTC and renders them in the venue
+// timezone.
+func (e *Event) Localize() {
+	loc := LoadLocation(e.Timezone)
+
+	e.Date = e.Date.UTC()
+	e.EndDate = e.EndDate.UTC()
+	e.LocalDate = e.Date.In(loc).Format(time.RFC3339)
+	e.LocalEndDate = e.EndDate.In(loc).Format(time.RFC3339)
+}
+
+var locations sync.Map
+
+// LoadLocation returns the IANA timezone by name, falling back to UTC for
+// unknown names.
+func LoadLocation(name string) *time.Location {
+	if cached, ok := locations.Load(name); ok {
+		return cached.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	locations.Store(name, loc)
+	return loc
+}
+
+// IsRecurring reports whether the event repeats. Recurrence holds an RFC 5545
+// RRULE value whose first occurrence starts at Date.
+func (e *Event) IsRecurring() bool {
+	return e.Recurrence != ""
+}
+
+// StatusAt derives the lifecycle status of the event from its schedule. A
+// recurring event is underway from its first occurrence until the end of its
+// last one, and forever when the recurrence has no end.
+func (e *Event) StatusAt(now time.Time) constants.EventStatus {
+	switch {
+	case now.Before(e.Date):
+		return constants.EventStatusComingUp
+	case e.IsRecurring() && e.RecurrenceEnd == nil:
+		return constants.EventStatusUnderway
+	case e.IsRecurring() && now.Before(*e.RecurrenceEnd):
+		return constants.EventStatusUnderway
+	case !e.IsRecurring() && now.Before(e.EndDate):
+		return constants.EventStatusUnderway
+	default:
+		return constants.EventStatusHeld
+	}
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ParseEventDuration accepts ISO 8601 durations ("PT2H30M", "P1DT2H") and Go
+// style durations ("2h30m").
+func ParseEventDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if match := isoDurationPattern.FindStringSubmatch(strings.ToUpper(value)); match != nil {
+		units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+		var total time.Duration
+		for i, unit := range units {
+			if match[i+1] == "" {
+				continue
+			}
+			n, err := strconv.Atoi(match[i+1])
+			if err != nil {
+				return 0, err
+			}
+			total += time.Duration(n) * unit
+		}
+		if total > 0 {
+			return total, nil
+		}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	return duration, nil
+}
+
+// EventStatusChange is published when an event moves to another lifecycle
+// status.
+type EventStatusChange struct {
+	EventID   string                `json:"eventId"`
+	Organizer string                `json:"organizer"`
+	From      constants.EventStatus `json:"from"`
+	To        constants.EventStatus `json:"to"`
+	ChangedAt time.Time             `json:"changedAt"`
+}
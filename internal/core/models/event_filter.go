@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+type EventFilter struct {
+	From              *time.Time
+	To                *time.Time
+	Statuses          []constants.EventStatus
+	ModerationStatus  constants.EventModerationStatus
+	TagIDs            []string
+	Organizer         string
+	Query             string
+	Sort              constants.EventSort
+	Cursor            string
+	Limit             int
+	IncludeUnapproved bool
+}
+
+// EventCursor is the position after the last event of a page: the value of
+// the sort column and the event ID as a tie-breaker.
+type EventCursor struct {
+	Value time.Time `json:"v"`
+	ID    string    `json:"id"`
+}
+
+type EventPage struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+}
+
+// SortValue returns the value of the column events are paged by for the given
+// sort order.
+func (e *Event) SortValue(sort constants.EventSort) time.Time {
+	if sort == constants.EventSortCreatedDesc {
+		return e.CreatedAt
+	}
+	return e.Date
+}
+
+// EventSearchResult is an event matched by full-text search. Highlighted
+// fragments wrap matched words in <mark> tags.
+type EventSearchResult struct {
+	Event          `gorm:"embedded"`
+	Rank           float64 `json:"rank" gorm:"column:rank"`
+	TitleHighlight string  `json:"titleHighlight" gorm:"column:title_highlight"`
+	Snippet        string  `json:"snippet" gorm:"column:snippet"`
+}
@@ -0,0 +1,55 @@
+package models
+
+// BoundingBox is a rectangle in degrees. MinLng greater than MaxLng means the
+// box crosses the antimeridian.
+type BoundingBox struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+// LngSpan is the width of the box in degrees of longitude.
+func (b BoundingBox) LngSpan() float64 {
+	if b.CrossesAntimeridian() {
+		return b.MaxLng - b.MinLng + 360
+	}
+	return b.MaxLng - b.MinLng
+}
+
+type NearbyQuery struct {
+	Lat      float64
+	Lng      float64
+	RadiusKm float64
+	// Box encloses the search circle and lets the database prune rows by
+	// index before computing exact distances.
+	Box   BoundingBox
+	Limit int
+}
+
+type EventWithDistance struct {
+	Event      `gorm:"embedded"`
+	DistanceKm float64 `json:"distanceKm" gorm:"column:distance_km"`
+}
+
+// EventCluster groups the events of one grid cell of a map viewport.
+type EventCluster struct {
+	Lat    float64 `json:"lat"`
+	Lng    float64 `json:"lng"`
+	Count  int     `json:"count"`
+	MinLat float64 `json:"minLat"`
+	MinLng float64 `json:"minLng"`
+	MaxLat float64 `json:"maxLat"`
+	MaxLng float64 `json:"maxLng"`
+}
+
+// MapViewport holds either individual events or, when the viewport contains
+// too many of them, clusters.
+type MapViewport struct {
+	Events   []Event        `json:"events,omitempty"`
+	Clusters []EventCluster `json:"clusters,omitempty"`
+}
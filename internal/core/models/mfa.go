@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// MFARecoveryCode is a one-time code that replaces a two-factor code when
+// the user has lost their authenticator. Only its hash is stored.
+type MFARecoveryCode struct {
+	ID        string `gorm:"primaryKey"`
+	UserID    string `gorm:"not null"`
+	CodeHash  string `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// RoleMFAPolicy tells whether users with the role must enable two-factor
+// authentication.
+type RoleMFAPolicy struct {
+	Role      constants.UserRole `json:"role" gorm:"primaryKey"`
+	Required  bool               `json:"required" gorm:"not null"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type MFAStatus struct {
+	Enabled bool `json:"enabled"`
+	// Required is set when the user's role requires two-factor
+	// authentication.
+	Required          bool  `json:"required"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+}
+
+// MFASetup is what an authenticator app needs to generate codes: the
+// secret, as text, as an otpauth URI, and as a QR code of the URI.
+type MFASetup struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	// QRCode is a PNG image as a data URI.
+	QRCode string `json:"qr_code"`
+}
+
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFASetupRequest struct {
+	Password string `json:"password"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+type MFADisableRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// MFALoginRequest finishes a login with either a code from the
+// authenticator app or a recovery code.
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type UpdateMFAPolicyRequest struct {
+	Required bool `json:"required"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// ModerationAction is an entry of an event's moderation history.
+type ModerationAction struct {
+	ID        string                     `json:"id" gorm:"primaryKey"`
+	EventID   string                     `json:"eventId" gorm:"not null"`
+	ActorID   string                     `json:"actorId" gorm:"not null"`
+	Action    constants.ModerationAction `json:"action" gorm:"not null"`
+	Reason    string                     `json:"reason,omitempty"`
+	CreatedAt time.Time                  `json:"createdAt"`
+}
+
+// ModerationClaim marks an event as being reviewed by a moderator until
+// ExpiresAt, so that other moderators skip it.
+type ModerationClaim struct {
+	EventID     string    `json:"eventId" gorm:"primaryKey"`
+	ModeratorID string    `json:"moderatorId" gorm:"not null"`
+	ExpiresAt   time.Time `json:"expiresAt" gorm:"not null"`
+}
+
+type ModerationDecisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ModerationQueueItem struct {
+	Event          `gorm:"embedded"`
+	ClaimedBy      *string    `json:"claimedBy,omitempty" gorm:"column:claimed_by"`
+	ClaimExpiresAt *time.Time `json:"claimExpiresAt,omitempty" gorm:"column:claim_expires_at"`
+	// Screening is what automated screening found in the event; it is only
+	// shown to moderators.
+	Screening *ScreeningResult `json:"screening,omitempty" gorm:"-"`
+}
+
+type ModerationQueuePage struct {
+	Events     []ModerationQueueItem `json:"events"`
+	NextCursor string                `json:"nextCursor,omitempty"`
+}
+
+// EventModeration is published when a moderator approves or rejects an
+// event.
+type EventModeration struct {
+	EventID     string                          `json:"eventId"`
+	Title       string                          `json:"title"`
+	Organizer   string                          `json:"organizer"`
+	ModeratorID string                          `json:"moderatorId"`
+	Status      constants.EventModerationStatus `json:"status"`
+	Reason      string                          `json:"reason,omitempty"`
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+type Notification struct {
+	ID        string                         `json:"id" gorm:"primaryKey"`
+	UserID    string                         `json:"userId" gorm:"not null"`
+	Type      constants.NotificationType     `json:"type" gorm:"not null"`
+	Category  constants.NotificationCategory `json:"category" gorm:"not null"`
+	Title     string                         `json:"title" gorm:"not null"`
+	Body      string                         `json:"body"`
+	Data      NotificationData               `json:"data" gorm:"type:jsonb;not null;default:'{}'"`
+	ReadAt    *time.Time                     `json:"readAt,omitempty"`
+	CreatedAt time.Time                      `json:"createdAt"`
+}
+
+// NotificationData holds the IDs a client needs to open what a notification
+// is about, such as "eventId" or "userId".
+type NotificationData map[string]string
+
+func (d NotificationData) Value() (driver.Value, error) {
+	if d == nil {
+		return "{}", nil
+	}
+	return json.Marshal(d)
+}
+
+func (d *NotificationData) Scan(value interface{}) error {
+	if value == nil {
+		*d = NotificationData{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return nil
+	}
+
+	return json.Unmarshal(bytes, d)
+}
+
+type NotificationPreference struct {
+	UserID    string                         `json:"-" gorm:"primaryKey"`
+	Category  constants.NotificationCategory `json:"category" gorm:"primaryKey"`
+	Enabled   bool                           `json:"enabled" gorm:"not null"`
+	UpdatedAt time.Time                      `json:"-"`
+}
+
+type NotificationFilter struct {
+	UnreadOnly bool
+	Category   constants.NotificationCategory
+	Cursor     string
+	Limit      int
+}
+
+type NotificationInbox struct {
+	Notifications    []Notification                           `json:"notifications"`
+	Unread           int64                                    `json:"unread"`
+	UnreadByCategory map[constants.NotificationCategory]int64 `json:"unreadByCategory"`
+	NextCursor       string                                   `json:"nextCursor,omitempty"`
+}
+
+type MarkNotificationsReadRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// NotificationPreferences maps every category to whether the user wants
+// notifications of it.
+type NotificationPreferences map[constants.NotificationCategory]bool
+
+// FriendRequestNotice is published when a user asks another to be friends.
+type FriendRequestNotice struct {
+	RequestID string `json:"requestId"`
+	FromID    string `json:"fromId"`
+	ToID      string `json:"toId"`
+}
+
+// FriendRequestAnswer is published when a friend request is accepted or
+// declined.
+type FriendRequestAnswer struct {
+	RequestID string `json:"requestId"`
+	FromID    string `json:"fromId"`
+	ToID      string `json:"toId"`
+	Accepted  bool   `json:"accepted"`
+}
+
+// EventChange is published when the time or place of an event changes.
+type EventChange struct {
+	EventID   string `json:"eventId"`
+	Title     string `json:"title"`
+	ChangedBy string `json:"changedBy"`
+}
+
+// EventCancellation is published when an event is deleted. Its registrations
+// are gone by then, so it carries the users who had registered.
+type EventCancellation struct {
+	EventID     string   `json:"eventId"`
+	Title       string   `json:"title"`
+	CancelledBy string   `json:"cancelledBy"`
+	AttendeeIDs []string `json:"attendeeIds,omitempty"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// UserIdentity links a user to their account at an OpenID Connect provider.
+type UserIdentity struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	UserID   string `json:"-" gorm:"not null"`
+	Provider string `json:"provider" gorm:"not null"`
+	// Subject identifies the account at the provider.
+	Subject string `json:"-" gorm:"not null"`
+	// Email is the address the provider confirmed when the identity was
+	// linked.
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OIDCAuthRequest remembers a login started at a provider until the user
+// comes back with the authorization code. Only the hash of the state is
+// stored.
+type OIDCAuthRequest struct {
+	ID           string    `gorm:"primaryKey"`
+	Provider     string    `gorm:"not null"`
+	StateHash    string    `gorm:"not null"`
+	Nonce        string    `gorm:"not null"`
+	CodeVerifier string    `gorm:"not null"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	CreatedAt    time.Time
+}
+
+// OIDCClaims are the claims of a verified ID token that login needs.
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Locale        string
+	Nonce         string
+}
+
+type OIDCProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+type OIDCStartResponse struct {
+	// AuthorizationURL is the provider's login page to send the user to.
+	AuthorizationURL string `json:"authorization_url"`
+	// State is kept in a cookie, so that only the browser that started the
+	// login can complete it.
+	State string `json:"-"`
+}
+
+// OIDCCallbackRequest carries the query parameters the provider redirected
+// the user back with.
+type OIDCCallbackRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
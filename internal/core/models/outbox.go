@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// OutboxMessage is a side effect, such as an email, recorded in the same
+// transaction as the change that caused it and delivered afterwards.
+type OutboxMessage struct {
+	ID      string               `json:"id" gorm:"primaryKey"`
+	Kind    constants.OutboxKind `json:"kind" gorm:"not null"`
+	Payload string               `json:"payload" gorm:"type:jsonb;not null"`
+	// Attempts counts failed deliveries.
+	Attempts      int        `json:"attempts" gorm:"not null"`
+	NextAttemptAt time.Time  `json:"nextAttemptAt" gorm:"not null"`
+	LastError     string     `json:"lastError,omitempty"`
+	SentAt        *time.Time `json:"sentAt,omitempty"`
+	// FailedAt is set when delivery is given up.
+	FailedAt  *time.Time `json:"failedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
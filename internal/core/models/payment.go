@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+type Order struct {
+	ID                string                `json:"id" gorm:"primaryKey"`
+	UserID            string                `json:"user_id" gorm:"not null"`
+	EventID           string                `json:"event_id" gorm:"not null"`
+	TicketTypeID      string                `json:"ticket_type_id" gorm:"not null"`
+	TicketID          *string               `json:"ticket_id,omitempty"`
+	Amount            int64                 `json:"amount" gorm:"not null"`
+	Currency          string                `json:"currency" gorm:"not null"`
+	Status            constants.OrderStatus `json:"status" gorm:"not null"`
+	Provider          string                `json:"provider" gorm:"not null"`
+	ProviderPaymentID string                `json:"provider_payment_id" gorm:"not null"`
+	CreatedAt         time.Time             `json:"created_at"`
+	UpdatedAt         time.Time             `json:"updated_at"`
+}
+
+type CreateOrderRequest struct {
+	TicketTypeID string `json:"ticket_type_id" validate:"required"`
+}
+
+type OrderResponse struct {
+	Order  *Order         `json:"order"`
+	Intent *PaymentIntent `json:"intent,omitempty"`
+}
+
+type PaymentIntentRequest struct {
+	OrderID     string
+	Amount      int64
+	Currency    string
+	Description string
+}
+
+type PaymentIntent struct {
+	ID              string                        `json:"id"`
+	Amount          int64                         `json:"amount"`
+	Currency        string                        `json:"currency"`
+	Status          constants.PaymentIntentStatus `json:"status"`
+	ClientSecret    string                        `json:"client_secret,omitempty"`
+	ConfirmationURL string                        `json:"confirmation_url,omitempty"`
+}
+
+// PaymentWebhookEvent is a provider notification after its signature has
+// been verified.
+type PaymentWebhookEvent struct {
+	ID        string                     `json:"id"`
+	Type      constants.PaymentEventType `json:"type"`
+	PaymentID string                     `json:"payment_id"`
+}
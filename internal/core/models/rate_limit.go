@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// RateLimitCounter counts hits on a key until ExpiresAt. The zero value is
+// an unused key.
+type RateLimitCounter struct {
+	Count     int
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// RealtimeMessage is pushed to the clients subscribed to its channel. Data
+// is kept encoded so that messages pass between replicas unchanged.
+type RealtimeMessage struct {
+	Channel string                        `json:"channel"`
+	Type    constants.RealtimeMessageType `json:"type"`
+	Data    json.RawMessage               `json:"data"`
+}
+
+// StreamTicketResponse carries a ticket for opening an event stream.
+type StreamTicketResponse struct {
+	Ticket string `json:"ticket"`
+	// ExpiresIn is how many seconds the ticket is valid for.
+	ExpiresIn int `json:"expires_in"`
+}
+
+func UserChannel(userID string) string {
+	return constants.RealtimeChannelUser + ":" + userID
+}
+
+func EventChannel(eventID string) string {
+	return constants.RealtimeChannelEvent + ":" + eventID
+}
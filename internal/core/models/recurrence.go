@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// OccurrenceOverride changes a single occurrence of a recurring event. The
+// occurrence is identified by its original start, OccurrenceDate.
+type OccurrenceOverride struct {
+	ID             string     `json:"id" gorm:"primaryKey"`
+	EventID        string     `json:"eventId" gorm:"not null"`
+	OccurrenceDate time.Time  `json:"occurrenceDate" gorm:"not null"`
+	Title          *string    `json:"title,omitempty"`
+	Description    *string    `json:"description,omitempty"`
+	Date           *time.Time `json:"date,omitempty"`
+	EndDate        *time.Time `json:"endDate,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// EventOccurrence is one occurrence of an event, with its overrides applied.
+// One-off events have a single occurrence.
+type EventOccurrence struct {
+	Event
+	OccurrenceDate time.Time `json:"occurrenceDate"`
+	Overridden     bool      `json:"overridden"`
+}
+
+type OccurrenceUpdateRequest struct {
+	Occurrence time.Time                 `json:"occurrence"`
+	Scope      constants.RecurrenceScope `json:"scope"`
+	Event      EventRequest              `json:"event"`
+}
+
+type OccurrenceDeleteRequest struct {
+	Occurrence time.Time                 `json:"occurrence"`
+	Scope      constants.RecurrenceScope `json:"scope"`
+}
+
+type Times []time.Time
+
+func (t Times) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	return json.Marshal(t)
+}
+
+func (t *Times) Scan(value interface{}) error {
+	if value == nil {
+		*t = Times{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return nil
+	}
+
+	return json.Unmarshal(bytes, t)
+}
+
+func (t Times) Contains(value time.Time) bool {
+	for _, item := range t {
+		if item.Equal(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+type Registration struct {
+	ID           string                       `json:"id" gorm:"primaryKey"`
+	EventID      string                       `json:"event_id" gorm:"not null"`
+	UserID       string                       `json:"user_id" gorm:"not null"`
+	Status       constants.RegistrationStatus `json:"status" gorm:"not null"`
+	WaitlistedAt *time.Time                   `json:"waitlisted_at,omitempty"`
+	CreatedAt    time.Time                    `json:"created_at"`
+	UpdatedAt    time.Time                    `json:"updated_at"`
+}
+
+type RegistrationRequest struct {
+	Status constants.RegistrationStatus `json:"status" validate:"required"`
+}
+
+type AttendeeResponse struct {
+	UserID       string                       `json:"user_id"`
+	Name         string                       `json:"name"`
+	Avatar       string                       `json:"avatar"`
+	Status       constants.RegistrationStatus `json:"status"`
+	WaitlistedAt *time.Time                   `json:"waitlisted_at,omitempty"`
+	RegisteredAt time.Time                    `json:"registered_at"`
+}
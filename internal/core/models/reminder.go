@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// EventReminder records that a user was reminded of an occurrence of an
+// event, so that they are reminded only once.
+type EventReminder struct {
+	EventID        string    `gorm:"primaryKey"`
+	OccurrenceDate time.Time `gorm:"primaryKey"`
+	UserID         string    `gorm:"primaryKey"`
+	CreatedAt      time.Time
+}
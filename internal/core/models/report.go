@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+type Report struct {
+	ID         string                     `json:"id" gorm:"primaryKey"`
+	CaseID     string                     `json:"caseId" gorm:"not null"`
+	ReporterID string                     `json:"reporterId" gorm:"not null"`
+	TargetType constants.ReportTargetType `json:"targetType" gorm:"not null"`
+	TargetID   string                     `json:"targetId" gorm:"not null"`
+	Reason     constants.ReportReason     `json:"reason" gorm:"not null"`
+	Comment    string                     `json:"comment,omitempty"`
+	CreatedAt  time.Time                  `json:"createdAt"`
+}
+
+type ReportRequest struct {
+	TargetType constants.ReportTargetType `json:"targetType"`
+	TargetID   string                     `json:"targetId"`
+	Reason     constants.ReportReason     `json:"reason"`
+	Comment    string                     `json:"comment"`
+}
+
+// AbuseCase gathers the reports about one piece of content until a moderator
+// closes it.
+type AbuseCase struct {
+	ID            string                         `json:"id" gorm:"primaryKey"`
+	TargetType    constants.ReportTargetType     `json:"targetType" gorm:"not null"`
+	TargetID      string                         `json:"targetId" gorm:"not null"`
+	Status        constants.AbuseCaseStatus      `json:"status" gorm:"not null"`
+	ReporterCount int                            `json:"reporterCount" gorm:"not null"`
+	Hidden        bool                           `json:"hidden" gorm:"not null"`
+	AssigneeID    *string                        `json:"assigneeId,omitempty"`
+	Resolution    *constants.AbuseCaseResolution `json:"resolution,omitempty"`
+	CreatedAt     time.Time                      `json:"createdAt"`
+	UpdatedAt     time.Time                      `json:"updatedAt"`
+	ClosedAt      *time.Time                     `json:"closedAt,omitempty"`
+}
+
+// AbuseCaseAction is an entry of the audit trail of a case. Status is the
+// status of the case after the action.
+type AbuseCaseAction struct {
+	ID        string                    `json:"id" gorm:"primaryKey"`
+	CaseID    string                    `json:"caseId" gorm:"not null"`
+	ActorID   string                    `json:"actorId" gorm:"not null"`
+	Action    constants.AbuseCaseAction `json:"action" gorm:"not null"`
+	Status    constants.AbuseCaseStatus `json:"status" gorm:"not null"`
+	Note      string                    `json:"note,omitempty"`
+	CreatedAt time.Time                 `json:"createdAt"`
+}
+
+type AbuseCaseDetails struct {
+	AbuseCase
+	Reports []Report          `json:"reports"`
+	Actions []AbuseCaseAction `json:"actions"`
+}
+
+type AbuseCaseUpdateRequest struct {
+	Status     constants.AbuseCaseStatus     `json:"status"`
+	Resolution constants.AbuseCaseResolution `json:"resolution"`
+	Note       string                        `json:"note"`
+}
+
+type AbuseCaseFilter struct {
+	Status     constants.AbuseCaseStatus
+	TargetType constants.ReportTargetType
+	Cursor     string
+	Limit      int
+}
+
+type AbuseCasePage struct {
+	Cases      []AbuseCase `json:"cases"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
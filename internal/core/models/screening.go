@@ -0,0 +1,76 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// ScreeningFinding is something a content screener found suspicious in an
+// event.
+type ScreeningFinding struct {
+	Rule   string  `json:"rule"`
+	Detail string  `json:"detail"`
+	Score  float64 `json:"score"`
+}
+
+type ScreeningFindings []ScreeningFinding
+
+func (f ScreeningFindings) Value() (driver.Value, error) {
+	if f == nil {
+		return "[]", nil
+	}
+	return json.Marshal(f)
+}
+
+func (f *ScreeningFindings) Scan(value interface{}) error {
+	if value == nil {
+		*f = ScreeningFindings{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return nil
+	}
+
+	return json.Unmarshal(bytes, f)
+}
+
+// Score adds up the scores of the findings.
+func (f ScreeningFindings) Score() float64 {
+	var score float64
+	for _, finding := range f {
+		score += finding.Score
+	}
+	return score
+}
+
+// Summary lists the details of the findings for moderators and organizers.
+func (f ScreeningFindings) Summary() string {
+	details := make([]string, len(f))
+	for i, finding := range f {
+		details[i] = finding.Detail
+	}
+	return strings.Join(details, "; ")
+}
+
+type ScreeningResult struct {
+	Verdict  constants.ScreeningVerdict `json:"verdict"`
+	Score    float64                    `json:"score"`
+	Findings ScreeningFindings          `json:"findings"`
+}
+
+// OrganizerModerationStats is the moderation track record organizers earn
+// trust with.
+type OrganizerModerationStats struct {
+	Approved         int64 `gorm:"column:approved"`
+	RecentRejections int64 `gorm:"column:recent_rejections"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// SecurityEvent is an entry in the security event log, such as a failed
+// login. Email is what was typed in, which may not belong to any user.
+type SecurityEvent struct {
+	ID        string                      `json:"id" gorm:"primaryKey"`
+	Type      constants.SecurityEventType `json:"type" gorm:"not null"`
+	UserID    *string                     `json:"userId,omitempty"`
+	Email     string                      `json:"email,omitempty"`
+	IP        string                      `json:"ip,omitempty"`
+	UserAgent string                      `json:"userAgent,omitempty"`
+	CreatedAt time.Time                   `json:"createdAt"`
+}
+
+type SecurityEventFilter struct {
+	Type   constants.SecurityEventType
+	UserID string
+	Email  string
+	IP     string
+	Cursor string
+	Limit  int
+}
+
+type SecurityEventPage struct {
+	Events     []SecurityEvent `json:"events"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+type Session struct {
+	ID               string     `json:"id" gorm:"primaryKey"`
+	UserID           string     `json:"user_id" gorm:"not null"`
+	RefreshTokenHash string     `json:"-" gorm:"not null"`
+	UserAgent        string     `json:"user_agent"`
+	IP               string     `json:"ip"`
+	ExpiresAt        time.Time  `json:"expires_at" gorm:"not null"`
+	LastUsedAt       time.Time  `json:"last_used_at" gorm:"not null"`
+	RevokedAt        *time.Time `json:"-"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// ClientInfo describes the device a session is opened from.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+func (s *Session) ToResponse(currentSessionID string) *SessionResponse {
+	return &SessionResponse{
+		ID:         s.ID,
+		UserAgent:  s.UserAgent,
+		IP:         s.IP,
+		Current:    s.ID == currentSessionID,
+		CreatedAt:  s.CreatedAt,
+		LastUsedAt: s.LastUsedAt,
+		ExpiresAt:  s.ExpiresAt,
+	}
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+type TicketType struct {
+	ID         string     `json:"id" gorm:"primaryKey"`
+	EventID    string     `json:"event_id" gorm:"not null"`
+	Name       string     `json:"name" gorm:"not null"`
+	Quota      int        `json:"quota" gorm:"not null"`
+	Price      int64      `json:"price" gorm:"not null"`
+	Currency   string     `json:"currency" gorm:"not null"`
+	SalesStart *time.Time `json:"sales_start,omitempty"`
+	SalesEnd   *time.Time `json:"sales_end,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type TicketTypeRequest struct {
+	Name       string     `json:"name" validate:"required"`
+	Quota      int        `json:"quota" validate:"required"`
+	Price      int64      `json:"price"`
+	Currency   string     `json:"currency"`
+	SalesStart *time.Time `json:"sales_start,omitempty"`
+	SalesEnd   *time.Time `json:"sales_end,omitempty"`
+}
+
+type TicketTypeResponse struct {
+	TicketType
+	Sold int64 `json:"sold"`
+}
+
+type Ticket struct {
+	ID           string                 `json:"id" gorm:"primaryKey"`
+	TicketTypeID string                 `json:"ticket_type_id" gorm:"not null"`
+	EventID      string                 `json:"event_id" gorm:"not null"`
+	UserID       string                 `json:"user_id" gorm:"not null"`
+	Status       constants.TicketStatus `json:"status" gorm:"not null"`
+	CheckedInAt  *time.Time             `json:"checked_in_at,omitempty"`
+	CheckedInBy  *string                `json:"checked_in_by,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+type TicketResponse struct {
+	Ticket
+	Code string `json:"code"`
+}
+
+type IssueTicketRequest struct {
+	TicketTypeID string `json:"ticket_type_id" validate:"required"`
+}
+
+type CheckInRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type CheckInResponse struct {
+	TicketID     string    `json:"ticket_id"`
+	TicketTypeID string    `json:"ticket_type_id"`
+	UserID       string    `json:"user_id"`
+	UserName     string    `json:"user_name"`
+	CheckedInAt  time.Time `json:"checked_in_at"`
+}
+
+type TicketTypeAttendance struct {
+	TicketTypeID string `json:"ticket_type_id"`
+	Name         string `json:"name"`
+	Issued       int64  `json:"issued"`
+	CheckedIn    int64  `json:"checked_in"`
+}
+
+type AttendanceStats struct {
+	EventID   string                 `json:"event_id"`
+	Issued    int64                  `json:"issued"`
+	CheckedIn int64                  `json:"checked_in"`
+	ByType    []TicketTypeAttendance `json:"by_type"`
+}
+
+// IsOnSale reports whether tickets of this type can be obtained at the given
+// moment according to its sales window.
+func (t *TicketType) IsOnSale(now time.Time) bool {
+	if t.SalesStart != nil && now.Before(*t.SalesStart) {
+		return false
+	}
+	if t.SalesEnd != nil && !now.Before(*t.SalesEnd) {
+		return false
+	}
+	return true
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+
 	"gorm.io/gorm"
 )
 
@@ -13,9 +15,21 @@ type User struct {
 	PasswordHash string `json:"-" gorm:"not null"`
 	Avatar       string `json:"avatar" gorm:"not null"`
 
-	Role         string `json:"role" validate:"required"`
-	Description  string `json:"description" validate:"required"`
-	ActivityArea string `json:"activity_area" validate:"required"`
+	Role         constants.UserRole `json:"role" validate:"required"`
+	Description  string             `json:"description" validate:"required"`
+	ActivityArea string             `json:"activity_area" validate:"required"`
+	// Locale is the language of the emails the user receives.
+	Locale constants.Locale `json:"locale" gorm:"not null;default:ru"`
+	// EmailVerifiedAt is set once the user follows the link emailed to them.
+	EmailVerifiedAt *time.Time `json:"-"`
+	// TOTPSecret is the encrypted two-factor secret. It is set during
+	// enrollment, but only used for login once MFAEnabledAt is set too.
+	TOTPSecret   string     `json:"-" gorm:"column:totp_secret"`
+	TOTPLastStep int64      `json:"-" gorm:"column:totp_last_step"`
+	MFAEnabledAt *time.Time `json:"-" gorm:"column:mfa_enabled_at"`
+
+	// HiddenAt is set while the profile is hidden because of reports.
+	HiddenAt *time.Time `json:"-"`
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -23,21 +37,29 @@ type User struct {
 }
 
 type SafeUser struct {
-	ID           string    `json:"id"`
-	Email        string    `json:"email"`
-	Name         string    `json:"name"`
-	Avatar       string    `json:"avatar"`
-	Role         string    `json:"role"`
-	Description  string    `json:"description"`
-	ActivityArea string    `json:"activity_area"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID            string             `json:"id"`
+	Email         string             `json:"email"`
+	Name          string             `json:"name"`
+	Avatar        string             `json:"avatar"`
+	Role          constants.UserRole `json:"role"`
+	Description   string             `json:"description"`
+	ActivityArea  string             `json:"activity_area"`
+	Locale        constants.Locale   `json:"locale"`
+	EmailVerified bool               `json:"email_verified"`
+	MFAEnabled    bool               `json:"mfa_enabled"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
 }
 
 type EditUserInfo struct {
-	Email  string `json:"email" validate:"required,email"`
 	Name   string `json:"name" validate:"required"`
 	Avatar string `json:"avatar"`
+	// Locale is left unchanged when empty.
+	Locale constants.Locale `json:"locale"`
+}
+
+type UpdateUserRole struct {
+	Role constants.UserRole `json:"role" validate:"required"`
 }
 
 type SearchUserResponse struct {
@@ -48,15 +70,18 @@ type SearchUserResponse struct {
 
 func (u *User) ToSafeUser() *SafeUser {
 	return &SafeUser{
-		ID:           u.ID,
-		Email:        u.Email,
-		Name:         u.Name,
-		Avatar:       u.Avatar,
-		Role:         u.Role,
-		Description:  u.Description,
-		ActivityArea: u.ActivityArea,
-		CreatedAt:    u.CreatedAt,
-		UpdatedAt:    u.UpdatedAt,
+		ID:            u.ID,
+		Email:         u.Email,
+		Name:          u.Name,
+		Avatar:        u.Avatar,
+		Role:          u.Role,
+		Description:   u.Description,
+		ActivityArea:  u.ActivityArea,
+		Locale:        u.Locale,
+		EmailVerified: u.EmailVerifiedAt != nil,
+		MFAEnabled:    u.MFAEnabledAt != nil,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
 	}
 }
 
@@ -1,10 +1,21 @@
 package ports
 
-import "github.com/EventFlow-Project/backend/internal/core/models"
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
 
 type AuthRepository interface {
-	CreateUserWithPassword(email, password, name, role, description, activityArea string) (*models.User, error)
+	// CreateUserWithPassword stores a new user with an already hashed password.
+	CreateUserWithPassword(ctx context.Context, email, passwordHash, name string, role constants.UserRole, description, activityArea string, locale constants.Locale) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)
 	GetUserByName(name string) (*models.User, error)
 	UpdateUser(user *models.User) error
+	MarkEmailVerified(ctx context.Context, userID string, at time.Time) error
+	UpdatePassword(ctx context.Context, userID, passwordHash string) error
+	// UpdateEmail changes the user's email to a confirmed address.
+	UpdateEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error
 }
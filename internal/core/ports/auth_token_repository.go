@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type AuthTokenRepository interface {
+	CreateToken(ctx context.Context, token *models.AuthToken) error
+	// RevokeTokens uses up the user's outstanding tokens for the purpose, so
+	// that only the newest one sent works.
+	RevokeTokens(ctx context.Context, userID string, purpose constants.AuthTokenPurpose, at time.Time) error
+	// FindToken returns the unexpired, unused token with the hash without
+	// using it up, or nil when there is no such token.
+	FindToken(ctx context.Context, purpose constants.AuthTokenPurpose, tokenHash string, now time.Time) (*models.AuthToken, error)
+	// ConsumeToken marks the unexpired, unused token with the hash as used
+	// and returns it, or returns nil when there is no such token.
+	ConsumeToken(ctx context.Context, purpose constants.AuthTokenPurpose, tokenHash string, now time.Time) (*models.AuthToken, error)
+	// DeleteSpentTokens deletes the user's used and expired tokens for the
+	// purpose.
+	DeleteSpentTokens(ctx context.Context, userID string, purpose constants.AuthTokenPurpose, now time.Time) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type CalendarRepository interface {
+	// SaveToken stores the user's feed token, replacing the previous one.
+	SaveToken(ctx context.Context, token *models.CalendarToken) error
+	GetTokenByHash(ctx context.Context, tokenHash string) (*models.CalendarToken, error)
+	DeleteToken(ctx context.Context, userID string) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+// ContentScreener inspects an event before it reaches moderators and reports
+// what looks suspicious about it. An event without findings is clean.
+type ContentScreener interface {
+	Name() string
+	Screen(ctx context.Context, event *models.Event) (models.ScreeningFindings, error)
+}
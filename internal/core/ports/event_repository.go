@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
@@ -11,10 +12,46 @@ type EventRepository interface {
 	CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error)
 	UpdateEvent(ctx context.Context, event *models.Event) error
 	DeleteEvent(ctx context.Context, eventID string) error
-	ApproveEvent(ctx context.Context, eventID string) error
-	RejectEvent(ctx context.Context, eventID string) error
 	GetEvent(ctx context.Context, eventID string) (*models.Event, error)
 	GetEventsByOrganizer(ctx context.Context, organizerID string) ([]models.Event, error)
+	// GetRecentEventsByOrganizer returns the events the organizer created
+	// since the given time, newest first.
+	GetRecentEventsByOrganizer(ctx context.Context, organizerID string, since time.Time) ([]models.Event, error)
 	GetEventsByStatus(ctx context.Context, status constants.EventStatus) ([]models.Event, error)
 	GetEventsByModerationStatus(ctx context.Context, status constants.EventModerationStatus) ([]models.Event, error)
+	// ListEvents returns up to limit events matching the filter, starting
+	// after the cursor when one is given.
+	ListEvents(ctx context.Context, filter *models.EventFilter, cursor *models.EventCursor, limit int) ([]models.Event, error)
+	// GetEventsInWindow returns approved events with an occurrence that may
+	// overlap [from, to).
+	GetEventsInWindow(ctx context.Context, from, to time.Time) ([]models.Event, error)
+	// GetCalendarEvents returns the events the user organizes or co-organizes
+	// and the approved events organized by the given friends.
+	GetCalendarEvents(ctx context.Context, userID string, friendIDs []string) ([]models.Event, error)
+	GetOccurrenceOverrides(ctx context.Context, eventIDs []string) ([]models.OccurrenceOverride, error)
+	UpsertOccurrenceOverride(ctx context.Context, override *models.OccurrenceOverride) error
+	// DeleteOccurrenceOverrides removes the overrides of occurrences starting
+	// at or after from, or all of them when from is nil.
+	DeleteOccurrenceOverrides(ctx context.Context, eventID string, from *time.Time) error
+	// SplitEventSeries saves the truncated series and creates the series
+	// continuing it, moving overrides from splitAt on to the new series.
+	SplitEventSeries(ctx context.Context, truncated *models.Event, continuation *models.Event, splitAt time.Time) error
+	// GetEventsToTransition returns events that have started by now and
+	// are not yet marked as underway, or have ended and are not yet marked
+	// as held.
+	GetEventsToTransition(ctx context.Context, now time.Time) ([]models.Event, error)
+	// UpdateEventStatus moves the event from one status to another and reports
+	// whether it was still in the from status.
+	UpdateEventStatus(ctx context.Context, eventID string, from, to constants.EventStatus) (bool, error)
+	// SearchEvents runs a full-text query, given in to_tsquery syntax, over
+	// approved events and returns them by descending rank.
+	SearchEvents(ctx context.Context, tsquery string, limit, offset int) ([]models.EventSearchResult, error)
+	// FindEventsNearby returns approved events within the query radius,
+	// nearest first.
+	FindEventsNearby(ctx context.Context, query *models.NearbyQuery) ([]models.EventWithDistance, error)
+	CountEventsInBox(ctx context.Context, box models.BoundingBox) (int64, error)
+	FindEventsInBox(ctx context.Context, box models.BoundingBox) ([]models.Event, error)
+	// ClusterEventsInBox groups approved events in the box into cells of the
+	// given size in degrees.
+	ClusterEventsInBox(ctx context.Context, box models.BoundingBox, cellLat, cellLng float64) ([]models.EventCluster, error)
 }
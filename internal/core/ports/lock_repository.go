@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+type LockRepository interface {
+	// TryWithLock runs fn while holding the cluster-wide lock identified by
+	// key. It returns false without running fn when another process holds
+	// the lock.
+	TryWithLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error)
+}
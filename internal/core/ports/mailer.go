@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type Mailer interface {
+	Send(ctx context.Context, email *models.Email) error
+}
+
+type EmailRenderer interface {
+	// Render fills in the message's template in its locale, falling back to
+	// the default locale.
+	Render(message *models.EmailMessage) (*models.Email, error)
+}
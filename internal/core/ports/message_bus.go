@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+)
+
+// MessageHandler reacts to a published message. Errors are logged by the bus
+// and never reach the publisher.
+type MessageHandler func(ctx context.Context, payload any) error
+
+// MessageBus lets services announce what happened without knowing who
+// reacts to it.
+type MessageBus interface {
+	Publish(ctx context.Context, topic constants.Topic, payload any)
+	Subscribe(topic constants.Topic, handler MessageHandler)
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type MFARepository interface {
+	// SetTOTPSecret stores a new encrypted secret for a user enrolling in
+	// two-factor authentication, which stays disabled until EnableMFA.
+	SetTOTPSecret(ctx context.Context, userID, encryptedSecret string) error
+	EnableMFA(ctx context.Context, userID string, at time.Time) error
+	// DisableMFA clears the secret and deletes the recovery codes.
+	DisableMFA(ctx context.Context, userID string) error
+	// AdvanceTOTPStep records that the code of the time step was used, unless
+	// a code of that or a later step was used before. It reports whether it
+	// did, so that each code works only once.
+	AdvanceTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes deletes the user's recovery codes and stores new
+	// ones.
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []models.MFARecoveryCode) error
+	// UseRecoveryCode marks the user's unused code with the hash as used and
+	// reports whether there was one.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID string) (int64, error)
+
+	GetMFAPolicies(ctx context.Context) ([]models.RoleMFAPolicy, error)
+	IsMFARequired(ctx context.Context, role constants.UserRole) (bool, error)
+	SaveMFAPolicy(ctx context.Context, policy *models.RoleMFAPolicy) error
+}
@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type ModerationRepository interface {
+	RecordAction(ctx context.Context, action *models.ModerationAction) error
+	GetActions(ctx context.Context, eventID string) ([]models.ModerationAction, error)
+	// GetOrganizerStats counts the organizer's approved events that a human
+	// moderator approved and the rejections of their events since the given
+	// time.
+	GetOrganizerStats(ctx context.Context, organizerID string, since time.Time) (*models.OrganizerModerationStats, error)
+	// GetQueue returns up to limit pending events, oldest submission first,
+	// skipping events claimed by other moderators until their claim expires.
+	GetQueue(ctx context.Context, moderatorID string, cursor *models.EventCursor, limit int, now time.Time) ([]models.ModerationQueueItem, error)
+	// ClaimEvent claims a pending event for the moderator and reports whether
+	// it was free, already theirs or its previous claim had expired.
+	ClaimEvent(ctx context.Context, claim *models.ModerationClaim, now time.Time) (bool, error)
+	// ClaimNextEvent claims the oldest unclaimed pending event and returns its
+	// ID, or an empty string when the queue is empty.
+	ClaimNextEvent(ctx context.Context, moderatorID string, expiresAt, now time.Time) (string, error)
+	GetClaim(ctx context.Context, eventID string) (*models.ModerationClaim, error)
+	ReleaseClaim(ctx context.Context, eventID, moderatorID string) error
+	// ApplyDecision moves the event from one moderation status to another,
+	// records the action and drops any claim on the event. It reports whether
+	// the event was still in the from status.
+	ApplyDecision(ctx context.Context, action *models.ModerationAction, from, to constants.EventModerationStatus) (bool, error)
+	// ApplyModeratorDecision is ApplyDecision for a moderator's decision,
+	// which also does not apply while another moderator holds an unexpired
+	// claim on the event.
+	ApplyModeratorDecision(ctx context.Context, action *models.ModerationAction, from, to constants.EventModerationStatus, now time.Time) (bool, error)
+}
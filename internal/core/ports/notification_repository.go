@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type NotificationRepository interface {
+	CreateNotifications(ctx context.Context, notifications []models.Notification) error
+	// ListNotifications returns up to limit notifications of the user, newest
+	// first, starting after the cursor when one is given.
+	ListNotifications(ctx context.Context, userID string, filter *models.NotificationFilter, cursor *models.EventCursor, limit int) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID string) (map[constants.NotificationCategory]int64, error)
+	MarkRead(ctx context.Context, userID string, ids []string) error
+	// MarkAllRead marks all notifications of the user in the category as read,
+	// or all of them when the category is empty.
+	MarkAllRead(ctx context.Context, userID string, category constants.NotificationCategory) error
+	GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error
+	// GetOptedOutUsers returns which of the users switched the category off.
+	GetOptedOutUsers(ctx context.Context, userIDs []string, category constants.NotificationCategory) ([]string, error)
+}
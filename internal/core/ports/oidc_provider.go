@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+// OIDCProvider logs users in at an OpenID Connect provider with the
+// authorization code flow and PKCE.
+type OIDCProvider interface {
+	Name() string
+	// AuthCodeURL returns the provider's login page, which sends the user
+	// back to redirectURL with a code and the state.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge, redirectURL string) (string, error)
+	// Exchange trades the code for an ID token and returns its claims once
+	// the token's signature, issuer, audience and expiry are verified. The
+	// nonce is left to the caller to check.
+	Exchange(ctx context.Context, code, codeVerifier, redirectURL string) (*models.OIDCClaims, error)
+}
+
+// OIDCProviders are the configured providers by name.
+type OIDCProviders map[string]OIDCProvider
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type OIDCRepository interface {
+	CreateAuthRequest(ctx context.Context, request *models.OIDCAuthRequest) error
+	// ConsumeAuthRequest deletes the unexpired request with the state hash
+	// and returns it, or returns nil when there is no such request.
+	ConsumeAuthRequest(ctx context.Context, stateHash string, now time.Time) (*models.OIDCAuthRequest, error)
+	DeleteExpiredAuthRequests(ctx context.Context, now time.Time) error
+	// GetIdentity returns the identity of the provider's account, or nil
+	// when it is not linked to a user.
+	GetIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	CreateIdentity(ctx context.Context, identity *models.UserIdentity) error
+	ListIdentities(ctx context.Context, userID string) ([]models.UserIdentity, error)
+}
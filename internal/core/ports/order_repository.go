@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, order *models.Order) error
+	GetOrder(ctx context.Context, orderID string) (*models.Order, error)
+	GetOrderByProviderPaymentID(ctx context.Context, provider, paymentID string) (*models.Order, error)
+	GetOrdersByUser(ctx context.Context, userID string) ([]models.Order, error)
+	// EventHasOrders reports whether any order, in any status, was placed
+	// for the event.
+	EventHasOrders(ctx context.Context, eventID string) (bool, error)
+	// GetOrdersByStatus returns up to limit orders in the status, least
+	// recently updated first.
+	GetOrdersByStatus(ctx context.Context, status constants.OrderStatus, limit int) ([]models.Order, error)
+	// TransitionOrderStatus moves the order from one status to another and
+	// reports false if the order was not in the expected status.
+	TransitionOrderStatus(ctx context.Context, orderID string, from, to constants.OrderStatus) (bool, error)
+	SetOrderTicket(ctx context.Context, orderID, ticketID string) error
+	IsWebhookEventProcessed(ctx context.Context, provider, eventID string) (bool, error)
+	MarkWebhookEventProcessed(ctx context.Context, provider string, event *models.PaymentWebhookEvent) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type OutboxRepository interface {
+	// Enqueue records a message for delivery. Called within a transaction,
+	// the message is only delivered if the transaction commits.
+	Enqueue(ctx context.Context, kind constants.OutboxKind, payload any) error
+	// ClaimDue returns up to limit messages due for delivery and postpones
+	// them until leaseUntil, so that other workers skip them and they are
+	// retried if this one dies before reporting back.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.OutboxMessage, error)
+	// MarkSent records the delivery and clears the payload, which may hold
+	// secrets such as password reset links.
+	MarkSent(ctx context.Context, messageID string, sentAt time.Time) error
+	// MarkFailed records a failed delivery and schedules the next attempt,
+	// or gives up on the message when retryAt is nil.
+	MarkFailed(ctx context.Context, messageID string, attempts int, lastError string, retryAt *time.Time) error
+}
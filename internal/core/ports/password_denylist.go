@@ -0,0 +1,7 @@
+package ports
+
+// PasswordDenylist knows passwords that leaked in breaches or are too common
+// to be safe.
+type PasswordDenylist interface {
+	Contains(password string) bool
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type PaymentProvider interface {
+	Name() string
+	CreateIntent(ctx context.Context, req models.PaymentIntentRequest) (*models.PaymentIntent, error)
+	ConfirmIntent(ctx context.Context, intentID string) (*models.PaymentIntent, error)
+	Refund(ctx context.Context, intentID string, amount int64) (*models.PaymentIntent, error)
+	// VerifyWebhook checks the signature of a webhook payload and decodes it.
+	VerifyWebhook(payload []byte, signature string) (*models.PaymentWebhookEvent, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+// RateLimitStore keeps counters that expire, shared by rate limiting and
+// login throttling.
+type RateLimitStore interface {
+	// Increment adds one hit to the key and returns its counter. A key
+	// without a live counter starts a new one that expires after ttl.
+	Increment(ctx context.Context, key string, now time.Time, ttl time.Duration) (models.RateLimitCounter, error)
+	// Get returns the key's counter, or the zero counter when it has none or
+	// it expired.
+	Get(ctx context.Context, key string, now time.Time) (models.RateLimitCounter, error)
+	// Decrement takes one hit back from the key's live counter.
+	Decrement(ctx context.Context, key string, now time.Time) error
+	Delete(ctx context.Context, key string) error
+	// DeleteExpired frees the space taken by expired counters.
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
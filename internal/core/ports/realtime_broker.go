@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+// RealtimeBroker delivers messages to the clients subscribed to their
+// channel, whichever replica they are connected to.
+type RealtimeBroker interface {
+	Publish(ctx context.Context, message *models.RealtimeMessage) error
+	Subscribe(channels []string) RealtimeSubscription
+}
+
+// RealtimeSubscription receives the messages published to its channels.
+type RealtimeSubscription interface {
+	// Messages is closed when the subscription is closed, including by the
+	// broker when the subscriber falls too far behind or on shutdown.
+	Messages() <-chan models.RealtimeMessage
+	Close()
+}
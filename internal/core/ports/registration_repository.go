@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type RegistrationRepository interface {
+	// SaveRegistration creates or updates the user's RSVP. A "going" RSVP is
+	// stored as waitlisted when the event is at capacity, and seats freed by
+	// the change are handed to the waitlist.
+	SaveRegistration(ctx context.Context, eventID, userID string, status constants.RegistrationStatus) (*models.Registration, error)
+	DeleteRegistration(ctx context.Context, eventID, userID string) error
+	GetRegistration(ctx context.Context, eventID, userID string) (*models.Registration, error)
+	PromoteWaitlist(ctx context.Context, eventID string) error
+	GetEventAttendees(ctx context.Context, eventID string) ([]models.AttendeeResponse, error)
+	GetUpcomingEventsByUser(ctx context.Context, userID string) ([]models.Event, error)
+}
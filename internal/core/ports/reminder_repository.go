@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type ReminderRepository interface {
+	// RecordReminder records the reminder and reports whether the user had
+	// not been reminded of the occurrence yet.
+	RecordReminder(ctx context.Context, reminder *models.EventReminder) (bool, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type ReportRepository interface {
+	// AddReport files the report under the active case of its target, opening
+	// one when there is none, and returns the case. It reports false without
+	// adding the report when the reporter has already reported the target in
+	// that case.
+	AddReport(ctx context.Context, report *models.Report) (*models.AbuseCase, bool, error)
+	GetCase(ctx context.Context, caseID string) (*models.AbuseCase, error)
+	ListCases(ctx context.Context, filter *models.AbuseCaseFilter, cursor *models.EventCursor, limit int) ([]models.AbuseCase, error)
+	GetReports(ctx context.Context, caseID string) ([]models.Report, error)
+	GetActions(ctx context.Context, caseID string) ([]models.AbuseCaseAction, error)
+	// UpdateCase saves the workflow fields of the case and appends the
+	// actions to its audit trail. It reports false without saving anything
+	// when the case is no longer in the from status.
+	UpdateCase(ctx context.Context, abuseCase *models.AbuseCase, from constants.AbuseCaseStatus, actions []models.AbuseCaseAction) (bool, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type SecurityEventRepository interface {
+	RecordEvent(ctx context.Context, event *models.SecurityEvent) error
+	// ListEvents returns up to limit events matching the filter, newest
+	// first, starting after the cursor when one is given.
+	ListEvents(ctx context.Context, filter *models.SecurityEventFilter, cursor *models.EventCursor, limit int) ([]models.SecurityEvent, error)
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+type SessionRepository interface {
+	CreateSession(session *models.Session) error
+	GetSession(sessionID string) (*models.Session, error)
+	GetActiveSessionsByUser(userID string) ([]models.Session, error)
+	// RotateRefreshToken swaps the refresh token hash only if the session is
+	// still active and currently holds oldHash. It reports whether it did.
+	RotateRefreshToken(sessionID, oldHash, newHash string, expiresAt time.Time) (bool, error)
+	RevokeSession(sessionID string) error
+	RevokeUserSessions(ctx context.Context, userID string) error
+	// RevokeOtherSessions revokes all of the user's sessions except one.
+	RevokeOtherSessions(ctx context.Context, userID, exceptSessionID string) error
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+// ErrTicketUnavailable is returned by IssueTicket when the quota is exhausted
+// or the user already holds a ticket of the type.
+var ErrTicketUnavailable = errors.New("ticket unavailable")
+
+type TicketRepository interface {
+	CreateTicketType(ctx context.Context, ticketType *models.TicketType) error
+	UpdateTicketType(ctx context.Context, ticketType *models.TicketType) error
+	GetTicketType(ctx context.Context, ticketTypeID string) (*models.TicketType, error)
+	GetTicketTypesByEvent(ctx context.Context, eventID string) ([]models.TicketTypeResponse, error)
+	// IssueTicket stores the ticket unless the ticket type quota is exhausted
+	// or the user already holds a valid ticket of that type.
+	IssueTicket(ctx context.Context, ticket *models.Ticket) error
+	GetTicket(ctx context.Context, ticketID string) (*models.Ticket, error)
+	GetTicketsByUser(ctx context.Context, userID string) ([]models.Ticket, error)
+	CancelTicket(ctx context.Context, ticketID string) error
+	// CheckIn marks the ticket as used. It reports false if the ticket was
+	// already checked in.
+	CheckIn(ctx context.Context, ticketID, checkedInBy string, at time.Time) (bool, error)
+	GetAttendanceStats(ctx context.Context, eventID string) (*models.AttendanceStats, error)
+}
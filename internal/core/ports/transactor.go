@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+type Transactor interface {
+	// WithinTransaction runs fn in a database transaction. Repository calls
+	// made with the context fn receives are part of it, and are rolled back
+	// when fn returns an error.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -1,9 +1,19 @@
 package ports
 
-import "github.com/EventFlow-Project/backend/internal/core/models"
+import (
+	"context"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
 
 type UserRepository interface {
 	GetUserByID(userID string) (*models.User, error)
 	EditUserInfo(userID string, info *models.EditUserInfo) (*models.User, error)
 	SearchUsersByName(name string) ([]*models.User, error)
+	UpdateUserRole(userID string, role constants.UserRole) (*models.User, error)
+	// SetUserHidden hides the user's profile from search or shows it again.
+	SetUserHidden(ctx context.Context, userID string, hidden bool) error
+	// GetUsersByIDs returns the users among userIDs that exist.
+	GetUsersByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
 }
@@ -1,32 +1,110 @@
 package services
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
 	"github.com/EventFlow-Project/backend/internal/core/ports"
 
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrInvalidAuthToken   = fmt.Errorf("%w: invalid or expired token", ErrInvalidArgument)
+	ErrInvalidCredentials = errors.New("invalid email or password")
+)
+
 type AuthService struct {
-	repo       ports.AuthRepository
-	jwtService *JWTService
+	config           *config.Config
+	repo             ports.AuthRepository
+	userRepository   ports.UserRepository
+	tokenRepository  ports.AuthTokenRepository
+	outboxRepository ports.OutboxRepository
+	transactor       ports.Transactor
+	passwordDenylist ports.PasswordDenylist
+	loginThrottle    *LoginThrottle
+	sessionService   *SessionService
+	mfaService       *MFAService
 }
 
-func NewAuthService(repo ports.AuthRepository, jwtService *JWTService) *AuthService {
+func NewAuthService(
+	config *config.Config,
+	repo ports.AuthRepository,
+	userRepository ports.UserRepository,
+	tokenRepository ports.AuthTokenRepository,
+	outboxRepository ports.OutboxRepository,
+	transactor ports.Transactor,
+	passwordDenylist ports.PasswordDenylist,
+	loginThrottle *LoginThrottle,
+	sessionService *SessionService,
+	mfaService *MFAService,
+) *AuthService {
 	return &AuthService{
-		repo:       repo,
-		jwtService: jwtService,
+		config:           config,
+		repo:             repo,
+		userRepository:   userRepository,
+		tokenRepository:  tokenRepository,
+		outboxRepository: outboxRepository,
+		transactor:       transactor,
+		passwordDenylist: passwordDenylist,
+		loginThrottle:    loginThrottle,
+		sessionService:   sessionService,
+		mfaService:       mfaService,
 	}
 }
 
-func (s *AuthService) Register(credentials models.RegistrationCredentials) (*models.User, error) {
+// Register creates the account and emails a link to confirm the address.
+func (s *AuthService) Register(ctx context.Context, credentials models.RegistrationCredentials) (*models.User, error) {
 	if _, err := s.repo.GetUserByEmail(credentials.Email); err == nil {
 		return nil, errors.New("user already exists")
 	}
 
-	user, err := s.repo.CreateUserWithPassword(credentials.Email, credentials.Password, credentials.Name, credentials.Role, credentials.Description, credentials.ActivityArea)
+	if credentials.Role == "" {
+		credentials.Role = constants.UserRoleParticipant
+	}
+
+	if !credentials.Role.IsSelfAssignable() {
+		return nil, errors.New("role cannot be self-assigned")
+	}
+
+	if credentials.Locale == "" {
+		credentials.Locale = constants.DefaultLocale
+	}
+
+	if !credentials.Locale.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported locale", ErrInvalidArgument)
+	}
+
+	if err := s.checkPassword(credentials.Password, credentials.Email); err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := s.hashPassword(credentials.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *models.User
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.repo.CreateUserWithPassword(ctx, credentials.Email, passwordHash, credentials.Name, credentials.Role, credentials.Description, credentials.ActivityArea, credentials.Locale)
+		if err != nil {
+			return err
+		}
+
+		return s.sendVerificationEmail(ctx, user)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -34,15 +112,415 @@ func (s *AuthService) Register(credentials models.RegistrationCredentials) (*mod
 	return user, nil
 }
 
-func (s *AuthService) Login(email, password string) (string, error) {
+// Login checks the credentials and starts a session. Repeated failures make
+// further attempts wait; see LoginThrottle.
+func (s *AuthService) Login(ctx context.Context, email, password string, client models.ClientInfo) (*models.AuthResponse, error) {
+	attempt, err := s.loginThrottle.Allow(ctx, email, client)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.GetUserByEmail(email)
 	if err != nil {
-		return "", errors.New("invalid email or password")
+		if err := s.loginThrottle.RecordFailure(ctx, attempt, constants.SecurityEventLoginFailed, nil); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", errors.New("invalid email or password")
+		if err := s.loginThrottle.RecordFailure(ctx, attempt, constants.SecurityEventLoginFailed, &user.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	// The password is only known now, so this is when a hash made with an
+	// outdated cost can be upgraded. Failing to do so does not fail the login.
+	if s.needsRehash(user.PasswordHash) {
+		if passwordHash, err := s.hashPassword(password); err == nil {
+			_ = s.repo.UpdatePassword(ctx, user.ID, passwordHash)
+		}
+	}
+
+	// With two-factor authentication the failures are only forgotten once
+	// the second step succeeds too.
+	if user.MFAEnabledAt != nil {
+		if err := s.loginThrottle.Release(ctx, attempt); err != nil {
+			return nil, err
+		}
+		return s.issueMFAChallenge(ctx, user)
+	}
+
+	if err := s.loginThrottle.RecordSuccess(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	return s.sessionService.CreateSession(user, client)
+}
+
+// CompleteMFALogin finishes a login started with the password, given the
+// challenge token and a code from the authenticator app or a recovery code.
+// Wrong codes count as failed logins.
+func (s *AuthService) CompleteMFALogin(ctx context.Context, request models.MFALoginRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+	tokenHash := hashToken(request.MFAToken)
+	authToken, err := s.tokenRepository.FindToken(ctx, constants.AuthTokenMFAChallenge, tokenHash, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if authToken == nil {
+		return nil, ErrInvalidAuthToken
+	}
+
+	user, err := s.userRepository.GetUserByID(authToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.MFAEnabledAt == nil {
+		return nil, ErrInvalidAuthToken
+	}
+
+	attempt, err := s.loginThrottle.Allow(ctx, user.Email, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var codeErr error
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.mfaService.VerifyCode(ctx, user, request.Code, request.RecoveryCode, client); err != nil {
+			if errors.Is(err, ErrInvalidMFACode) {
+				codeErr = err
+				return nil
+			}
+			return err
+		}
+
+		consumed, err := s.tokenRepository.ConsumeToken(ctx, constants.AuthTokenMFAChallenge, tokenHash, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if consumed == nil {
+			return ErrInvalidAuthToken
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if codeErr != nil {
+		if err := s.loginThrottle.RecordFailure(ctx, attempt, constants.SecurityEventMFAFailed, &user.ID); err != nil {
+			return nil, err
+		}
+		return nil, codeErr
+	}
+
+	if err := s.loginThrottle.RecordSuccess(ctx, attempt); err != nil {
+		return nil, err
 	}
 
-	return s.jwtService.GenerateToken(user)
+	return s.sessionService.CreateSession(user, client)
+}
+
+// VerifyEmail confirms the address the token was sent to and welcomes the
+// user.
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		authToken, err := s.tokenRepository.ConsumeToken(ctx, constants.AuthTokenEmailVerification, hashToken(token), time.Now())
+		if err != nil {
+			return err
+		}
+
+		if authToken == nil {
+			return ErrInvalidAuthToken
+		}
+
+		user, err := s.userRepository.GetUserByID(authToken.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.MarkEmailVerified(ctx, user.ID, time.Now()); err != nil {
+			return err
+		}
+
+		return enqueueEmail(ctx, s.outboxRepository, user, constants.EmailTemplateWelcome, map[string]string{
+			"name": user.Name,
+			"link": s.config.AppURL,
+		})
+	})
+}
+
+// ResendVerification emails a new confirmation link; earlier links stop
+// working.
+func (s *AuthService) ResendVerification(ctx context.Context, actor *models.Principal) error {
+	user, err := s.userRepository.GetUserByID(actor.ID)
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return fmt.Errorf("%w: email is already verified", ErrConflict)
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.sendVerificationEmail(ctx, user)
+	})
+}
+
+// ForgotPassword emails a password reset link if an account with the email
+// exists. It reports success either way so that it does not reveal which
+// emails are registered.
+func (s *AuthService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.repo.GetUserByEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.issueToken(ctx, &models.AuthToken{
+			UserID:  user.ID,
+			Purpose: constants.AuthTokenPasswordReset,
+		}, s.config.Auth.PasswordResetTTL)
+		if err != nil {
+			return err
+		}
+
+		return enqueueEmail(ctx, s.outboxRepository, user, constants.EmailTemplatePasswordReset, map[string]string{
+			"name":      user.Name,
+			"link":      s.tokenLink("/reset-password", token),
+			"expiresIn": formatEmailDuration(s.config.Auth.PasswordResetTTL, user.Locale),
+		})
+	})
+}
+
+// ResetPassword sets a new password and signs the user out everywhere. The
+// reset link proves the user owns the address, so it also verifies it.
+func (s *AuthService) ResetPassword(ctx context.Context, token, password string) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		authToken, err := s.tokenRepository.ConsumeToken(ctx, constants.AuthTokenPasswordReset, hashToken(token), time.Now())
+		if err != nil {
+			return err
+		}
+
+		if authToken == nil {
+			return ErrInvalidAuthToken
+		}
+
+		user, err := s.userRepository.GetUserByID(authToken.UserID)
+		if err != nil {
+			return err
+		}
+
+		// A rejected password rolls the transaction back, so the link can
+		// be used again.
+		if err := s.checkPassword(password, user.Email); err != nil {
+			return err
+		}
+
+		passwordHash, err := s.hashPassword(password)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.UpdatePassword(ctx, authToken.UserID, passwordHash); err != nil {
+			return err
+		}
+
+		if err := s.repo.MarkEmailVerified(ctx, authToken.UserID, time.Now()); err != nil {
+			return err
+		}
+
+		return s.sessionService.RevokeAllSessions(ctx, authToken.UserID)
+	})
+}
+
+// ChangePassword replaces the password after checking the current one and
+// signs the user out of their other sessions.
+func (s *AuthService) ChangePassword(ctx context.Context, actor *models.Principal, currentPassword, newPassword string, client models.ClientInfo) error {
+	user, err := s.reauthenticate(ctx, actor, currentPassword, client)
+	if err != nil {
+		return err
+	}
+
+	if newPassword == currentPassword {
+		return fmt.Errorf("%w: new password must differ from the current one", ErrInvalidArgument)
+	}
+
+	if err := s.checkPassword(newPassword, user.Email); err != nil {
+		return err
+	}
+
+	passwordHash, err := s.hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdatePassword(ctx, user.ID, passwordHash); err != nil {
+			return err
+		}
+
+		return s.sessionService.RevokeOtherSessions(ctx, user.ID, actor.SessionID)
+	})
+}
+
+// ChangeEmail emails a confirmation link to the new address after checking
+// the password. The email only changes once the link is followed.
+func (s *AuthService) ChangeEmail(ctx context.Context, actor *models.Principal, password, newEmail string, client models.ClientInfo) error {
+	user, err := s.reauthenticate(ctx, actor, password, client)
+	if err != nil {
+		return err
+	}
+
+	newEmail = strings.TrimSpace(newEmail)
+	if address, err := mail.ParseAddress(newEmail); err != nil || address.Address != newEmail {
+		return fmt.Errorf("%w: invalid email", ErrInvalidArgument)
+	}
+
+	if strings.EqualFold(newEmail, user.Email) {
+		return fmt.Errorf("%w: this is already your email", ErrInvalidArgument)
+	}
+
+	if _, err := s.repo.GetUserByEmail(newEmail); err == nil {
+		return fmt.Errorf("%w: email already in use", ErrConflict)
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.issueToken(ctx, &models.AuthToken{
+			UserID:   user.ID,
+			Purpose:  constants.AuthTokenEmailChange,
+			NewEmail: &newEmail,
+		}, s.config.Auth.EmailChangeTTL)
+		if err != nil {
+			return err
+		}
+
+		return enqueueEmailTo(ctx, s.outboxRepository, newEmail, user, constants.EmailTemplateConfirmEmailChange, map[string]string{
+			"name":      user.Name,
+			"link":      s.tokenLink("/confirm-email-change", token),
+			"expiresIn": formatEmailDuration(s.config.Auth.EmailChangeTTL, user.Locale),
+		})
+	})
+}
+
+// ConfirmEmailChange switches the user to the address the token was sent to
+// and tells the old address about it.
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, token string) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		authToken, err := s.tokenRepository.ConsumeToken(ctx, constants.AuthTokenEmailChange, hashToken(token), now)
+		if err != nil {
+			return err
+		}
+
+		if authToken == nil || authToken.NewEmail == nil {
+			return ErrInvalidAuthToken
+		}
+		newEmail := *authToken.NewEmail
+
+		user, err := s.userRepository.GetUserByID(authToken.UserID)
+		if err != nil {
+			return err
+		}
+
+		if existing, err := s.repo.GetUserByEmail(newEmail); err == nil && existing.ID != user.ID {
+			return fmt.Errorf("%w: email already in use", ErrConflict)
+		}
+
+		if err := s.repo.UpdateEmail(ctx, user.ID, newEmail, now); err != nil {
+			return err
+		}
+
+		return enqueueEmail(ctx, s.outboxRepository, user, constants.EmailTemplateEmailChanged, map[string]string{
+			"name":     user.Name,
+			"newEmail": newEmail,
+		})
+	})
+}
+
+// reauthenticate checks the password of the signed-in user before a
+// sensitive change to their account.
+func (s *AuthService) reauthenticate(ctx context.Context, actor *models.Principal, password string, client models.ClientInfo) (*models.User, error) {
+	user, err := s.userRepository.GetUserByID(actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loginThrottle.CheckPassword(ctx, user, password, client); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *AuthService) sendVerificationEmail(ctx context.Context, user *models.User) error {
+	token, err := s.issueToken(ctx, &models.AuthToken{
+		UserID:  user.ID,
+		Purpose: constants.AuthTokenEmailVerification,
+	}, s.config.Auth.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return enqueueEmail(ctx, s.outboxRepository, user, constants.EmailTemplateVerifyEmail, map[string]string{
+		"name":      user.Name,
+		"link":      s.tokenLink("/verify-email", token),
+		"expiresIn": formatEmailDuration(s.config.Auth.EmailVerificationTTL, user.Locale),
+	})
+}
+
+// issueToken revokes the user's earlier tokens for the purpose and stores
+// authToken with a new token. Only the token's hash is kept.
+func (s *AuthService) issueToken(ctx context.Context, authToken *models.AuthToken, ttl time.Duration) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := time.Now()
+	if err := s.tokenRepository.RevokeTokens(ctx, authToken.UserID, authToken.Purpose, now); err != nil {
+		return "", err
+	}
+
+	authToken.ID = uuid.New().String()
+	authToken.TokenHash = hashToken(token)
+	authToken.ExpiresAt = now.Add(ttl)
+	authToken.CreatedAt = now
+	if err := s.tokenRepository.CreateToken(ctx, authToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// issueMFAChallenge returns the token that lets the user finish logging in
+// with a two-factor code.
+func (s *AuthService) issueMFAChallenge(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
+	var token string
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		token, err = s.issueToken(ctx, &models.AuthToken{
+			UserID:  user.ID,
+			Purpose: constants.AuthTokenMFAChallenge,
+		}, s.config.Auth.MFAChallengeTTL)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{
+		MFARequired: true,
+		MFAToken:    token,
+	}, nil
+}
+
+func (s *AuthService) tokenLink(path, token string) string {
+	return strings.TrimSuffix(s.config.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
 }
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+var ErrInvalidCalendarToken = errors.New("invalid calendar token")
+
+// calendarRefreshInterval is the polling interval suggested to subscribed
+// calendar clients.
+const calendarRefreshInterval = "PT1H"
+
+type CalendarService struct {
+	calendarRepository ports.CalendarRepository
+	eventRepository    ports.EventRepository
+	friendRepository   ports.FriendRepository
+}
+
+func NewCalendarService(
+	calendarRepository ports.CalendarRepository,
+	eventRepository ports.EventRepository,
+	friendRepository ports.FriendRepository,
+) *CalendarService {
+	return &CalendarService{
+		calendarRepository: calendarRepository,
+		eventRepository:    eventRepository,
+		friendRepository:   friendRepository,
+	}
+}
+
+// GetEventCalendar renders a single event, with its recurrence, as an
+// iCalendar document. It returns nil when the event does not exist or the
+// actor may not see it.
+func (s *CalendarService) GetEventCalendar(ctx context.Context, actor *models.Principal, eventID string) (*models.Calendar, error) {
+	if eventID == "" {
+		return nil, errors.New("event ID is required")
+	}
+
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil || !canViewEvent(actor, event) {
+		return nil, nil
+	}
+
+	return s.render(ctx, []models.Event{*event}, func(w *icalWriter) {})
+}
+
+// IssueToken creates the secret token of the user's calendar feed. Issuing a
+// new token revokes the previous one, so a leaked feed URL can be replaced.
+func (s *CalendarService) IssueToken(ctx context.Context, userID string) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	if err := s.calendarRepository.SaveToken(ctx, &models.CalendarToken{
+		UserID:    userID,
+		TokenHash: hashToken(token),
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *CalendarService) RevokeToken(ctx context.Context, userID string) error {
+	return s.calendarRepository.DeleteToken(ctx, userID)
+}
+
+// GetFeedCalendar renders the feed identified by the token: the events the
+// user organizes and the approved events organized by their friends.
+func (s *CalendarService) GetFeedCalendar(ctx context.Context, token string) (*models.Calendar, error) {
+	calendarToken, err := s.calendarRepository.GetTokenByHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if calendarToken == nil {
+		return nil, ErrInvalidCalendarToken
+	}
+
+	friends, err := s.friendRepository.GetFriendsList(calendarToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	friendIDs := make([]string, len(friends))
+	for i, friend := range friends {
+		friendIDs[i] = friend.ID
+	}
+
+	events, err := s.eventRepository.GetCalendarEvents(ctx, calendarToken.UserID, friendIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.render(ctx, events, func(w *icalWriter) {
+		w.text("X-WR-CALNAME", "EventFlow")
+		w.line("REFRESH-INTERVAL;VALUE=DURATION", calendarRefreshInterval)
+		w.line("X-PUBLISHED-TTL", calendarRefreshInterval)
+	})
+}
+
+func (s *CalendarService) render(ctx context.Context, events []models.Event, header func(w *icalWriter)) (*models.Calendar, error) {
+	var recurringIDs []string
+	for _, event := range events {
+		if event.IsRecurring() {
+			recurringIDs = append(recurringIDs, event.ID)
+		}
+	}
+
+	overrides, err := s.eventRepository.GetOccurrenceOverrides(ctx, recurringIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	overridesByEvent := make(map[string][]models.OccurrenceOverride)
+	for _, override := range overrides {
+		overridesByEvent[override.EventID] = append(overridesByEvent[override.EventID], override)
+	}
+
+	w := &icalWriter{}
+	w.begin()
+	header(w)
+	for i := range events {
+		w.event(&events[i], overridesByEvent[events[i].ID])
+	}
+	body := w.end()
+
+	sum := sha256.Sum256(body)
+
+	return &models.Calendar{
+		Body: body,
+		ETag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+// enqueueEmail queues an email to the user in their language. Called within
+// a transaction, the email is only sent if the transaction commits.
+func enqueueEmail(ctx context.Context, outbox ports.OutboxRepository, user *models.User, template constants.EmailTemplate, data map[string]string) error {
+	return enqueueEmailTo(ctx, outbox, user.Email, user, template, data)
+}
+
+// enqueueEmailTo queues an email to the user in their language, sent to an
+// address other than their current one.
+func enqueueEmailTo(ctx context.Context, outbox ports.OutboxRepository, to string, user *models.User, template constants.EmailTemplate, data map[string]string) error {
+	locale := user.Locale
+	if !locale.IsValid() {
+		locale = constants.DefaultLocale
+	}
+
+	return outbox.Enqueue(ctx, constants.OutboxKindEmail, &models.EmailMessage{
+		To:       to,
+		Template: template,
+		Locale:   locale,
+		Data:     data,
+	})
+}
+
+// enqueueModerationEmail tells the organizer that a moderator approved or
+// rejected their event. Other moderation actions are not emailed.
+func enqueueModerationEmail(ctx context.Context, outbox ports.OutboxRepository, appURL string, organizer *models.User, event *models.Event, action constants.ModerationAction, reason string) error {
+	var template constants.EmailTemplate
+	switch action {
+	case constants.ModerationActionApproved:
+		template = constants.EmailTemplateEventApproved
+	case constants.ModerationActionRejected:
+		template = constants.EmailTemplateEventRejected
+	default:
+		return nil
+	}
+
+	return enqueueEmail(ctx, outbox, organizer, template, map[string]string{
+		"name":       organizer.Name,
+		"eventTitle": event.Title,
+		"reason":     reason,
+		"link":       eventLink(appURL, event.ID),
+	})
+}
+
+func eventLink(appURL, eventID string) string {
+	return strings.TrimSuffix(appURL, "/") + "/events/" + eventID
+}
+
+// formatEmailTime formats a time for an email in the event's timezone.
+func formatEmailTime(t time.Time, timezone string, locale constants.Locale) string {
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	t = t.In(models.LoadLocation(timezone))
+	if locale == constants.LocaleEN {
+		return t.Format("January 2, 2006 3:04 PM") + " (" + timezone + ")"
+	}
+
+	return t.Format("02.01.2006 15:04") + " (" + timezone + ")"
+}
+
+// formatEmailDuration spells out how long an emailed link stays valid, e.g.
+// "48 часов" or "1 hour".
+func formatEmailDuration(d time.Duration, locale constants.Locale) string {
+	var n int64
+	var ru [3]string
+	var en string
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		n, ru, en = int64(d/(24*time.Hour)), [3]string{"день", "дня", "дней"}, "day"
+	case d >= time.Hour && d%time.Hour == 0:
+		n, ru, en = int64(d/time.Hour), [3]string{"час", "часа", "часов"}, "hour"
+	default:
+		n, ru, en = int64(d.Round(time.Minute)/time.Minute), [3]string{"минуту", "минуты", "минут"}, "minute"
+	}
+
+	if locale == constants.LocaleEN {
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, en)
+		}
+		return fmt.Sprintf("%d %ss", n, en)
+	}
+
+	return fmt.Sprintf("%d %s", n, ru[russianPluralForm(n)])
+}
+
+// russianPluralForm picks the noun form for n: 0 for "1 час", 1 for
+// "2 часа", 2 for "5 часов".
+func russianPluralForm(n int64) int {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return 1
+	default:
+		return 2
+	}
+}
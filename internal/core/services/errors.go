@@ -0,0 +1,34 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrForbidden = errors.New("forbidden")
+	ErrConflict  = errors.New("conflict")
+	// ErrNotFound marks requests for something that does not exist or that
+	// the actor may not know exists.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidArgument marks errors caused by malformed client input.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrTooManyRequests marks requests refused because the client made too
+	// many of them. The error is a *RetryAfterError when the client should
+	// wait a known time.
+	ErrTooManyRequests = errors.New("too many requests")
+)
+
+// RetryAfterError tells the client how long to wait before trying again.
+type RetryAfterError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%s: try again in %s", ErrTooManyRequests, e.RetryAfter.Round(time.Second))
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return ErrTooManyRequests
+}
@@ -2,8 +2,15 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
@@ -11,17 +18,68 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	defaultEventPageSize = 20
+	maxEventPageSize     = 100
+
+	defaultNearbyRadiusKm = 10
+	maxNearbyRadiusKm     = 500
+	maxNearbyResults      = 100
+
+	// A viewport with more events than maxMapPins is answered with clusters
+	// on a mapGridSize x mapGridSize grid.
+	maxMapPins  = 200
+	mapGridSize = 16
+
+	kmPerDegreeLat = 111.32
+
+	maxSearchTerms = 8
+
+	defaultEventTimezone = "UTC"
+)
+
 type EventService struct {
-	eventRepository ports.EventRepository
+	eventRepository        ports.EventRepository
+	userRepository         ports.UserRepository
+	registrationRepository ports.RegistrationRepository
+	moderationRepository   ports.ModerationRepository
+	orderRepository        ports.OrderRepository
+	transactor             ports.Transactor
+	screeningService       *ScreeningService
+	messageBus             ports.MessageBus
 }
 
-func NewEventService(eventRepository ports.EventRepository) *EventService {
+func NewEventService(
+	eventRepository ports.EventRepository,
+	userRepository ports.UserRepository,
+	registrationRepository ports.RegistrationRepository,
+	moderationRepository ports.ModerationRepository,
+	orderRepository ports.OrderRepository,
+	transactor ports.Transactor,
+	screeningService *ScreeningService,
+	messageBus ports.MessageBus,
+) *EventService {
 	return &EventService{
-		eventRepository: eventRepository,
+		eventRepository:        eventRepository,
+		userRepository:         userRepository,
+		registrationRepository: registrationRepository,
+		moderationRepository:   moderationRepository,
+		orderRepository:        orderRepository,
+		transactor:             transactor,
+		screeningService:       screeningService,
+		messageBus:             messageBus,
 	}
 }
 
-func (s *EventService) CreateEvent(ctx context.Context, eventRequest *models.EventRequest) (*models.Event, error) {
+func (s *EventService) CreateEvent(ctx context.Context, actor *models.Principal, eventRequest *models.EventRequest) (*models.Event, error) {
+	if !actor.Can(constants.PermissionCreateEvent) {
+		return nil, ErrForbidden
+	}
+
+	if !actor.EmailVerified {
+		return nil, fmt.Errorf("%w: confirm your email before creating events", ErrForbidden)
+	}
+
 	if eventRequest == nil {
 		return nil, errors.New("event is required")
 	}
@@ -30,43 +88,75 @@ func (s *EventService) CreateEvent(ctx context.Context, eventRequest *models.Eve
 		return nil, errors.New("title is required")
 	}
 
-	if eventRequest.Date == "" {
-		return nil, errors.New("date is required")
-	}
-
-	date, err := time.Parse(time.RFC3339, eventRequest.Date)
+	schedule, err := parseEventSchedule(eventRequest, defaultEventTimezone)
 	if err != nil {
-		return nil, errors.New("invalid date format")
+		return nil, err
 	}
 
-	if eventRequest.Duration == "" {
-		return nil, errors.New("duration is required")
+	if eventRequest.Capacity != nil && *eventRequest.Capacity <= 0 {
+		return nil, errors.New("capacity must be positive")
 	}
 
-	if eventRequest.Organizer == "" {
-		return nil, errors.New("organizer is required")
+	if err := s.validateCoOrganizers(ctx, eventRequest.CoOrganizers); err != nil {
+		return nil, err
 	}
 
 	event := &models.Event{
 		ID:               uuid.New().String(),
 		Title:            eventRequest.Title,
 		Description:      eventRequest.Description,
-		Date:             date,
-		Duration:         eventRequest.Duration,
-		Organizer:        eventRequest.Organizer,
-		Status:           constants.EventStatusComingUp,
+		Date:             schedule.start,
+		EndDate:          schedule.end,
+		Timezone:         schedule.timezone,
+		Organizer:        actor.ID,
+		CoOrganizers:     eventRequest.CoOrganizers,
 		ModerationStatus: constants.EventModerationStatusPending,
+		SubmittedAt:      time.Now(),
 		Location:         eventRequest.Location,
 		Tags:             eventRequest.Tags,
+		Capacity:         eventRequest.Capacity,
 		Image:            eventRequest.Image,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
 
-	return s.eventRepository.CreateEvent(ctx, event)
+	if err := applyRecurrence(event, eventRequest.Recurrence, eventRequest.RecurrenceExceptions); err != nil {
+		return nil, err
+	}
+
+	event.Status, err = resolveEventStatus(event, eventRequest.Status, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	screening, err := s.screenEvent(ctx, actor, event)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdEvent *models.Event
+	var moderation *models.EventModeration
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdEvent, err = s.eventRepository.CreateEvent(ctx, event)
+		if err != nil {
+			return err
+		}
+
+		moderation, err = s.recordScreening(ctx, actor, createdEvent, constants.ModerationActionSubmitted, screening)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishModeration(ctx, moderation)
+	createdEvent.Localize()
+
+	return createdEvent, nil
 }
 
-func (s *EventService) UpdateEvent(ctx context.Context, eventRequest *models.EventRequest) error {
+func (s *EventService) UpdateEvent(ctx context.Context, actor *models.Principal, eventRequest *models.EventRequest) error {
 	if eventRequest == nil {
 		return errors.New("event is required")
 	}
@@ -84,31 +174,111 @@ func (s *EventService) UpdateEvent(ctx context.Context, eventRequest *models.Eve
 		return errors.New("event not found")
 	}
 
-	date, err := time.Parse(time.RFC3339, eventRequest.Date)
+	if !canEditEvent(actor, existingEvent) {
+		return ErrForbidden
+	}
+
+	if eventRequest.Title == "" {
+		return errors.New("title is required")
+	}
+
+	schedule, err := parseEventSchedule(eventRequest, existingEvent.Timezone)
 	if err != nil {
-		return errors.New("invalid date format")
+		return err
+	}
+
+	if eventRequest.Capacity != nil && *eventRequest.Capacity <= 0 {
+		return errors.New("capacity must be positive")
+	}
+
+	coOrganizers := existingEvent.CoOrganizers
+	if eventRequest.CoOrganizers != nil && canDeleteEvent(actor, existingEvent) {
+		if err := s.validateCoOrganizers(ctx, eventRequest.CoOrganizers); err != nil {
+			return err
+		}
+		coOrganizers = eventRequest.CoOrganizers
 	}
 
 	event := &models.Event{
-		ID:               eventRequest.ID,
-		Title:            eventRequest.Title,
-		Description:      eventRequest.Description,
-		Date:             date,
-		Duration:         eventRequest.Duration,
-		Organizer:        eventRequest.Organizer,
-		Status:           eventRequest.Status,
-		ModerationStatus: existingEvent.ModerationStatus,
-		Location:         eventRequest.Location,
-		Tags:             eventRequest.Tags,
-		Image:            eventRequest.Image,
-		CreatedAt:        existingEvent.CreatedAt,
-		UpdatedAt:        time.Now(),
+		ID:                eventRequest.ID,
+		Title:             eventRequest.Title,
+		Description:       eventRequest.Description,
+		Date:              schedule.start,
+		EndDate:           schedule.end,
+		Timezone:          schedule.timezone,
+		Organizer:         existingEvent.Organizer,
+		CoOrganizers:      coOrganizers,
+		ModerationStatus:  existingEvent.ModerationStatus,
+		ModerationReason:  existingEvent.ModerationReason,
+		SubmittedAt:       existingEvent.SubmittedAt,
+		ScreeningScore:    existingEvent.ScreeningScore,
+		ScreeningFindings: existingEvent.ScreeningFindings,
+		Location:          eventRequest.Location,
+		Tags:              eventRequest.Tags,
+		Capacity:          eventRequest.Capacity,
+		Image:             eventRequest.Image,
+		CreatedAt:         existingEvent.CreatedAt,
+		UpdatedAt:         time.Now(),
+	}
+
+	screening, err := s.rescreenEvent(ctx, actor, existingEvent, event)
+	if err != nil {
+		return err
+	}
+
+	if err := applyRecurrence(event, eventRequest.Recurrence, eventRequest.RecurrenceExceptions); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	event.Status, err = resolveEventStatus(event, eventRequest.Status, now)
+	if err != nil {
+		return err
+	}
+
+	var moderation *models.EventModeration
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventRepository.UpdateEvent(ctx, event); err != nil {
+			return err
+		}
+
+		var err error
+		moderation, err = s.recordRescreening(ctx, actor, existingEvent, event, screening)
+		if err != nil {
+			return err
+		}
+
+		// Overrides are keyed by occurrence start and no longer match once
+		// the series is rescheduled.
+		if !event.Date.Equal(existingEvent.Date) || event.Recurrence != existingEvent.Recurrence {
+			return s.eventRepository.DeleteOccurrenceOverrides(ctx, event.ID, nil)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return s.eventRepository.UpdateEvent(ctx, event)
+	s.publishModeration(ctx, moderation)
+
+	if event.Status != existingEvent.Status {
+		s.publishStatusChange(ctx, event, existingEvent.Status, now)
+	}
+
+	if event.ModerationStatus == constants.EventModerationStatusApproved && scheduleOrPlaceChanged(existingEvent, event) {
+		s.messageBus.Publish(ctx, constants.TopicEventChanged, &models.EventChange{
+			EventID:   event.ID,
+			Title:     event.Title,
+			ChangedBy: actor.ID,
+		})
+	}
+
+	// A raised or removed capacity frees seats for the waitlist.
+	return s.registrationRepository.PromoteWaitlist(ctx, event.ID)
 }
 
-func (s *EventService) DeleteEvent(ctx context.Context, eventID string) error {
+func (s *EventService) DeleteEvent(ctx context.Context, actor *models.Principal, eventID string) error {
 	if eventID == "" {
 		return errors.New("event ID is required")
 	}
@@ -122,12 +292,127 @@ func (s *EventService) DeleteEvent(ctx context.Context, eventID string) error {
 		return errors.New("event not found")
 	}
 
-	return s.eventRepository.DeleteEvent(ctx, eventID)
+	if !canDeleteEvent(actor, existingEvent) {
+		return ErrForbidden
+	}
+
+	// Orders are kept as the record of payments and refunds, so an event
+	// that sold tickets cannot be deleted.
+	hasOrders, err := s.orderRepository.EventHasOrders(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if hasOrders {
+		return fmt.Errorf("%w: the event has ticket orders and cannot be deleted", ErrConflict)
+	}
+
+	// Registrations go with the event, so attendees are collected first.
+	attendees, err := s.registrationRepository.GetEventAttendees(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.eventRepository.DeleteEvent(ctx, eventID); err != nil {
+		return err
+	}
+
+	s.messageBus.Publish(ctx, constants.TopicEventCancelled, &models.EventCancellation{
+		EventID:     existingEvent.ID,
+		Title:       existingEvent.Title,
+		CancelledBy: actor.ID,
+		AttendeeIDs: activeAttendeeIDs(attendees, ""),
+	})
+
+	return nil
 }
 
-func (s *EventService) ApproveEvent(ctx context.Context, eventID string) error {
-	if eventID == "" {
-		return errors.New("event ID is required")
+// scheduleOrPlaceChanged reports whether attendees need to hear about the
+// update: the event moved in time or to another place.
+func scheduleOrPlaceChanged(before, after *models.Event) bool {
+	return !before.Date.Equal(after.Date) ||
+		!before.EndDate.Equal(after.EndDate) ||
+		before.Timezone != after.Timezone ||
+		before.Recurrence != after.Recurrence ||
+		before.Location.Lat != after.Location.Lat ||
+		before.Location.Lng != after.Location.Lng ||
+		before.Location.Address != after.Location.Address
+}
+
+// GetEventOccurrences expands the event into its occurrences within the
+// window [from, to).
+func (s *EventService) GetEventOccurrences(ctx context.Context, actor *models.Principal, eventID string, from, to time.Time) ([]models.EventOccurrence, error) {
+	if err := validateOccurrenceWindow(from, to); err != nil {
+		return nil, err
+	}
+
+	event, err := s.GetEvent(ctx, actor, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, fmt.Errorf("event %w", ErrNotFound)
+	}
+
+	overrides, err := s.eventRepository.GetOccurrenceOverrides(ctx, []string{event.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return expandOccurrences(event, overrides, from, to)
+}
+
+// ListOccurrences returns the occurrences of all approved events, one-off
+// and recurring, within the window [from, to), ordered by start.
+func (s *EventService) ListOccurrences(ctx context.Context, from, to time.Time) ([]models.EventOccurrence, error) {
+	if err := validateOccurrenceWindow(from, to); err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepository.GetEventsInWindow(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	eventIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		if event.IsRecurring() {
+			eventIDs = append(eventIDs, event.ID)
+		}
+	}
+
+	overrides, err := s.eventRepository.GetOccurrenceOverrides(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	overridesByEvent := make(map[string][]models.OccurrenceOverride)
+	for _, override := range overrides {
+		overridesByEvent[override.EventID] = append(overridesByEvent[override.EventID], override)
+	}
+
+	occurrences := []models.EventOccurrence{}
+	for i := range events {
+		expanded, err := expandOccurrences(&events[i], overridesByEvent[events[i].ID], from, to)
+		if err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, expanded...)
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+
+	return occurrences, nil
+}
+
+// UpdateOccurrence edits one occurrence of a recurring event, the occurrence
+// and the ones after it, or the whole series.
+func (s *EventService) UpdateOccurrence(ctx context.Context, actor *models.Principal, eventID string, request *models.OccurrenceUpdateRequest) error {
+	if !request.Scope.IsValid() {
+		return fmt.Errorf("%w: invalid scope", ErrInvalidArgument)
 	}
 
 	existingEvent, err := s.eventRepository.GetEvent(ctx, eventID)
@@ -139,16 +424,36 @@ func (s *EventService) ApproveEvent(ctx context.Context, eventID string) error {
 		return errors.New("event not found")
 	}
 
-	if existingEvent.ModerationStatus == constants.EventModerationStatusApproved {
-		return errors.New("event is already approved")
+	if !canEditEvent(actor, existingEvent) {
+		return ErrForbidden
 	}
 
-	return s.eventRepository.ApproveEvent(ctx, eventID)
+	request.Event.ID = eventID
+	if request.Scope == constants.RecurrenceScopeAll {
+		return s.UpdateEvent(ctx, actor, &request.Event)
+	}
+
+	occurrence, err := findOccurrence(existingEvent, request.Occurrence)
+	if err != nil {
+		return err
+	}
+
+	if request.Scope == constants.RecurrenceScopeThis {
+		return s.overrideOccurrence(ctx, actor, existingEvent, occurrence, &request.Event)
+	}
+
+	if occurrence.Equal(existingEvent.Date) {
+		return s.UpdateEvent(ctx, actor, &request.Event)
+	}
+
+	return s.splitEventSeries(ctx, actor, existingEvent, occurrence, &request.Event)
 }
 
-func (s *EventService) RejectEvent(ctx context.Context, eventID string) error {
-	if eventID == "" {
-		return errors.New("event ID is required")
+// DeleteOccurrence cancels one occurrence of a recurring event, the
+// occurrence and the ones after it, or deletes the whole series.
+func (s *EventService) DeleteOccurrence(ctx context.Context, actor *models.Principal, eventID string, request *models.OccurrenceDeleteRequest) error {
+	if !request.Scope.IsValid() {
+		return fmt.Errorf("%w: invalid scope", ErrInvalidArgument)
 	}
 
 	existingEvent, err := s.eventRepository.GetEvent(ctx, eventID)
@@ -160,41 +465,943 @@ func (s *EventService) RejectEvent(ctx context.Context, eventID string) error {
 		return errors.New("event not found")
 	}
 
-	if existingEvent.ModerationStatus == constants.EventModerationStatusRejected {
-		return errors.New("event is already rejected")
+	if request.Scope == constants.RecurrenceScopeAll || !existingEvent.IsRecurring() {
+		return s.DeleteEvent(ctx, actor, eventID)
+	}
+
+	if !canEditEvent(actor, existingEvent) {
+		return ErrForbidden
+	}
+
+	occurrence, err := findOccurrence(existingEvent, request.Occurrence)
+	if err != nil {
+		return err
+	}
+
+	if request.Scope == constants.RecurrenceScopeFollowing && occurrence.Equal(existingEvent.Date) {
+		return s.DeleteEvent(ctx, actor, eventID)
+	}
+
+	var event *models.Event
+	if request.Scope == constants.RecurrenceScopeThis {
+		event = new(models.Event)
+		*event = *existingEvent
+		exceptions := append(models.Times{occurrence}, existingEvent.RecurrenceExceptions...)
+		if err := applyRecurrence(event, existingEvent.Recurrence, exceptions); err != nil {
+			return err
+		}
+	} else {
+		if event, _, err = truncateEventSeries(existingEvent, occurrence); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	event.Status = event.StatusAt(now)
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventRepository.UpdateEvent(ctx, event); err != nil {
+			return err
+		}
+
+		if request.Scope == constants.RecurrenceScopeFollowing {
+			return s.eventRepository.DeleteOccurrenceOverrides(ctx, eventID, &occurrence)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if event.Status != existingEvent.Status {
+		s.publishStatusChange(ctx, event, existingEvent.Status, now)
+	}
+
+	return nil
+}
+
+// overrideOccurrence changes one occurrence of a series. Moderators approved
+// what the series says, so an occurrence's own title or description is
+// screened like an edit of the series, which goes back to moderation unless
+// screening approves the new text.
+func (s *EventService) overrideOccurrence(ctx context.Context, actor *models.Principal, event *models.Event, occurrence time.Time, eventRequest *models.EventRequest) error {
+	now := time.Now()
+	override := &models.OccurrenceOverride{
+		ID:             uuid.New().String(),
+		EventID:        event.ID,
+		OccurrenceDate: occurrence,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if eventRequest.Title != "" {
+		override.Title = &eventRequest.Title
+	}
+
+	if eventRequest.Description != "" {
+		override.Description = &eventRequest.Description
+	}
+
+	if eventRequest.Date != "" {
+		schedule, err := parseEventSchedule(eventRequest, event.Timezone)
+		if err != nil {
+			return err
+		}
+		override.Date = &schedule.start
+		override.EndDate = &schedule.end
+	}
+
+	screened := *event
+	if override.Title != nil {
+		screened.Title = *override.Title
+	}
+	if override.Description != nil {
+		screened.Description = *override.Description
+	}
+
+	screening, err := s.rescreenEvent(ctx, actor, event, &screened)
+	if err != nil {
+		return err
+	}
+
+	// The series keeps its own text and takes only the moderation outcome.
+	updated := *event
+	if screening != nil {
+		updated.ModerationStatus = screened.ModerationStatus
+		updated.ModerationReason = screened.ModerationReason
+		updated.SubmittedAt = screened.SubmittedAt
+		updated.ScreeningScore = screened.ScreeningScore
+		updated.ScreeningFindings = screened.ScreeningFindings
+	}
+
+	var moderation *models.EventModeration
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if screening != nil {
+			if err := s.eventRepository.UpdateEvent(ctx, &updated); err != nil {
+				return err
+			}
+		}
+
+		if err := s.eventRepository.UpsertOccurrenceOverride(ctx, override); err != nil {
+			return err
+		}
+
+		var err error
+		moderation, err = s.recordRescreening(ctx, actor, event, &updated, screening)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishModeration(ctx, moderation)
+	return nil
+}
+
+// splitEventSeries ends the series before the occurrence and starts a new
+// series from it with the requested changes. Without a recurrence in the
+// request the new series continues the original rule.
+func (s *EventService) splitEventSeries(ctx context.Context, actor *models.Principal, existingEvent *models.Event, occurrence time.Time, eventRequest *models.EventRequest) error {
+	if eventRequest.Title == "" {
+		return errors.New("title is required")
+	}
+
+	if eventRequest.Capacity != nil && *eventRequest.Capacity <= 0 {
+		return errors.New("capacity must be positive")
+	}
+
+	if eventRequest.Date == "" {
+		eventRequest.Date = occurrence.Format(time.RFC3339)
+		eventRequest.EndDate = occurrence.Add(existingEvent.EndDate.Sub(existingEvent.Date)).Format(time.RFC3339)
+		eventRequest.Duration = ""
+	}
+
+	schedule, err := parseEventSchedule(eventRequest, existingEvent.Timezone)
+	if err != nil {
+		return err
+	}
+
+	truncated, remaining, err := truncateEventSeries(existingEvent, occurrence)
+	if err != nil {
+		return err
+	}
+
+	rule := eventRequest.Recurrence
+	if rule == "" {
+		rule = remaining
+	}
+
+	coOrganizers := existingEvent.CoOrganizers
+	if eventRequest.CoOrganizers != nil && canDeleteEvent(actor, existingEvent) {
+		if err := s.validateCoOrganizers(ctx, eventRequest.CoOrganizers); err != nil {
+			return err
+		}
+		coOrganizers = eventRequest.CoOrganizers
+	}
+
+	now := time.Now()
+	continuation := &models.Event{
+		ID:                uuid.New().String(),
+		Title:             eventRequest.Title,
+		Description:       eventRequest.Description,
+		Date:              schedule.start,
+		EndDate:           schedule.end,
+		Timezone:          schedule.timezone,
+		Organizer:         existingEvent.Organizer,
+		CoOrganizers:      coOrganizers,
+		ModerationStatus:  existingEvent.ModerationStatus,
+		ModerationReason:  existingEvent.ModerationReason,
+		SubmittedAt:       existingEvent.SubmittedAt,
+		ScreeningScore:    existingEvent.ScreeningScore,
+		ScreeningFindings: existingEvent.ScreeningFindings,
+		Location:          eventRequest.Location,
+		Tags:              eventRequest.Tags,
+		Capacity:          eventRequest.Capacity,
+		Image:             eventRequest.Image,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	var exceptions models.Times
+	for _, exception := range existingEvent.RecurrenceExceptions {
+		if !exception.Before(occurrence) {
+			exceptions = append(exceptions, exception)
+		}
+	}
+
+	if err := applyRecurrence(continuation, rule, exceptions); err != nil {
+		return err
+	}
+
+	continuation.Status, err = resolveEventStatus(continuation, eventRequest.Status, now)
+	if err != nil {
+		return err
+	}
+
+	truncated.Status = truncated.StatusAt(now)
+
+	screening, err := s.rescreenEvent(ctx, actor, existingEvent, continuation)
+	if err != nil {
+		return err
+	}
+
+	var moderation *models.EventModeration
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.eventRepository.SplitEventSeries(ctx, truncated, continuation, occurrence); err != nil {
+			return err
+		}
+
+		var err error
+		moderation, err = s.recordRescreening(ctx, actor, existingEvent, continuation, screening)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishModeration(ctx, moderation)
+
+	if truncated.Status != existingEvent.Status {
+		s.publishStatusChange(ctx, truncated, existingEvent.Status, now)
+	}
+
+	return nil
+}
+
+// SyncEventStatuses moves events whose schedule says they have started or
+// finished to the matching status and reports how many changed.
+func (s *EventService) SyncEventStatuses(ctx context.Context, now time.Time) (int, error) {
+	events, err := s.eventRepository.GetEventsToTransition(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for i := range events {
+		event := &events[i]
+
+		status := event.StatusAt(now)
+		if status == event.Status {
+			continue
+		}
+
+		// The guard on the old status makes a concurrent edit win over the
+		// scheduler instead of being overwritten.
+		updated, err := s.eventRepository.UpdateEventStatus(ctx, event.ID, event.Status, status)
+		if err != nil {
+			return changed, err
+		}
+
+		if !updated {
+			continue
+		}
+
+		from := event.Status
+		event.Status = status
+		s.publishStatusChange(ctx, event, from, now)
+		changed++
+	}
+
+	return changed, nil
+}
+
+func (s *EventService) publishStatusChange(ctx context.Context, event *models.Event, from constants.EventStatus, now time.Time) {
+	s.messageBus.Publish(ctx, constants.TopicEventStatusChanged, &models.EventStatusChange{
+		EventID:   event.ID,
+		Organizer: event.Organizer,
+		From:      from,
+		To:        event.Status,
+		ChangedAt: now,
+	})
+}
+
+// screenEvent runs automated screening over the event and applies its
+// verdict to the moderation status.
+func (s *EventService) screenEvent(ctx context.Context, actor *models.Principal, event *models.Event) (*models.ScreeningResult, error) {
+	result, err := s.screeningService.Screen(ctx, actor, event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.ScreeningScore = result.Score
+	event.ScreeningFindings = result.Findings
+	event.ModerationReason = ""
+
+	switch result.Verdict {
+	case constants.ScreeningVerdictApprove:
+		event.ModerationStatus = constants.EventModerationStatusApproved
+	case constants.ScreeningVerdictReject:
+		event.ModerationStatus = constants.EventModerationStatusRejected
+		event.ModerationReason = "automatic screening: " + result.Findings.Summary()
+	default:
+		event.ModerationStatus = constants.EventModerationStatusPending
+	}
+
+	return result, nil
+}
+
+// rescreenEvent screens an edited event when what moderators review has
+// changed, since they approved what the event said, not what it says now.
+// Moderators' own edits, rejected events, which go back to moderation by
+// resubmission, and events hidden because of reports are not screened; nil is
+// returned for them.
+func (s *EventService) rescreenEvent(ctx context.Context, actor *models.Principal, before, after *models.Event) (*models.ScreeningResult, error) {
+	if before.ModerationStatus == constants.EventModerationStatusRejected ||
+		before.ModerationStatus == constants.EventModerationStatusHidden ||
+		actor.Can(constants.PermissionModerateEvents) ||
+		!contentChanged(before, after) {
+		return nil, nil
+	}
+
+	result, err := s.screenEvent(ctx, actor, after)
+	if err != nil {
+		return nil, err
+	}
+
+	// An approved event sent back to moderation joins the end of the queue.
+	if before.ModerationStatus == constants.EventModerationStatusApproved &&
+		after.ModerationStatus == constants.EventModerationStatusPending {
+		after.SubmittedAt = time.Now()
+	}
+
+	return result, nil
+}
+
+// recordRescreening records the outcome of rescreenEvent. An approved event
+// that stays approved leaves no trace in the moderation history.
+func (s *EventService) recordRescreening(ctx context.Context, actor *models.Principal, before, after *models.Event, result *models.ScreeningResult) (*models.EventModeration, error) {
+	if result == nil {
+		return nil, nil
+	}
+
+	if before.ModerationStatus != constants.EventModerationStatusApproved {
+		return s.recordScreening(ctx, actor, after, "", result)
+	}
+
+	if after.ModerationStatus == constants.EventModerationStatusApproved {
+		return nil, nil
+	}
+
+	return s.recordScreening(ctx, actor, after, constants.ModerationActionEdited, result)
+}
+
+// recordScreening records the actor's submission, when given, followed by
+// the screening decision in the moderation history. Events left for review
+// are recorded as flagged only when screening found something. It returns
+// the decision to announce once it is committed, if screening made one.
+func (s *EventService) recordScreening(ctx context.Context, actor *models.Principal, event *models.Event, submitted constants.ModerationAction, result *models.ScreeningResult) (*models.EventModeration, error) {
+	now := time.Now()
+
+	if submitted != "" {
+		if err := s.moderationRepository.RecordAction(ctx, &models.ModerationAction{
+			ID:        uuid.New().String(),
+			EventID:   event.ID,
+			ActorID:   actor.ID,
+			Action:    submitted,
+			CreatedAt: now,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	var action constants.ModerationAction
+	switch {
+	case result.Verdict == constants.ScreeningVerdictApprove:
+		action = constants.ModerationActionApproved
+	case result.Verdict == constants.ScreeningVerdictReject:
+		action = constants.ModerationActionRejected
+	case len(result.Findings) > 0:
+		action = constants.ModerationActionFlagged
+	default:
+		return nil, nil
+	}
+
+	if err := s.moderationRepository.RecordAction(ctx, &models.ModerationAction{
+		ID:        uuid.New().String(),
+		EventID:   event.ID,
+		ActorID:   constants.ModerationActorSystem,
+		Action:    action,
+		Reason:    result.Findings.Summary(),
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
 	}
 
-	return s.eventRepository.RejectEvent(ctx, eventID)
+	if action == constants.ModerationActionFlagged {
+		return nil, nil
+	}
+
+	return &models.EventModeration{
+		EventID:     event.ID,
+		Title:       event.Title,
+		Organizer:   event.Organizer,
+		ModeratorID: constants.ModerationActorSystem,
+		Status:      event.ModerationStatus,
+		Reason:      event.ModerationReason,
+	}, nil
+}
+
+// publishModeration announces a screening decision.
+func (s *EventService) publishModeration(ctx context.Context, moderation *models.EventModeration) {
+	if moderation != nil {
+		s.messageBus.Publish(ctx, constants.TopicEventModerated, moderation)
+	}
 }
 
-func (s *EventService) GetEvent(ctx context.Context, eventID string) (*models.Event, error) {
+// GetEvent returns the event, or nil when it does not exist or the actor may
+// not see it.
+func (s *EventService) GetEvent(ctx context.Context, actor *models.Principal, eventID string) (*models.Event, error) {
 	if eventID == "" {
 		return nil, errors.New("event ID is required")
 	}
 
-	return s.eventRepository.GetEvent(ctx, eventID)
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil || event == nil || !canViewEvent(actor, event) {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ListEvents returns one page of events matching the filter. Only moderators
+// see events that have not been approved.
+func (s *EventService) ListEvents(ctx context.Context, actor *models.Principal, filter *models.EventFilter) (*models.EventPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEventPageSize
+	}
+
+	if filter.Limit > maxEventPageSize {
+		filter.Limit = maxEventPageSize
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = constants.EventSortDateAsc
+	}
+
+	if !filter.Sort.IsValid() {
+		return nil, fmt.Errorf("%w: invalid sort", ErrInvalidArgument)
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return nil, fmt.Errorf("%w: invalid date range", ErrInvalidArgument)
+	}
+
+	filter.IncludeUnapproved = actor.Can(constants.PermissionModerateEvents)
+
+	var cursor *models.EventCursor
+	if filter.Cursor != "" {
+		decoded, err := decodeEventCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = decoded
+	}
+
+	events, err := s.eventRepository.ListEvents(ctx, filter, cursor, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.EventPage{Events: events}
+	if len(events) > filter.Limit {
+		page.Events = events[:filter.Limit]
+		last := page.Events[filter.Limit-1]
+		page.NextCursor, err = encodeEventCursor(&models.EventCursor{
+			Value: last.SortValue(filter.Sort),
+			ID:    last.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// SearchEvents finds approved events by words in the title, description,
+// address or tags. The last word is matched as a prefix so results can be
+// shown while the user is typing.
+func (s *EventService) SearchEvents(ctx context.Context, text string, limit, offset int) ([]models.EventSearchResult, error) {
+	tsquery := buildPrefixTSQuery(text)
+	if tsquery == "" {
+		return nil, fmt.Errorf("%w: search query is required", ErrInvalidArgument)
+	}
+
+	if limit <= 0 {
+		limit = defaultEventPageSize
+	}
+
+	if limit > maxEventPageSize {
+		limit = maxEventPageSize
+	}
+
+	if offset < 0 {
+		return nil, fmt.Errorf("%w: invalid offset", ErrInvalidArgument)
+	}
+
+	results, err := s.eventRepository.SearchEvents(ctx, tsquery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Localize()
+	}
+
+	return results, nil
+}
+
+// GetEventsNearby returns approved events within radiusKm of the point,
+// nearest first. A zero radius means the default one.
+func (s *EventService) GetEventsNearby(ctx context.Context, lat, lng, radiusKm float64) ([]models.EventWithDistance, error) {
+	if !validLatLng(lat, lng) {
+		return nil, fmt.Errorf("%w: invalid coordinates", ErrInvalidArgument)
+	}
+
+	if radiusKm == 0 {
+		radiusKm = defaultNearbyRadiusKm
+	}
+
+	if radiusKm < 0 || radiusKm > maxNearbyRadiusKm {
+		return nil, fmt.Errorf("%w: radius must be between 0 and %d km", ErrInvalidArgument, maxNearbyRadiusKm)
+	}
+
+	return s.eventRepository.FindEventsNearby(ctx, &models.NearbyQuery{
+		Lat:      lat,
+		Lng:      lng,
+		RadiusKm: radiusKm,
+		Box:      boundingBoxAround(lat, lng, radiusKm),
+		Limit:    maxNearbyResults,
+	})
+}
+
+// GetEventsInViewport returns the approved events inside the map viewport,
+// or clusters of them when there are too many to draw one by one. A viewport
+// with MinLng greater than MaxLng crosses the antimeridian.
+func (s *EventService) GetEventsInViewport(ctx context.Context, box models.BoundingBox) (*models.MapViewport, error) {
+	if !validLatLng(box.MinLat, box.MinLng) || !validLatLng(box.MaxLat, box.MaxLng) ||
+		box.MinLat >= box.MaxLat || box.MinLng == box.MaxLng {
+		return nil, fmt.Errorf("%w: invalid bbox", ErrInvalidArgument)
+	}
+
+	count, err := s.eventRepository.CountEventsInBox(ctx, box)
+	if err != nil {
+		return nil, err
+	}
+
+	if count <= maxMapPins {
+		events, err := s.eventRepository.FindEventsInBox(ctx, box)
+		if err != nil {
+			return nil, err
+		}
+		return &models.MapViewport{Events: events}, nil
+	}
+
+	clusters, err := s.eventRepository.ClusterEventsInBox(ctx, box,
+		(box.MaxLat-box.MinLat)/mapGridSize,
+		box.LngSpan()/mapGridSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MapViewport{Clusters: clusters}, nil
 }
 
-func (s *EventService) GetEventsByOrganizer(ctx context.Context, organizerID string) ([]models.Event, error) {
+func (s *EventService) GetEventsByOrganizer(ctx context.Context, actor *models.Principal, organizerID string) ([]models.Event, error) {
 	if organizerID == "" {
 		return nil, errors.New("organizer ID is required")
 	}
 
-	return s.eventRepository.GetEventsByOrganizer(ctx, organizerID)
+	events, err := s.eventRepository.GetEventsByOrganizer(ctx, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return visibleEvents(actor, events), nil
 }
 
-func (s *EventService) GetEventsByStatus(ctx context.Context, status constants.EventStatus) ([]models.Event, error) {
+func (s *EventService) GetEventsByStatus(ctx context.Context, actor *models.Principal, status constants.EventStatus) ([]models.Event, error) {
 	if status == "" {
 		return nil, errors.New("status is required")
 	}
 
-	return s.eventRepository.GetEventsByStatus(ctx, status)
+	events, err := s.eventRepository.GetEventsByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return visibleEvents(actor, events), nil
 }
 
-func (s *EventService) GetEventsByModerationStatus(ctx context.Context, status constants.EventModerationStatus) ([]models.Event, error) {
+func (s *EventService) GetEventsByModerationStatus(ctx context.Context, actor *models.Principal, status constants.EventModerationStatus) ([]models.Event, error) {
+	if !actor.Can(constants.PermissionModerateEvents) {
+		return nil, ErrForbidden
+	}
+
 	if status == "" {
 		return nil, errors.New("moderation status is required")
 	}
 
 	return s.eventRepository.GetEventsByModerationStatus(ctx, status)
 }
+
+// canViewEvent reports whether the actor may see the event. Events that are
+// not approved, hidden ones included, are seen only by those who can edit
+// them and by moderators. The actor is nil for anonymous requests.
+func canViewEvent(actor *models.Principal, event *models.Event) bool {
+	return event.ModerationStatus == constants.EventModerationStatusApproved ||
+		canEditEvent(actor, event) ||
+		actor.Can(constants.PermissionModerateEvents)
+}
+
+// visibleEvents filters events down to those the actor may see.
+func visibleEvents(actor *models.Principal, events []models.Event) []models.Event {
+	visible := events[:0]
+	for _, event := range events {
+		if canViewEvent(actor, &event) {
+			visible = append(visible, event)
+		}
+	}
+
+	return visible
+}
+
+// canEditEvent reports whether the actor may change the event: its owner,
+// one of its co-organizers, or anyone allowed to manage all events.
+func canEditEvent(actor *models.Principal, event *models.Event) bool {
+	if actor == nil {
+		return false
+	}
+
+	return event.CanBeManagedBy(actor.ID) || actor.Can(constants.PermissionManageAnyEvent)
+}
+
+// canDeleteEvent is stricter than canEditEvent: co-organizers may edit an
+// event but neither delete it nor change who co-organizes it.
+func canDeleteEvent(actor *models.Principal, event *models.Event) bool {
+	if actor == nil {
+		return false
+	}
+
+	return event.IsOrganizedBy(actor.ID) || actor.Can(constants.PermissionManageAnyEvent)
+}
+
+// contentChanged reports whether the parts of the event that moderators
+// review differ between the two versions.
+func contentChanged(before, after *models.Event) bool {
+	if before.Title != after.Title || before.Description != after.Description {
+		return true
+	}
+
+	if (before.Image == nil) != (after.Image == nil) {
+		return true
+	}
+
+	return before.Image != nil && *before.Image != *after.Image
+}
+
+func encodeEventCursor(cursor *models.EventCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeEventCursor(value string) (*models.EventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidArgument)
+	}
+
+	var cursor models.EventCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidArgument)
+	}
+
+	return &cursor, nil
+}
+
+// validateCoOrganizers checks that every co-organizer is an existing user
+// with the organizer role.
+func (s *EventService) validateCoOrganizers(ctx context.Context, coOrganizers models.UserIDs) error {
+	if len(coOrganizers) == 0 {
+		return nil
+	}
+
+	users, err := s.userRepository.GetUsersByIDs(ctx, coOrganizers)
+	if err != nil {
+		return err
+	}
+
+	organizers := make(map[string]bool, len(users))
+	for _, user := range users {
+		organizers[user.ID] = user.Role == constants.UserRoleOrganizer
+	}
+
+	for _, id := range coOrganizers {
+		if !organizers[id] {
+			return fmt.Errorf("%w: co-organizer %s is not an organizer", ErrInvalidArgument, id)
+		}
+	}
+
+	return nil
+}
+
+func validLatLng(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// boundingBoxAround returns a box enclosing the circle of radiusKm around the
+// point. Near the poles the box spans all longitudes.
+func boundingBoxAround(lat, lng, radiusKm float64) models.BoundingBox {
+	latDelta := radiusKm / kmPerDegreeLat
+	box := models.BoundingBox{
+		MinLat: math.Max(lat-latDelta, -90),
+		MaxLat: math.Min(lat+latDelta, 90),
+		MinLng: -180,
+		MaxLng: 180,
+	}
+
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+
+	lngDelta := radiusKm / (kmPerDegreeLat * math.Cos(lat*math.Pi/180))
+	if lngDelta >= 180 {
+		return box
+	}
+
+	box.MinLng = normalizeLng(lng - lngDelta)
+	box.MaxLng = normalizeLng(lng + lngDelta)
+
+	return box
+}
+
+func normalizeLng(lng float64) float64 {
+	if lng < -180 {
+		return lng + 360
+	}
+	if lng > 180 {
+		return lng - 360
+	}
+	return lng
+}
+
+// buildPrefixTSQuery turns free text into a to_tsquery expression that
+// requires every word and matches the last one as a prefix. Everything but
+// letters and digits is dropped, so user input cannot inject tsquery
+// operators.
+func buildPrefixTSQuery(text string) string {
+	terms := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(terms) == 0 {
+		return ""
+	}
+
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+
+	terms[len(terms)-1] += ":*"
+
+	return strings.Join(terms, " & ")
+}
+
+// resolveEventStatus returns the status the event has at now. A status sent
+// by the client is accepted only when it agrees with the schedule.
+func resolveEventStatus(event *models.Event, requested constants.EventStatus, now time.Time) (constants.EventStatus, error) {
+	status := event.StatusAt(now)
+	if requested != "" && requested != status {
+		return "", fmt.Errorf("%w: status %q contradicts the event schedule, expected %q", ErrInvalidArgument, requested, status)
+	}
+
+	return status, nil
+}
+
+type eventSchedule struct {
+	start    time.Time
+	end      time.Time
+	timezone string
+}
+
+// Layouts accepted for event times without an offset. Such times are read as
+// wall-clock time at the venue.
+var localEventTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// parseEventSchedule reads the start, the end (given directly or as a
+// duration) and the venue timezone of the requested event. The timezone
+// defaults to defaultTimezone when the request leaves it out.
+func parseEventSchedule(eventRequest *models.EventRequest, defaultTimezone string) (*eventSchedule, error) {
+	schedule := &eventSchedule{timezone: eventRequest.Timezone}
+	if schedule.timezone == "" {
+		schedule.timezone = defaultTimezone
+	}
+
+	loc, err := time.LoadLocation(schedule.timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidArgument, schedule.timezone)
+	}
+
+	if eventRequest.Date == "" {
+		return nil, fmt.Errorf("%w: date is required", ErrInvalidArgument)
+	}
+
+	if schedule.start, err = parseEventTime(eventRequest.Date, loc); err != nil {
+		return nil, fmt.Errorf("%w: invalid date format", ErrInvalidArgument)
+	}
+
+	switch {
+	case eventRequest.EndDate != "":
+		if schedule.end, err = parseEventTime(eventRequest.EndDate, loc); err != nil {
+			return nil, fmt.Errorf("%w: invalid endDate format", ErrInvalidArgument)
+		}
+	case eventRequest.Duration != "":
+		duration, err := models.ParseEventDuration(eventRequest.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+		}
+		schedule.end = schedule.start.Add(duration)
+	default:
+		return nil, fmt.Errorf("%w: endDate is required", ErrInvalidArgument)
+	}
+
+	if !schedule.end.After(schedule.start) {
+		return nil, fmt.Errorf("%w: endDate must be after date", ErrInvalidArgument)
+	}
+
+	schedule.start = schedule.start.UTC()
+	schedule.end = schedule.end.UTC()
+
+	return schedule, nil
+}
+
+func parseEventTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	var err error
+	for _, layout := range localEventTimeLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// findOccurrence checks that the event has an occurrence starting at the
+// given time and returns that time in UTC.
+func findOccurrence(event *models.Event, occurrence time.Time) (time.Time, error) {
+	if !event.IsRecurring() {
+		return time.Time{}, fmt.Errorf("%w: event is not recurring", ErrInvalidArgument)
+	}
+
+	occurrence = occurrence.UTC()
+
+	found, err := isOccurrenceOf(event, occurrence)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !found {
+		return time.Time{}, fmt.Errorf("%w: event has no occurrence at %s", ErrInvalidArgument, occurrence.Format(time.RFC3339))
+	}
+
+	return occurrence, nil
+}
+
+// truncateEventSeries returns a copy of the series ending right before the
+// occurrence, and the rule that continues the series from the occurrence on.
+func truncateEventSeries(event *models.Event, occurrence time.Time) (*models.Event, string, error) {
+	option, err := parseRecurrenceRule(event.Recurrence)
+	if err != nil {
+		return nil, "", err
+	}
+
+	remaining := *option
+	if option.Count > 0 {
+		before, err := countOccurrencesBefore(event, option, occurrence)
+		if err != nil {
+			return nil, "", err
+		}
+		remaining.Count = option.Count - before
+	}
+
+	option.Count = 0
+	option.Until = occurrence.Add(-time.Second)
+
+	var exceptions models.Times
+	for _, exception := range event.RecurrenceExceptions {
+		if exception.Before(occurrence) {
+			exceptions = append(exceptions, exception)
+		}
+	}
+
+	truncated := new(models.Event)
+	*truncated = *event
+	if err := applyRecurrence(truncated, option.RRuleString(), exceptions); err != nil {
+		return nil, "", err
+	}
+
+	return truncated, remaining.RRuleString(), nil
+}
+
+func validateOccurrenceWindow(from, to time.Time) error {
+	if !to.After(from) {
+		return fmt.Errorf("%w: invalid date range", ErrInvalidArgument)
+	}
+
+	if to.Sub(from) > maxOccurrenceWindow {
+		return fmt.Errorf("%w: date range may span at most %d days", ErrInvalidArgument, int(maxOccurrenceWindow.Hours()/24))
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package services_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+)
+
+// fakeTransactor runs transactions in place. When one fails it calls the
+// rollback that snapshot returned before it started, if snapshot is set.
+type fakeTransactor struct {
+	snapshot func() (rollback func())
+}
+
+func (t *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	rollback := func() {}
+	if t.snapshot != nil {
+		rollback = t.snapshot()
+	}
+
+	if err := fn(ctx); err != nil {
+		rollback()
+		return err
+	}
+
+	return nil
+}
+
+// newTestConfig returns a configuration every service accepts.
+func newTestConfig() *config.Config {
+	cfg := &config.Config{AppURL: "http://localhost:3000", Environment: "test"}
+	cfg.JWT = config.JWTConfig{Secret: "jwt-secret", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}
+	cfg.Auth.MFAEncryptionKey = "mfa-key"
+	cfg.Tickets.SigningSecret = "ticket-secret"
+	cfg.OIDC.StateTTL = time.Minute
+	return cfg
+}
@@ -1,23 +1,27 @@
 package services
 
 import (
+	"context"
 	"errors"
 
+	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
 	"github.com/EventFlow-Project/backend/internal/core/ports"
 )
 
 type FriendService struct {
-	repo ports.FriendRepository
+	repo       ports.FriendRepository
+	messageBus ports.MessageBus
 }
 
-func NewFriendService(repo ports.FriendRepository) *FriendService {
+func NewFriendService(repo ports.FriendRepository, messageBus ports.MessageBus) *FriendService {
 	return &FriendService{
-		repo: repo,
+		repo:       repo,
+		messageBus: messageBus,
 	}
 }
 
-func (s *FriendService) SendFriendRequest(fromID, toID string) (*models.FriendRequestResponse, error) {
+func (s *FriendService) SendFriendRequest(ctx context.Context, fromID, toID string) (*models.FriendRequestResponse, error) {
 	exists, err := s.repo.CheckExistingRequest(fromID, toID)
 	if err != nil {
 		return nil, err
@@ -27,10 +31,21 @@ func (s *FriendService) SendFriendRequest(fromID, toID string) (*models.FriendRe
 		return nil, errors.New("friend request already exists")
 	}
 
-	return s.repo.CreateFriendRequest(fromID, toID)
+	response, err := s.repo.CreateFriendRequest(fromID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.messageBus.Publish(ctx, constants.TopicFriendRequestSent, &models.FriendRequestNotice{
+		RequestID: response.ID,
+		FromID:    response.FromID,
+		ToID:      response.ToID,
+	})
+
+	return response, nil
 }
 
-func (s *FriendService) RespondToFriendRequest(friendID string, accept bool) error {
+func (s *FriendService) RespondToFriendRequest(ctx context.Context, friendID string, accept bool) error {
 	request, err := s.repo.GetFriendRequestByFromID(friendID)
 	if err != nil {
 		return err
@@ -41,7 +56,18 @@ func (s *FriendService) RespondToFriendRequest(friendID string, accept bool) err
 		status = "accepted"
 	}
 
-	return s.repo.UpdateFriendRequestStatus(request.ID, status)
+	if err := s.repo.UpdateFriendRequestStatus(request.ID, status); err != nil {
+		return err
+	}
+
+	s.messageBus.Publish(ctx, constants.TopicFriendRequestAnswered, &models.FriendRequestAnswer{
+		RequestID: request.ID,
+		FromID:    request.FromID,
+		ToID:      request.ToID,
+		Accepted:  accept,
+	})
+
+	return nil
 }
 
 func (s *FriendService) GetFriendsList(userID string) (*models.FriendListResponse, error) {
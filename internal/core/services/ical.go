@@ -0,0 +1,189 @@
+package services
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+const (
+	icalProductID    = "-//EventFlow//EventFlow//RU"
+	icalUIDDomain    = "eventflow"
+	icalDateTimeUTC  = "20060102T150405Z"
+	icalDateTimeZone = "20060102T150405"
+	// icalLineLimit is the maximum length of a content line in octets,
+	// excluding the line break.
+	icalLineLimit = 75
+)
+
+// icalWriter renders RFC 5545 content lines.
+type icalWriter struct {
+	buf bytes.Buffer
+}
+
+// line writes a content line, folding it at icalLineLimit octets without
+// splitting UTF-8 sequences.
+func (w *icalWriter) line(name, value string) {
+	content := name + ":" + value
+	limit := icalLineLimit
+
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space that counts toward the limit.
+		limit = icalLineLimit - 1
+	}
+
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
+
+func (w *icalWriter) text(name, value string) {
+	w.line(name, escapeICalText(value))
+}
+
+// dateTime writes a time in the event timezone when given, so that
+// recurrence rules follow the venue's DST changes, and in UTC otherwise.
+// Calendar clients resolve IANA TZIDs without an accompanying VTIMEZONE.
+func (w *icalWriter) dateTime(name string, t time.Time, timezone string) {
+	if timezone == "" || timezone == "UTC" {
+		w.line(name, t.UTC().Format(icalDateTimeUTC))
+		return
+	}
+
+	w.line(name+";TZID="+timezone, t.In(models.LoadLocation(timezone)).Format(icalDateTimeZone))
+}
+
+func (w *icalWriter) begin() {
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", icalProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+}
+
+func (w *icalWriter) end() []byte {
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// event writes the event as a VEVENT, followed by one VEVENT per overridden
+// occurrence when the event recurs.
+func (w *icalWriter) event(event *models.Event, overrides []models.OccurrenceOverride) {
+	timezone := ""
+	if event.IsRecurring() {
+		timezone = event.Timezone
+	}
+
+	w.line("BEGIN", "VEVENT")
+	w.eventHeader(event)
+	w.dateTime("DTSTART", event.Date, timezone)
+	w.dateTime("DTEND", event.EndDate, timezone)
+	w.text("SUMMARY", event.Title)
+	w.eventDetails(event)
+
+	if event.IsRecurring() {
+		w.line("RRULE", event.Recurrence)
+		for _, exception := range event.RecurrenceExceptions {
+			w.dateTime("EXDATE", exception, timezone)
+		}
+	}
+	w.line("END", "VEVENT")
+
+	if !event.IsRecurring() {
+		return
+	}
+
+	duration := event.EndDate.Sub(event.Date)
+	for i := range overrides {
+		override := &overrides[i]
+		if event.RecurrenceExceptions.Contains(override.OccurrenceDate) {
+			continue
+		}
+
+		start, end := override.OccurrenceDate, override.OccurrenceDate.Add(duration)
+		if override.Date != nil && override.EndDate != nil {
+			start, end = *override.Date, *override.EndDate
+		}
+
+		title := event.Title
+		if override.Title != nil {
+			title = *override.Title
+		}
+
+		occurrence := *event
+		if override.Description != nil {
+			occurrence.Description = *override.Description
+		}
+
+		w.line("BEGIN", "VEVENT")
+		w.eventHeader(event)
+		w.dateTime("RECURRENCE-ID", override.OccurrenceDate, timezone)
+		w.dateTime("DTSTART", start, timezone)
+		w.dateTime("DTEND", end, timezone)
+		w.text("SUMMARY", title)
+		w.eventDetails(&occurrence)
+		w.line("END", "VEVENT")
+	}
+}
+
+func (w *icalWriter) eventHeader(event *models.Event) {
+	w.line("UID", event.ID+"@"+icalUIDDomain)
+	// DTSTAMP follows the last change rather than the time of rendering so
+	// that unchanged calendars render identically and keep their ETag.
+	w.line("DTSTAMP", event.UpdatedAt.UTC().Format(icalDateTimeUTC))
+	w.line("CREATED", event.CreatedAt.UTC().Format(icalDateTimeUTC))
+	w.line("LAST-MODIFIED", event.UpdatedAt.UTC().Format(icalDateTimeUTC))
+}
+
+func (w *icalWriter) eventDetails(event *models.Event) {
+	if event.Description != "" {
+		w.text("DESCRIPTION", event.Description)
+	}
+
+	if event.Location.Address != "" {
+		w.text("LOCATION", event.Location.Address)
+	}
+
+	w.line("GEO", strconv.FormatFloat(event.Location.Lat, 'f', -1, 64)+";"+strconv.FormatFloat(event.Location.Lng, 'f', -1, 64))
+
+	if len(event.Tags) > 0 {
+		names := make([]string, len(event.Tags))
+		for i, tag := range event.Tags {
+			names[i] = escapeICalText(tag.Name)
+		}
+		w.line("CATEGORIES", strings.Join(names, ","))
+	}
+
+	switch event.ModerationStatus {
+	case constants.EventModerationStatusApproved:
+		w.line("STATUS", "CONFIRMED")
+	case constants.EventModerationStatusRejected:
+		w.line("STATUS", "CANCELLED")
+	default:
+		w.line("STATUS", "TENTATIVE")
+	}
+}
+
+var icalTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeICalText(value string) string {
+	return icalTextEscaper.Replace(value)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEscapeICalText(t *testing.T) {
+	for _, test := range []struct {
+		value, want string
+	}{
+		{"Concert", "Concert"},
+		{`C:\path`, `C:\\path`},
+		{"Doors; bar", `Doors\; bar`},
+		{"Moscow, Red Square", `Moscow\, Red Square`},
+		{"line\r\nbreak", `line\nbreak`},
+		{"line\nbreak", `line\nbreak`},
+		{"line\rbreak", `line\nbreak`},
+		{`a\;b`, `a\\\;b`},
+		{"Привет, мир", `Привет\, мир`},
+	} {
+		if got := escapeICalText(test.value); got != test.want {
+			t.Errorf("escapeICalText(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+}
+
+func TestICalLineFolding(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		value string
+		lines int
+	}{
+		{"short", "Concert", 1},
+		{"exactly the limit", strings.Repeat("a", icalLineLimit-len("SUMMARY:")), 1},
+		{"one octet over", strings.Repeat("a", icalLineLimit-len("SUMMARY:")+1), 2},
+		{"long ASCII", strings.Repeat("a", 500), 7},
+		{"two-byte runes", strings.Repeat("я", 200), 6},
+		{"four-byte runes", strings.Repeat("🎉", 100), 6},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var w icalWriter
+			w.line("SUMMARY", test.value)
+			output := w.buf.String()
+
+			if !strings.HasSuffix(output, "\r\n") {
+				t.Fatalf("output %q does not end with CRLF", output)
+			}
+			lines := strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n")
+			if len(lines) != test.lines {
+				t.Errorf("got %d lines, want %d", len(lines), test.lines)
+			}
+
+			for i, line := range lines {
+				if len(line) > icalLineLimit {
+					t.Errorf("line %d is %d octets long", i, len(line))
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 sequence", i)
+				}
+			}
+
+			if unfolded := strings.ReplaceAll(output, "\r\n ", ""); unfolded != "SUMMARY:"+test.value+"\r\n" {
+				t.Errorf("unfolded output = %q", unfolded)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/EventFlow-Project/backend/internal/config"
@@ -14,17 +15,30 @@ type JWTService struct {
 	config *config.Config
 }
 
-func NewJWTService(config *config.Config) *JWTService {
+type TokenClaims struct {
+	UserID    string
+	SessionID string
+	ExpiresAt time.Time
+}
+
+func NewJWTService(config *config.Config) (*JWTService, error) {
+	if err := config.JWT.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &JWTService{
 		config: config,
-	}
+	}, nil
 }
 
-func (s *JWTService) GenerateToken(user *models.User) (string, error) {
+// GenerateToken issues a short-lived access token bound to the given session,
+// so revoking the session also invalidates the token.
+func (s *JWTService) GenerateToken(user *models.User, sessionID string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": user.ID,
 		"email":   user.Email,
-		"exp":     time.Now().Add(time.Hour * 24 * 7).Unix(),
+		"sid":     sessionID,
+		"exp":     time.Now().Add(s.config.JWT.AccessTokenTTL).Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
@@ -33,25 +47,42 @@ func (s *JWTService) GenerateToken(user *models.User) (string, error) {
 
 func (s *JWTService) ValidateToken(tokenString string) (*jwt.Token, error) {
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
 		return []byte(s.config.JWT.Secret), nil
 	})
 }
 
-func (s *JWTService) GetUserIDFromToken(tokenString string) (string, error) {
+func (s *JWTService) GetClaimsFromToken(tokenString string) (*TokenClaims, error) {
 	token, err := s.ValidateToken(tokenString)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", errors.New("invalid token claims")
+		return nil, errors.New("invalid token claims")
 	}
 
 	userID, ok := claims["user_id"].(string)
 	if !ok {
-		return "", errors.New("user_id not found in token")
+		return nil, errors.New("user_id not found in token")
+	}
+
+	sessionID, ok := claims["sid"].(string)
+	if !ok {
+		return nil, errors.New("sid not found in token")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("exp not found in token")
 	}
 
-	return userID, nil
+	return &TokenClaims{
+		UserID:    userID,
+		SessionID: sessionID,
+		ExpiresAt: time.Unix(int64(exp), 0),
+	}, nil
 }
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// loginScope is what failed logins are counted against: the account typed
+// in, or the IP the attempt came from.
+type loginScope struct {
+	name             string
+	freeAttempts     int
+	lockoutThreshold int
+	lockedEvent      constants.SecurityEventType
+}
+
+// LoginThrottle slows down password guessing. Failures are counted per
+// account and per IP; past the free attempts each failure doubles the wait
+// before the next attempt, and reaching the threshold locks the account or
+// IP out for a while.
+//
+// An attempt is counted as a failure as soon as Allow lets it through and is
+// taken back when the password turns out right, so concurrent guesses cannot
+// all pass the check before any of them has failed.
+type LoginThrottle struct {
+	config               config.LoginConfig
+	store                ports.RateLimitStore
+	securityEventService *SecurityEventService
+	account              loginScope
+	ip                   loginScope
+}
+
+// LoginAttempt is a login attempt let through by Allow. It must end with
+// RecordFailure, RecordSuccess or Release.
+type LoginAttempt struct {
+	email       string
+	client      models.ClientInfo
+	reservation []scopeReservation
+}
+
+// scopeReservation is what an attempt added to the counters of one scope.
+type scopeReservation struct {
+	scope loginScope
+	id    string
+	// gate is set when the attempt opened the scope's backoff gate.
+	gate bool
+}
+
+func NewLoginThrottle(
+	cfg *config.Config,
+	store ports.RateLimitStore,
+	securityEventService *SecurityEventService,
+) *LoginThrottle {
+	return &LoginThrottle{
+		config:               cfg.Login,
+		store:                store,
+		securityEventService: securityEventService,
+		account: loginScope{
+			name:             "account",
+			freeAttempts:     cfg.Login.AccountFreeAttempts,
+			lockoutThreshold: cfg.Login.AccountLockoutThreshold,
+			lockedEvent:      constants.SecurityEventAccountLocked,
+		},
+		ip: loginScope{
+			name:             "ip",
+			freeAttempts:     cfg.Login.IPFreeAttempts,
+			lockoutThreshold: cfg.Login.IPLockoutThreshold,
+			lockedEvent:      constants.SecurityEventIPLocked,
+		},
+	}
+}
+
+// Allow reserves a login attempt for the email from the client. It returns
+// a *RetryAfterError if the attempt must wait, and logs the refused attempt.
+func (t *LoginThrottle) Allow(ctx context.Context, email string, client models.ClientInfo) (*LoginAttempt, error) {
+	now := time.Now()
+	attempt := &LoginAttempt{email: email, client: client}
+
+	var wait time.Duration
+	for _, check := range []struct {
+		scope loginScope
+		id    string
+	}{
+		{t.account, loginAccountID(email)},
+		{t.ip, client.IP},
+	} {
+		reservation, scopeWait, err := t.reserve(ctx, check.scope, check.id, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if reservation != nil {
+			attempt.reservation = append(attempt.reservation, *reservation)
+		}
+		wait = max(wait, scopeWait)
+	}
+
+	if wait <= 0 {
+		return attempt, nil
+	}
+
+	if err := t.release(ctx, attempt.reservation, now); err != nil {
+		return nil, err
+	}
+
+	if err := t.securityEventService.Record(ctx, constants.SecurityEventLoginThrottled, nil, email, client); err != nil {
+		return nil, err
+	}
+
+	return nil, &RetryAfterError{RetryAfter: wait}
+}
+
+// RecordFailure logs the failed attempt as eventType, along with any lockout
+// it causes. userID is nil when no account has the email.
+func (t *LoginThrottle) RecordFailure(ctx context.Context, attempt *LoginAttempt, eventType constants.SecurityEventType, userID *string) error {
+	if err := t.securityEventService.Record(ctx, eventType, userID, attempt.email, attempt.client); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, reservation := range attempt.reservation {
+		counter, err := t.store.Get(ctx, failuresKey(reservation.scope, reservation.id), now)
+		if err != nil {
+			return err
+		}
+
+		if counter.Count < reservation.scope.lockoutThreshold {
+			continue
+		}
+
+		lock, err := t.store.Increment(ctx, lockKey(reservation.scope, reservation.id), now, t.config.LockoutDuration)
+		if err != nil {
+			return err
+		}
+
+		// Only a new lock is logged, not every failure while locked.
+		if lock.Count == 1 {
+			if err := t.securityEventService.Record(ctx, reservation.scope.lockedEvent, userID, attempt.email, attempt.client); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RecordSuccess forgets the account's failures. The IP's are kept, so that
+// logging into an account of one's own does not reset guessing at others;
+// only the attempt itself is taken back from them.
+func (t *LoginThrottle) RecordSuccess(ctx context.Context, attempt *LoginAttempt) error {
+	id := loginAccountID(attempt.email)
+	if err := t.store.Delete(ctx, failuresKey(t.account, id)); err != nil {
+		return err
+	}
+
+	if err := t.store.Delete(ctx, gateKey(t.account, id)); err != nil {
+		return err
+	}
+
+	var ip []scopeReservation
+	for _, reservation := range attempt.reservation {
+		if reservation.scope.name == t.ip.name {
+			ip = append(ip, reservation)
+		}
+	}
+
+	return t.release(ctx, ip, time.Now())
+}
+
+// Release takes the attempt back without forgetting earlier failures, for a
+// right password that still needs a second factor.
+func (t *LoginThrottle) Release(ctx context.Context, attempt *LoginAttempt) error {
+	return t.release(ctx, attempt.reservation, time.Now())
+}
+
+// Verify runs check, which reports whether a secret a signed-in user gave is
+// right, as a login attempt on their account, so that a stolen session
+// cannot be used to guess the secret. Wrong secrets are logged as eventType.
+// A right one does not forget earlier failures: it proves less than a full
+// login.
+func (t *LoginThrottle) Verify(ctx context.Context, user *models.User, client models.ClientInfo, eventType constants.SecurityEventType, check func() (bool, error)) (bool, error) {
+	attempt, err := t.Allow(ctx, user.Email, client)
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := check()
+	if err != nil || ok {
+		return ok, errors.Join(err, t.Release(ctx, attempt))
+	}
+
+	return false, t.RecordFailure(ctx, attempt, eventType, &user.ID)
+}
+
+// CheckPassword confirms the password of a signed-in user before a
+// sensitive change to their account.
+func (t *LoginThrottle) CheckPassword(ctx context.Context, user *models.User, password string, client models.ClientInfo) error {
+	ok, err := t.Verify(ctx, user, client, constants.SecurityEventReauthFailed, func() (bool, error) {
+		return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: password is incorrect", ErrForbidden)
+	}
+
+	return nil
+}
+
+// reserve counts an attempt against the scope and returns how long it has to
+// wait instead. From the free attempts on, every attempt makes the next one
+// wait; the gate counter stands for that wait, and only the attempt that
+// opens it may go ahead. A locked scope reserves nothing.
+func (t *LoginThrottle) reserve(ctx context.Context, scope loginScope, id string, now time.Time) (*scopeReservation, time.Duration, error) {
+	lock, err := t.store.Get(ctx, lockKey(scope, id), now)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if lock.Count > 0 {
+		return nil, lock.ExpiresAt.Sub(now), nil
+	}
+
+	failures, err := t.store.Increment(ctx, failuresKey(scope, id), now, t.config.FailureWindow)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reservation := &scopeReservation{scope: scope, id: id}
+	if failures.Count < scope.freeAttempts {
+		return reservation, 0, nil
+	}
+
+	gate, err := t.store.Increment(ctx, gateKey(scope, id), now, t.backoff(failures.Count-scope.freeAttempts))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reservation.gate = gate.Count == 1
+	if reservation.gate || failures.Count == scope.freeAttempts {
+		return reservation, 0, nil
+	}
+
+	return reservation, gate.ExpiresAt.Sub(now), nil
+}
+
+// release takes the reserved attempts back from the counters.
+func (t *LoginThrottle) release(ctx context.Context, reservations []scopeReservation, now time.Time) error {
+	for _, reservation := range reservations {
+		if err := t.store.Decrement(ctx, failuresKey(reservation.scope, reservation.id), now); err != nil {
+			return err
+		}
+
+		if !reservation.gate {
+			continue
+		}
+
+		if err := t.store.Delete(ctx, gateKey(reservation.scope, reservation.id)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backoff returns the wait after the given number of failures past the free
+// attempts, doubling from BackoffBase up to BackoffMax.
+func (t *LoginThrottle) backoff(extraFailures int) time.Duration {
+	delay := t.config.BackoffBase
+	for i := 0; i < extraFailures && delay < t.config.BackoffMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, t.config.BackoffMax)
+}
+
+// loginAccountID identifies the account typed in by the hash of the email,
+// which keeps the counter keys short however long the input.
+func loginAccountID(email string) string {
+	return hashToken(strings.ToLower(strings.TrimSpace(email)))
+}
+
+func failuresKey(scope loginScope, id string) string {
+	return "login:failures:" + scope.name + ":" + id
+}
+
+func lockKey(scope loginScope, id string) string {
+	return "login:lock:" + scope.name + ":" + id
+}
+
+func gateKey(scope loginScope, id string) string {
+	return "login:gate:" + scope.name + ":" + id
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/ratelimit"
+)
+
+type securityEventLog struct {
+	ports.SecurityEventRepository
+	mu     sync.Mutex
+	events []constants.SecurityEventType
+}
+
+func (l *securityEventLog) RecordEvent(ctx context.Context, event *models.SecurityEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event.Type)
+	return nil
+}
+
+// testLoginConfig waits a minute after the free attempts, long enough for
+// refusals not to expire while a test runs.
+var testLoginConfig = config.LoginConfig{
+	FailureWindow:           time.Hour,
+	AccountFreeAttempts:     3,
+	IPFreeAttempts:          10,
+	BackoffBase:             time.Minute,
+	BackoffMax:              time.Hour,
+	AccountLockoutThreshold: 10,
+	IPLockoutThreshold:      50,
+	LockoutDuration:         15 * time.Minute,
+}
+
+type loginOutcome int
+
+const (
+	loginFails loginOutcome = iota
+	loginSucceeds
+	// loginReleased is a right password that still needs a second factor.
+	loginReleased
+	// loginRefused is an attempt Allow must refuse.
+	loginRefused
+)
+
+type loginStep struct {
+	email   string
+	ip      string
+	outcome loginOutcome
+}
+
+// attempts repeats a step n times.
+func attempts(n int, step loginStep) []loginStep {
+	steps := make([]loginStep, n)
+	for i := range steps {
+		steps[i] = step
+	}
+	return steps
+}
+
+var (
+	fail    = loginStep{"user@example.com", "10.0.0.1", loginFails}
+	succeed = loginStep{"user@example.com", "10.0.0.1", loginSucceeds}
+	release = loginStep{"user@example.com", "10.0.0.1", loginReleased}
+	refused = loginStep{"user@example.com", "10.0.0.1", loginRefused}
+)
+
+func TestLoginThrottle(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config func(*config.LoginConfig)
+		steps  [][]loginStep
+		// events must all have been logged.
+		events []constants.SecurityEventType
+	}{
+		{
+			name:   "free attempts, then a wait",
+			steps:  [][]loginStep{attempts(3, fail), {refused}},
+			events: []constants.SecurityEventType{constants.SecurityEventLoginFailed, constants.SecurityEventLoginThrottled},
+		},
+		{
+			name:  "success forgets the account's failures",
+			steps: [][]loginStep{attempts(2, fail), {succeed}, attempts(3, fail), {refused}},
+		},
+		{
+			name:  "a second factor still due keeps the failures",
+			steps: [][]loginStep{attempts(2, fail), {release}, {fail}, {refused}},
+		},
+		{
+			name: "email case and spaces do not matter",
+			steps: [][]loginStep{
+				{fail, {"USER@example.com", "10.0.0.1", loginFails}, {" user@example.com ", "10.0.0.1", loginFails}},
+				{{"User@Example.com", "10.0.0.1", loginRefused}},
+			},
+		},
+		{
+			name: "accounts are counted apart",
+			steps: [][]loginStep{
+				attempts(3, fail),
+				attempts(3, loginStep{"other@example.com", "10.0.0.1", loginFails}),
+				{refused},
+			},
+		},
+		{
+			name:   "one IP guessing at many accounts",
+			config: func(c *config.LoginConfig) { c.IPFreeAttempts = 4 },
+			steps: [][]loginStep{
+				{
+					{"a@example.com", "10.0.0.1", loginFails},
+					{"b@example.com", "10.0.0.1", loginFails},
+					{"c@example.com", "10.0.0.1", loginFails},
+					{"d@example.com", "10.0.0.1", loginFails},
+				},
+				{{"e@example.com", "10.0.0.1", loginRefused}, {"e@example.com", "10.0.0.2", loginSucceeds}},
+			},
+		},
+		{
+			name:   "success does not reset the IP",
+			config: func(c *config.LoginConfig) { c.IPFreeAttempts = 3 },
+			steps: [][]loginStep{
+				{{"a@example.com", "10.0.0.1", loginFails}, {"b@example.com", "10.0.0.1", loginFails}},
+				{{"c@example.com", "10.0.0.1", loginSucceeds}, {"d@example.com", "10.0.0.1", loginFails}},
+				{{"e@example.com", "10.0.0.1", loginRefused}},
+			},
+		},
+		{
+			name: "lockout refuses even the right password",
+			config: func(c *config.LoginConfig) {
+				c.AccountFreeAttempts = 100
+				c.AccountLockoutThreshold = 5
+			},
+			steps:  [][]loginStep{attempts(5, fail), {refused}, {{"user@example.com", "10.0.0.2", loginRefused}}},
+			events: []constants.SecurityEventType{constants.SecurityEventAccountLocked},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Config{Login: testLoginConfig}
+			if test.config != nil {
+				test.config(&cfg.Login)
+			}
+			log := &securityEventLog{}
+			throttle := NewLoginThrottle(cfg, ratelimit.NewMemoryStore(), NewSecurityEventService(log))
+			ctx := context.Background()
+
+			for i, step := range slices.Concat(test.steps...) {
+				attempt, err := throttle.Allow(ctx, step.email, models.ClientInfo{IP: step.ip})
+
+				var retry *RetryAfterError
+				if step.outcome == loginRefused {
+					if !errors.As(err, &retry) || retry.RetryAfter <= 0 {
+						t.Fatalf("step %d: err = %v, want a wait", i, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("step %d (%s from %s): %v", i, step.email, step.ip, err)
+				}
+
+				switch step.outcome {
+				case loginFails:
+					err = throttle.RecordFailure(ctx, attempt, constants.SecurityEventLoginFailed, nil)
+				case loginSucceeds:
+					err = throttle.RecordSuccess(ctx, attempt)
+				case loginReleased:
+					err = throttle.Release(ctx, attempt)
+				}
+				if err != nil {
+					t.Fatalf("step %d: %v", i, err)
+				}
+			}
+
+			for _, event := range test.events {
+				if !slices.Contains(log.events, event) {
+					t.Errorf("events %v lack %s", log.events, event)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginBackoff(t *testing.T) {
+	throttle := NewLoginThrottle(&config.Config{Login: config.LoginConfig{
+		BackoffBase: time.Second,
+		BackoffMax:  time.Minute,
+	}}, nil, nil)
+
+	for extra, want := range []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 32 * time.Second,
+		time.Minute, time.Minute,
+	} {
+		if got := throttle.backoff(extra); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", extra, got, want)
+		}
+	}
+}
@@ -0,0 +1,483 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	recoveryCodeCount = 10
+	// recoveryCodeAlphabet leaves out characters that are easily confused,
+	// such as 0 and o, or 1 and l.
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeLength   = 10
+	mfaQRCodeSize        = 256
+)
+
+var ErrInvalidMFACode = errors.New("invalid two-factor code")
+
+// MFAService manages TOTP two-factor authentication: enrollment, codes,
+// recovery codes and which roles must use it.
+type MFAService struct {
+	config               *config.Config
+	userRepository       ports.UserRepository
+	mfaRepository        ports.MFARepository
+	transactor           ports.Transactor
+	securityEventService *SecurityEventService
+	loginThrottle        *LoginThrottle
+	gcm                  cipher.AEAD
+}
+
+func NewMFAService(
+	config *config.Config,
+	userRepository ports.UserRepository,
+	mfaRepository ports.MFARepository,
+	transactor ports.Transactor,
+	securityEventService *SecurityEventService,
+	loginThrottle *LoginThrottle,
+) (*MFAService, error) {
+	if err := config.Auth.Validate(); err != nil {
+		return nil, err
+	}
+
+	// A leaked signing secret must not also reveal the two-factor secrets.
+	if config.Auth.MFAEncryptionKey == config.JWT.Secret {
+		return nil, errors.New("AUTH_MFA_ENCRYPTION_KEY must differ from JWT_SECRET")
+	}
+	sum := sha256.Sum256([]byte(config.Auth.MFAEncryptionKey))
+
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFAService{
+		config:               config,
+		userRepository:       userRepository,
+		mfaRepository:        mfaRepository,
+		transactor:           transactor,
+		securityEventService: securityEventService,
+		loginThrottle:        loginThrottle,
+		gcm:                  gcm,
+	}, nil
+}
+
+func (s *MFAService) GetStatus(ctx context.Context, actor *models.Principal) (*models.MFAStatus, error) {
+	user, err := s.userRepository.GetUserByID(actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.mfaRepository.IsMFARequired(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.MFAStatus{
+		Enabled:  user.MFAEnabledAt != nil,
+		Required: required,
+	}
+
+	if status.Enabled {
+		status.RecoveryCodesLeft, err = s.mfaRepository.CountRecoveryCodes(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// BeginSetup generates a new secret for the user to add to their
+// authenticator app. Two-factor authentication is enabled once ConfirmSetup
+// gets a code generated from it.
+func (s *MFAService) BeginSetup(ctx context.Context, actor *models.Principal, password string, client models.ClientInfo) (*models.MFASetup, error) {
+	user, err := s.userRepository.GetUserByID(actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loginThrottle.CheckPassword(ctx, user, password, client); err != nil {
+		return nil, err
+	}
+
+	if user.MFAEnabledAt != nil {
+		return nil, fmt.Errorf("%w: two-factor authentication is already enabled", ErrConflict)
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepository.SetTOTPSecret(ctx, user.ID, encrypted); err != nil {
+		return nil, err
+	}
+
+	uri := totpURI(s.config.Auth.MFAIssuer, user.Email, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, mfaQRCodeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MFASetup{
+		Secret:     secret,
+		OTPAuthURI: uri,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// ConfirmSetup enables two-factor authentication once the user proves
+// their app generates the right codes, and returns their recovery codes.
+// The codes are shown only this once.
+func (s *MFAService) ConfirmSetup(ctx context.Context, actor *models.Principal, code string, client models.ClientInfo) (*models.MFARecoveryCodes, error) {
+	user, err := s.userRepository.GetUserByID(actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.MFAEnabledAt != nil {
+		return nil, fmt.Errorf("%w: two-factor authentication is already enabled", ErrConflict)
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, fmt.Errorf("%w: start the setup first", ErrInvalidArgument)
+	}
+
+	var codes []string
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.verifyTOTP(ctx, user, code)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("%w: %w", ErrInvalidArgument, ErrInvalidMFACode)
+		}
+
+		if err := s.mfaRepository.EnableMFA(ctx, user.ID, time.Now()); err != nil {
+			return err
+		}
+
+		codes, err = s.replaceRecoveryCodes(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.securityEventService.Record(ctx, constants.SecurityEventMFAEnabled, &user.ID, user.Email, client)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// Disable turns two-factor authentication off, unless the user's role
+// requires it. It takes the password and a code, or a recovery code; wrong
+// ones count as failed logins.
+func (s *MFAService) Disable(ctx context.Context, actor *models.Principal, password, code string, client models.ClientInfo) error {
+	user, err := s.userRepository.GetUserByID(actor.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.loginThrottle.CheckPassword(ctx, user, password, client); err != nil {
+		return err
+	}
+
+	if user.MFAEnabledAt == nil {
+		return fmt.Errorf("%w: two-factor authentication is not enabled", ErrConflict)
+	}
+
+	required, err := s.mfaRepository.IsMFARequired(ctx, user.Role)
+	if err != nil {
+		return err
+	}
+
+	if required {
+		return fmt.Errorf("%w: two-factor authentication is required for your role", ErrForbidden)
+	}
+
+	ok, err := s.loginThrottle.Verify(ctx, user, client, constants.SecurityEventMFAFailed, func() (bool, error) {
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.VerifyCode(ctx, user, code, code, client); err != nil {
+				return err
+			}
+
+			if err := s.mfaRepository.DisableMFA(ctx, user.ID); err != nil {
+				return err
+			}
+
+			return s.securityEventService.Record(ctx, constants.SecurityEventMFADisabled, &user.ID, user.Email, client)
+		})
+		if errors.Is(err, ErrInvalidMFACode) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %w", ErrInvalidArgument, ErrInvalidMFACode)
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes, for example
+// when they are running out. It takes a code from the authenticator app;
+// wrong codes count as failed logins.
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, actor *models.Principal, code string, client models.ClientInfo) (*models.MFARecoveryCodes, error) {
+	user, err := s.userRepository.GetUserByID(actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.MFAEnabledAt == nil {
+		return nil, fmt.Errorf("%w: two-factor authentication is not enabled", ErrConflict)
+	}
+
+	var codes []string
+	ok, err := s.loginThrottle.Verify(ctx, user, client, constants.SecurityEventMFAFailed, func() (bool, error) {
+		var ok bool
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			ok, err = s.verifyTOTP(ctx, user, code)
+			if err != nil || !ok {
+				return err
+			}
+
+			codes, err = s.replaceRecoveryCodes(ctx, user.ID)
+			if err != nil {
+				return err
+			}
+
+			return s.securityEventService.Record(ctx, constants.SecurityEventRecoveryCodesReset, &user.ID, user.Email, client)
+		})
+		return ok, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, ErrInvalidMFACode)
+	}
+
+	return &models.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// VerifyCode checks a code from the user's authenticator app or, failing
+// that, one of their recovery codes, which is then used up. It returns
+// ErrInvalidMFACode when neither matches.
+func (s *MFAService) VerifyCode(ctx context.Context, user *models.User, code, recoveryCode string, client models.ClientInfo) error {
+	if code != "" {
+		ok, err := s.verifyTOTP(ctx, user, code)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	if recoveryCode != "" {
+		ok, err := s.mfaRepository.UseRecoveryCode(ctx, user.ID, hashToken(normalizeRecoveryCode(recoveryCode)), time.Now())
+		if err != nil {
+			return err
+		}
+		if ok {
+			return s.securityEventService.Record(ctx, constants.SecurityEventRecoveryCodeUsed, &user.ID, user.Email, client)
+		}
+	}
+
+	return ErrInvalidMFACode
+}
+
+// IsSetupRequired reports whether the user's role requires two-factor
+// authentication that the user has not enabled.
+func (s *MFAService) IsSetupRequired(ctx context.Context, user *models.User) (bool, error) {
+	if user.MFAEnabledAt != nil {
+		return false, nil
+	}
+
+	return s.mfaRepository.IsMFARequired(ctx, user.Role)
+}
+
+// GetPolicies returns for every role whether it requires two-factor
+// authentication.
+func (s *MFAService) GetPolicies(ctx context.Context, actor *models.Principal) ([]models.RoleMFAPolicy, error) {
+	if !actor.Can(constants.PermissionManageRoles) {
+		return nil, ErrForbidden
+	}
+
+	saved, err := s.mfaRepository.GetMFAPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byRole := make(map[constants.UserRole]models.RoleMFAPolicy, len(saved))
+	for _, policy := range saved {
+		byRole[policy.Role] = policy
+	}
+
+	policies := make([]models.RoleMFAPolicy, len(constants.UserRoles))
+	for i, role := range constants.UserRoles {
+		policy, ok := byRole[role]
+		if !ok {
+			policy = models.RoleMFAPolicy{Role: role}
+		}
+		policies[i] = policy
+	}
+
+	return policies, nil
+}
+
+// UpdatePolicy makes two-factor authentication required for a role, or
+// optional again. Users of the role who have not enabled it lose the role's
+// permissions until they do.
+func (s *MFAService) UpdatePolicy(ctx context.Context, actor *models.Principal, role constants.UserRole, required bool) (*models.RoleMFAPolicy, error) {
+	if !actor.Can(constants.PermissionManageRoles) {
+		return nil, ErrForbidden
+	}
+
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: invalid role", ErrInvalidArgument)
+	}
+
+	policy := &models.RoleMFAPolicy{
+		Role:      role,
+		Required:  required,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.mfaRepository.SaveMFAPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// verifyTOTP checks a code against the user's secret. Each code is accepted
+// only once.
+func (s *MFAService) verifyTOTP(ctx context.Context, user *models.User, code string) (bool, error) {
+	secret, err := s.decrypt(user.TOTPSecret)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok := matchTOTP(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	return s.mfaRepository.AdvanceTOTPStep(ctx, user.ID, step)
+}
+
+func (s *MFAService) replaceRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	now := time.Now()
+	codes := make([]string, recoveryCodeCount)
+	records := make([]models.MFARecoveryCode, recoveryCodeCount)
+
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		codes[i] = code
+		records[i] = models.MFARecoveryCode{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			CodeHash:  hashToken(normalizeRecoveryCode(code)),
+			CreatedAt: now,
+		}
+	}
+
+	if err := s.mfaRepository.ReplaceRecoveryCodes(ctx, userID, records); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *MFAService) encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, s.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := s.gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *MFAService) decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(sealed) < s.gcm.NonceSize() {
+		return "", errors.New("invalid two-factor secret")
+	}
+
+	nonce, sealed := sealed[:s.gcm.NonceSize()], sealed[s.gcm.NonceSize():]
+	plaintext, err := s.gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt two-factor secret: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// generateRecoveryCode returns a code such as "k7m2q-x9bfa".
+func generateRecoveryCode() (string, error) {
+	random := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	var code strings.Builder
+	for i, b := range random {
+		if i == recoveryCodeLength/2 {
+			code.WriteByte('-')
+		}
+		// 256 is not a multiple of the alphabet size, so some characters
+		// are very slightly more likely; with 10 characters that is harmless.
+		code.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+	}
+
+	return code.String(), nil
+}
+
+// normalizeRecoveryCode lets users type recovery codes in any case, with or
+// without the dash.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
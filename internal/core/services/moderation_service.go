@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultModerationPageSize = 20
+	maxModerationPageSize     = 100
+
+	// moderationClaimTTL is how long a claimed event stays hidden from other
+	// moderators; a moderator who walks away does not block it for good.
+	moderationClaimTTL = 15 * time.Minute
+
+	maxModerationReasonLength = 2000
+)
+
+type ModerationService struct {
+	config               *config.Config
+	eventRepository      ports.EventRepository
+	moderationRepository ports.ModerationRepository
+	userRepository       ports.UserRepository
+	outboxRepository     ports.OutboxRepository
+	transactor           ports.Transactor
+	messageBus           ports.MessageBus
+}
+
+func NewModerationService(
+	config *config.Config,
+	eventRepository ports.EventRepository,
+	moderationRepository ports.ModerationRepository,
+	userRepository ports.UserRepository,
+	outboxRepository ports.OutboxRepository,
+	transactor ports.Transactor,
+	messageBus ports.MessageBus,
+) *ModerationService {
+	return &ModerationService{
+		config:               config,
+		eventRepository:      eventRepository,
+		moderationRepository: moderationRepository,
+		userRepository:       userRepository,
+		outboxRepository:     outboxRepository,
+		transactor:           transactor,
+		messageBus:           messageBus,
+	}
+}
+
+func (s *ModerationService) ApproveEvent(ctx context.Context, actor *models.Principal, eventID string, request *models.ModerationDecisionRequest) error {
+	reason := ""
+	if request != nil {
+		reason = strings.TrimSpace(request.Reason)
+	}
+
+	return s.decide(ctx, actor, eventID, constants.EventModerationStatusApproved, reason)
+}
+
+// RejectEvent rejects the event with a reason that is shown to its
+// organizers.
+func (s *ModerationService) RejectEvent(ctx context.Context, actor *models.Principal, eventID string, request *models.ModerationDecisionRequest) error {
+	if request == nil || strings.TrimSpace(request.Reason) == "" {
+		return fmt.Errorf("%w: rejection reason is required", ErrInvalidArgument)
+	}
+
+	return s.decide(ctx, actor, eventID, constants.EventModerationStatusRejected, strings.TrimSpace(request.Reason))
+}
+
+func (s *ModerationService) decide(ctx context.Context, actor *models.Principal, eventID string, status constants.EventModerationStatus, reason string) error {
+	if !actor.Can(constants.PermissionModerateEvents) {
+		return ErrForbidden
+	}
+
+	if eventID == "" {
+		return errors.New("event ID is required")
+	}
+
+	if len([]rune(reason)) > maxModerationReasonLength {
+		return fmt.Errorf("%w: reason may be at most %d characters", ErrInvalidArgument, maxModerationReasonLength)
+	}
+
+	action := constants.ModerationActionApproved
+	if status == constants.EventModerationStatusRejected {
+		action = constants.ModerationActionRejected
+	}
+
+	existingEvent, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if existingEvent == nil {
+		return fmt.Errorf("event %w", ErrNotFound)
+	}
+
+	if existingEvent.ModerationStatus == status {
+		return fmt.Errorf("%w: event is already %s", ErrConflict, action)
+	}
+
+	now := time.Now()
+	organizer, err := s.userRepository.GetUserByID(existingEvent.Organizer)
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		applied, err := s.moderationRepository.ApplyModeratorDecision(ctx, &models.ModerationAction{
+			ID:        uuid.New().String(),
+			EventID:   eventID,
+			ActorID:   actor.ID,
+			Action:    action,
+			Reason:    reason,
+			CreatedAt: now,
+		}, existingEvent.ModerationStatus, status, now)
+		if err != nil {
+			return err
+		}
+
+		if !applied {
+			return s.decisionConflict(ctx, actor, eventID, now)
+		}
+
+		if organizer.ID == actor.ID {
+			return nil
+		}
+
+		return enqueueModerationEmail(ctx, s.outboxRepository, s.config.AppURL, organizer, existingEvent, action, reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.messageBus.Publish(ctx, constants.TopicEventModerated, &models.EventModeration{
+		EventID:     existingEvent.ID,
+		Title:       existingEvent.Title,
+		Organizer:   existingEvent.Organizer,
+		ModeratorID: actor.ID,
+		Status:      status,
+		Reason:      reason,
+	})
+
+	return nil
+}
+
+// decisionConflict explains why a decision did not apply: another moderator
+// claimed the event, or it was changed since it was read.
+func (s *ModerationService) decisionConflict(ctx context.Context, actor *models.Principal, eventID string, now time.Time) error {
+	claim, err := s.moderationRepository.GetClaim(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if claim != nil && claim.ModeratorID != actor.ID && claim.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: event is being reviewed by another moderator", ErrConflict)
+	}
+
+	return fmt.Errorf("%w: event was changed concurrently", ErrConflict)
+}
+
+// ResubmitEvent returns a rejected event to the moderation queue, normally
+// after its organizer has addressed the rejection reason.
+func (s *ModerationService) ResubmitEvent(ctx context.Context, actor *models.Principal, eventID string) error {
+	if eventID == "" {
+		return errors.New("event ID is required")
+	}
+
+	existingEvent, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if existingEvent == nil {
+		return fmt.Errorf("event %w", ErrNotFound)
+	}
+
+	if !canEditEvent(actor, existingEvent) {
+		return ErrForbidden
+	}
+
+	if existingEvent.ModerationStatus != constants.EventModerationStatusRejected {
+		return fmt.Errorf("%w: only rejected events can be resubmitted", ErrConflict)
+	}
+
+	applied, err := s.moderationRepository.ApplyDecision(ctx, &models.ModerationAction{
+		ID:        uuid.New().String(),
+		EventID:   eventID,
+		ActorID:   actor.ID,
+		Action:    constants.ModerationActionResubmitted,
+		CreatedAt: time.Now(),
+	}, constants.EventModerationStatusRejected, constants.EventModerationStatusPending)
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		return fmt.Errorf("%w: event was changed concurrently", ErrConflict)
+	}
+
+	return nil
+}
+
+// GetHistory returns the moderation history of the event, oldest first, to
+// its organizers and to moderators.
+func (s *ModerationService) GetHistory(ctx context.Context, actor *models.Principal, eventID string) ([]models.ModerationAction, error) {
+	if eventID == "" {
+		return nil, errors.New("event ID is required")
+	}
+
+	existingEvent, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingEvent == nil {
+		return nil, fmt.Errorf("event %w", ErrNotFound)
+	}
+
+	if !canEditEvent(actor, existingEvent) && !actor.Can(constants.PermissionModerateEvents) {
+		return nil, ErrForbidden
+	}
+
+	return s.moderationRepository.GetActions(ctx, eventID)
+}
+
+// GetQueue returns one page of events awaiting moderation, oldest submission
+// first. Events claimed by other moderators are left out.
+func (s *ModerationService) GetQueue(ctx context.Context, actor *models.Principal, cursor string, limit int) (*models.ModerationQueuePage, error) {
+	if !actor.Can(constants.PermissionModerateEvents) {
+		return nil, ErrForbidden
+	}
+
+	if limit <= 0 {
+		limit = defaultModerationPageSize
+	}
+
+	if limit > maxModerationPageSize {
+		limit = maxModerationPageSize
+	}
+
+	var position *models.EventCursor
+	if cursor != "" {
+		var err error
+		position, err = decodeEventCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// One extra item tells whether there is a next page.
+	items, err := s.moderationRepository.GetQueue(ctx, actor.ID, position, limit+1, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.ModerationQueuePage{Events: items}
+	if len(items) > limit {
+		page.Events = items[:limit]
+		last := page.Events[limit-1]
+		page.NextCursor, err = encodeEventCursor(&models.EventCursor{Value: last.SubmittedAt, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range page.Events {
+		item := &page.Events[i]
+		item.Localize()
+		item.Screening = &models.ScreeningResult{
+			Verdict:  constants.ScreeningVerdictReview,
+			Score:    item.ScreeningScore,
+			Findings: item.ScreeningFindings,
+		}
+	}
+
+	return page, nil
+}
+
+// ClaimEvent reserves a pending event for the moderator for
+// moderationClaimTTL. Claiming an event again extends the claim.
+func (s *ModerationService) ClaimEvent(ctx context.Context, actor *models.Principal, eventID string) (*models.ModerationClaim, error) {
+	if !actor.Can(constants.PermissionModerateEvents) {
+		return nil, ErrForbidden
+	}
+
+	if eventID == "" {
+		return nil, errors.New("event ID is required")
+	}
+
+	now := time.Now()
+	claim := &models.ModerationClaim{
+		EventID:     eventID,
+		ModeratorID: actor.ID,
+		ExpiresAt:   now.Add(moderationClaimTTL),
+	}
+
+	claimed, err := s.moderationRepository.ClaimEvent(ctx, claim, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if claimed {
+		return claim, nil
+	}
+
+	existingEvent, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingEvent == nil {
+		return nil, fmt.Errorf("event %w", ErrNotFound)
+	}
+
+	if existingEvent.ModerationStatus != constants.EventModerationStatusPending {
+		return nil, fmt.Errorf("%w: event is not awaiting moderation", ErrConflict)
+	}
+
+	return nil, fmt.Errorf("%w: event is being reviewed by another moderator", ErrConflict)
+}
+
+// ClaimNextEvent claims the oldest unclaimed event in the queue and returns
+// it, or nil when there is nothing to review.
+func (s *ModerationService) ClaimNextEvent(ctx context.Context, actor *models.Principal) (*models.Event, error) {
+	if !actor.Can(constants.PermissionModerateEvents) {
+		return nil, ErrForbidden
+	}
+
+	now := time.Now()
+	eventID, err := s.moderationRepository.ClaimNextEvent(ctx, actor.ID, now.Add(moderationClaimTTL), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if eventID == "" {
+		return nil, nil
+	}
+
+	return s.eventRepository.GetEvent(ctx, eventID)
+}
+
+func (s *ModerationService) ReleaseClaim(ctx context.Context, actor *models.Principal, eventID string) error {
+	if !actor.Can(constants.PermissionModerateEvents) {
+		return ErrForbidden
+	}
+
+	if eventID == "" {
+		return errors.New("event ID is required")
+	}
+
+	return s.moderationRepository.ReleaseClaim(ctx, eventID, actor.ID)
+}
@@ -5,10 +5,27 @@ import "go.uber.org/fx"
 var Module = fx.Module("services",
 	fx.Provide(
 		NewJWTService,
+		NewSessionService,
+		NewSecurityEventService,
+		NewLoginThrottle,
+		NewMFAService,
+		NewOIDCService,
 		NewAuthService,
 		NewUserService,
 		NewFriendService,
 		NewEventService,
+		NewRegistrationService,
+		NewTicketService,
+		NewPaymentService,
+		NewCalendarService,
+		NewModerationService,
+		NewScreeningService,
+		NewReportService,
+		NewNotificationService,
+		NewRealtimeService,
+		NewOutboxService,
+		NewReminderService,
 		NewMinioService,
 	),
+	fx.Invoke(SubscribeNotifications, SubscribeRealtime),
 )
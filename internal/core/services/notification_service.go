@@ -0,0 +1,400 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultNotificationPageSize = 20
+	maxNotificationPageSize     = 100
+	maxMarkReadIDs              = 100
+)
+
+// NotificationService turns what other services publish on the message bus
+// into in-app notifications and serves users their inbox.
+type NotificationService struct {
+	notificationRepository ports.NotificationRepository
+	eventRepository        ports.EventRepository
+	registrationRepository ports.RegistrationRepository
+	userRepository         ports.UserRepository
+	messageBus             ports.MessageBus
+}
+
+func NewNotificationService(
+	notificationRepository ports.NotificationRepository,
+	eventRepository ports.EventRepository,
+	registrationRepository ports.RegistrationRepository,
+	userRepository ports.UserRepository,
+	messageBus ports.MessageBus,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepository: notificationRepository,
+		eventRepository:        eventRepository,
+		registrationRepository: registrationRepository,
+		userRepository:         userRepository,
+		messageBus:             messageBus,
+	}
+}
+
+// SubscribeNotifications subscribes the notification service to the topics
+// it notifies users about.
+func SubscribeNotifications(bus ports.MessageBus, s *NotificationService) {
+	bus.Subscribe(constants.TopicFriendRequestSent, s.onFriendRequestSent)
+	bus.Subscribe(constants.TopicFriendRequestAnswered, s.onFriendRequestAnswered)
+	bus.Subscribe(constants.TopicEventModerated, s.onEventModerated)
+	bus.Subscribe(constants.TopicEventChanged, s.onEventChanged)
+	bus.Subscribe(constants.TopicEventCancelled, s.onEventCancelled)
+	bus.Subscribe(constants.TopicEventStatusChanged, s.onEventStatusChanged)
+}
+
+// Notify sends a copy of the notification to each of the users, except those
+// who switched its category off.
+func (s *NotificationService) Notify(ctx context.Context, userIDs []string, notification *models.Notification) error {
+	recipients := make([]string, 0, len(userIDs))
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			recipients = append(recipients, userID)
+		}
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	category := notification.Type.Category()
+	optedOut, err := s.notificationRepository.GetOptedOutUsers(ctx, recipients, category)
+	if err != nil {
+		return err
+	}
+
+	skip := make(map[string]bool, len(optedOut))
+	for _, userID := range optedOut {
+		skip[userID] = true
+	}
+
+	now := time.Now()
+	notifications := make([]models.Notification, 0, len(recipients))
+	for _, userID := range recipients {
+		if skip[userID] {
+			continue
+		}
+
+		recipientCopy := *notification
+		recipientCopy.ID = uuid.New().String()
+		recipientCopy.UserID = userID
+		recipientCopy.Category = category
+		recipientCopy.CreatedAt = now
+		notifications = append(notifications, recipientCopy)
+	}
+
+	if err := s.notificationRepository.CreateNotifications(ctx, notifications); err != nil {
+		return err
+	}
+
+	for i := range notifications {
+		s.messageBus.Publish(ctx, constants.TopicNotificationCreated, &notifications[i])
+	}
+
+	return nil
+}
+
+func (s *NotificationService) onFriendRequestSent(ctx context.Context, payload any) error {
+	notice, ok := payload.(*models.FriendRequestNotice)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	sender, err := s.userRepository.GetUserByID(notice.FromID)
+	if err != nil {
+		return err
+	}
+
+	return s.Notify(ctx, []string{notice.ToID}, &models.Notification{
+		Type:  constants.NotificationTypeFriendRequest,
+		Title: "Новая заявка в друзья",
+		Body:  sender.Name + " хочет добавить вас в друзья",
+		Data:  models.NotificationData{"requestId": notice.RequestID, "userId": notice.FromID},
+	})
+}
+
+func (s *NotificationService) onFriendRequestAnswered(ctx context.Context, payload any) error {
+	answer, ok := payload.(*models.FriendRequestAnswer)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	// Declined requests are not announced.
+	if !answer.Accepted {
+		return nil
+	}
+
+	friend, err := s.userRepository.GetUserByID(answer.ToID)
+	if err != nil {
+		return err
+	}
+
+	return s.Notify(ctx, []string{answer.FromID}, &models.Notification{
+		Type:  constants.NotificationTypeFriendRequestAccepted,
+		Title: "Заявка в друзья принята",
+		Body:  friend.Name + " теперь у вас в друзьях",
+		Data:  models.NotificationData{"requestId": answer.RequestID, "userId": answer.ToID},
+	})
+}
+
+func (s *NotificationService) onEventModerated(ctx context.Context, payload any) error {
+	moderation, ok := payload.(*models.EventModeration)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	if moderation.ModeratorID == moderation.Organizer {
+		return nil
+	}
+
+	notification := &models.Notification{
+		Data: models.NotificationData{"eventId": moderation.EventID},
+	}
+
+	switch moderation.Status {
+	case constants.EventModerationStatusApproved:
+		notification.Type = constants.NotificationTypeEventApproved
+		notification.Title = "Мероприятие опубликовано"
+		notification.Body = fmt.Sprintf("«%s» прошло модерацию", moderation.Title)
+	case constants.EventModerationStatusRejected:
+		notification.Type = constants.NotificationTypeEventRejected
+		notification.Title = "Мероприятие отклонено"
+		notification.Body = fmt.Sprintf("«%s»: %s", moderation.Title, moderation.Reason)
+	case constants.EventModerationStatusHidden:
+		notification.Type = constants.NotificationTypeEventHidden
+		notification.Title = "Мероприятие скрыто"
+		notification.Body = fmt.Sprintf("«%s» скрыто до проверки жалоб пользователей", moderation.Title)
+	default:
+		return nil
+	}
+
+	return s.Notify(ctx, []string{moderation.Organizer}, notification)
+}
+
+func (s *NotificationService) onEventChanged(ctx context.Context, payload any) error {
+	change, ok := payload.(*models.EventChange)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	attendees, err := s.attendeeIDs(ctx, change.EventID, change.ChangedBy)
+	if err != nil {
+		return err
+	}
+
+	return s.Notify(ctx, attendees, &models.Notification{
+		Type:  constants.NotificationTypeEventChanged,
+		Title: "Мероприятие изменилось",
+		Body:  fmt.Sprintf("Изменились время или место «%s»", change.Title),
+		Data:  models.NotificationData{"eventId": change.EventID},
+	})
+}
+
+func (s *NotificationService) onEventCancelled(ctx context.Context, payload any) error {
+	cancellation, ok := payload.(*models.EventCancellation)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	attendees := make([]string, 0, len(cancellation.AttendeeIDs))
+	for _, userID := range cancellation.AttendeeIDs {
+		if userID != cancellation.CancelledBy {
+			attendees = append(attendees, userID)
+		}
+	}
+
+	return s.Notify(ctx, attendees, &models.Notification{
+		Type:  constants.NotificationTypeEventCancelled,
+		Title: "Мероприятие отменено",
+		Body:  fmt.Sprintf("«%s» отменено", cancellation.Title),
+		Data:  models.NotificationData{"eventId": cancellation.EventID},
+	})
+}
+
+func (s *NotificationService) onEventStatusChanged(ctx context.Context, payload any) error {
+	change, ok := payload.(*models.EventStatusChange)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	if change.To != constants.EventStatusUnderway {
+		return nil
+	}
+
+	event, err := s.eventRepository.GetEvent(ctx, change.EventID)
+	if err != nil || event == nil {
+		return err
+	}
+
+	attendees, err := s.attendeeIDs(ctx, event.ID, "")
+	if err != nil {
+		return err
+	}
+
+	return s.Notify(ctx, attendees, &models.Notification{
+		Type:  constants.NotificationTypeEventStarted,
+		Title: "Мероприятие началось",
+		Body:  fmt.Sprintf("«%s» уже идёт", event.Title),
+		Data:  models.NotificationData{"eventId": event.ID},
+	})
+}
+
+// attendeeIDs returns the users registered for the event who have not
+// declined, except the given one.
+func (s *NotificationService) attendeeIDs(ctx context.Context, eventID, except string) ([]string, error) {
+	attendees, err := s.registrationRepository.GetEventAttendees(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return activeAttendeeIDs(attendees, except), nil
+}
+
+func activeAttendeeIDs(attendees []models.AttendeeResponse, except string) []string {
+	ids := make([]string, 0, len(attendees))
+	for _, attendee := range attendees {
+		if attendee.Status != constants.RegistrationStatusDeclined && attendee.UserID != except {
+			ids = append(ids, attendee.UserID)
+		}
+	}
+	return ids
+}
+
+// GetInbox returns one page of the actor's notifications, newest first,
+// with unread counts.
+func (s *NotificationService) GetInbox(ctx context.Context, actor *models.Principal, filter *models.NotificationFilter) (*models.NotificationInbox, error) {
+	if filter.Category != "" && !filter.Category.IsValid() {
+		return nil, fmt.Errorf("%w: invalid category", ErrInvalidArgument)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultNotificationPageSize
+	}
+
+	if filter.Limit > maxNotificationPageSize {
+		filter.Limit = maxNotificationPageSize
+	}
+
+	var cursor *models.EventCursor
+	if filter.Cursor != "" {
+		var err error
+		cursor, err = decodeEventCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// One extra notification tells whether there is a next page.
+	notifications, err := s.notificationRepository.ListNotifications(ctx, actor.ID, filter, cursor, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepository.CountUnread(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	inbox := &models.NotificationInbox{
+		Notifications:    notifications,
+		UnreadByCategory: make(map[constants.NotificationCategory]int64, len(constants.NotificationCategories)),
+	}
+
+	for _, category := range constants.NotificationCategories {
+		inbox.UnreadByCategory[category] = unread[category]
+		inbox.Unread += unread[category]
+	}
+
+	if len(notifications) > filter.Limit {
+		inbox.Notifications = notifications[:filter.Limit]
+		last := inbox.Notifications[filter.Limit-1]
+		inbox.NextCursor, err = encodeEventCursor(&models.EventCursor{Value: last.CreatedAt, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return inbox, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, actor *models.Principal, ids []string) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("%w: notification IDs are required", ErrInvalidArgument)
+	}
+
+	if len(ids) > maxMarkReadIDs {
+		return fmt.Errorf("%w: at most %d notifications can be marked at once", ErrInvalidArgument, maxMarkReadIDs)
+	}
+
+	return s.notificationRepository.MarkRead(ctx, actor.ID, ids)
+}
+
+// MarkAllRead marks all the actor's notifications in the category as read,
+// or all of them when no category is given.
+func (s *NotificationService) MarkAllRead(ctx context.Context, actor *models.Principal, category constants.NotificationCategory) error {
+	if category != "" && !category.IsValid() {
+		return fmt.Errorf("%w: invalid category", ErrInvalidArgument)
+	}
+
+	return s.notificationRepository.MarkAllRead(ctx, actor.ID, category)
+}
+
+// GetPreferences returns whether the actor wants notifications of each
+// category. Categories are on until switched off.
+func (s *NotificationService) GetPreferences(ctx context.Context, actor *models.Principal) (models.NotificationPreferences, error) {
+	stored, err := s.notificationRepository.GetPreferences(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make(models.NotificationPreferences, len(constants.NotificationCategories))
+	for _, category := range constants.NotificationCategories {
+		preferences[category] = true
+	}
+
+	for _, preference := range stored {
+		if preference.Category.IsValid() {
+			preferences[preference.Category] = preference.Enabled
+		}
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences changes the given categories and leaves the others as
+// they are.
+func (s *NotificationService) UpdatePreferences(ctx context.Context, actor *models.Principal, preferences models.NotificationPreferences) (models.NotificationPreferences, error) {
+	now := time.Now()
+	changes := make([]models.NotificationPreference, 0, len(preferences))
+	for category, enabled := range preferences {
+		if !category.IsValid() {
+			return nil, fmt.Errorf("%w: invalid category %q", ErrInvalidArgument, category)
+		}
+
+		changes = append(changes, models.NotificationPreference{
+			UserID:    actor.ID,
+			Category:  category,
+			Enabled:   enabled,
+			UpdatedAt: now,
+		})
+	}
+
+	if err := s.notificationRepository.SavePreferences(ctx, changes); err != nil {
+		return nil, err
+	}
+
+	return s.GetPreferences(ctx, actor)
+}
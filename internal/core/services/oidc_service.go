@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidOIDCState = fmt.Errorf("%w: invalid or expired login state", ErrInvalidArgument)
+
+// OIDCService logs users in with OpenID Connect providers. A provider
+// account is linked to the user with the same email the first time it is
+// used, provided both the provider and this service have verified the
+// address; users without an account get one.
+type OIDCService struct {
+	config           *config.Config
+	providers        ports.OIDCProviders
+	oidcRepository   ports.OIDCRepository
+	authRepository   ports.AuthRepository
+	userRepository   ports.UserRepository
+	outboxRepository ports.OutboxRepository
+	transactor       ports.Transactor
+	sessionService   *SessionService
+	authService      *AuthService
+}
+
+func NewOIDCService(
+	config *config.Config,
+	providers ports.OIDCProviders,
+	oidcRepository ports.OIDCRepository,
+	authRepository ports.AuthRepository,
+	userRepository ports.UserRepository,
+	outboxRepository ports.OutboxRepository,
+	transactor ports.Transactor,
+	sessionService *SessionService,
+	authService *AuthService,
+) *OIDCService {
+	return &OIDCService{
+		config:           config,
+		providers:        providers,
+		oidcRepository:   oidcRepository,
+		authRepository:   authRepository,
+		userRepository:   userRepository,
+		outboxRepository: outboxRepository,
+		transactor:       transactor,
+		sessionService:   sessionService,
+		authService:      authService,
+	}
+}
+
+// Providers returns the names of the configured providers.
+func (s *OIDCService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// StartLogin returns the provider's login page for the user to go to. The
+// provider sends them back to the frontend with a code and the state, which
+// the caller also keeps in the browser.
+func (s *OIDCService) StartLogin(ctx context.Context, providerName string) (*models.OIDCStartResponse, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	codeVerifier, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, codeChallenge(codeVerifier), s.redirectURL(providerName))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.oidcRepository.DeleteExpiredAuthRequests(ctx, now); err != nil {
+			return err
+		}
+
+		return s.oidcRepository.CreateAuthRequest(ctx, &models.OIDCAuthRequest{
+			ID:           uuid.New().String(),
+			Provider:     providerName,
+			StateHash:    hashToken(state),
+			Nonce:        nonce,
+			CodeVerifier: codeVerifier,
+			ExpiresAt:    now.Add(s.config.OIDC.StateTTL),
+			CreatedAt:    now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OIDCStartResponse{AuthorizationURL: authURL, State: state}, nil
+}
+
+// CompleteLogin finishes a login the user came back from. browserState is
+// the state the browser kept when the login started; without it, anyone
+// could log a victim into the attacker's account by sending them the
+// attacker's callback link. Like a password login, it asks for the second
+// factor when the user has one.
+func (s *OIDCService) CompleteLogin(ctx context.Context, providerName string, request models.OIDCCallbackRequest, browserState string, client models.ClientInfo) (*models.AuthResponse, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if browserState == "" || subtle.ConstantTimeCompare([]byte(browserState), []byte(request.State)) != 1 {
+		return nil, ErrInvalidOIDCState
+	}
+
+	authRequest, err := s.oidcRepository.ConsumeAuthRequest(ctx, hashToken(request.State), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if authRequest == nil || authRequest.Provider != providerName {
+		return nil, ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, request.Code, authRequest.CodeVerifier, s.redirectURL(providerName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(authRequest.Nonce)) != 1 {
+		return nil, fmt.Errorf("%w: ID token nonce does not match", ErrInvalidArgument)
+	}
+
+	var user *models.User
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.findOrCreateUser(ctx, providerName, claims)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if user.MFAEnabledAt != nil {
+		return s.authService.issueMFAChallenge(ctx, user)
+	}
+
+	return s.sessionService.CreateSession(user, client)
+}
+
+// ListIdentities returns the provider accounts linked to the user.
+func (s *OIDCService) ListIdentities(ctx context.Context, actor *models.Principal) ([]models.UserIdentity, error) {
+	return s.oidcRepository.ListIdentities(ctx, actor.ID)
+}
+
+func (s *OIDCService) findOrCreateUser(ctx context.Context, providerName string, claims *models.OIDCClaims) (*models.User, error) {
+	identity, err := s.oidcRepository.GetIdentity(ctx, providerName, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity != nil {
+		return s.userRepository.GetUserByID(identity.UserID)
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, fmt.Errorf("%w: the provider has not verified your email", ErrForbidden)
+	}
+
+	user, err := s.authRepository.GetUserByEmail(claims.Email)
+	if err == nil {
+		// Otherwise whoever registered the address first, perhaps not its
+		// owner, would get the provider account too.
+		if user.EmailVerifiedAt == nil {
+			return nil, fmt.Errorf("%w: confirm your email before logging in with %s", ErrConflict, providerName)
+		}
+	} else {
+		user, err = s.createUser(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.oidcRepository.CreateIdentity(ctx, &models.UserIdentity{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		Provider:  providerName,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// createUser registers a participant without a password; they can set one
+// with the password reset.
+func (s *OIDCService) createUser(ctx context.Context, claims *models.OIDCClaims) (*models.User, error) {
+	name := claims.Name
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	locale := constants.Locale(strings.ToLower(claims.Locale))
+	if len(locale) > 2 {
+		locale = locale[:2]
+	}
+	if !locale.IsValid() {
+		locale = constants.DefaultLocale
+	}
+
+	user, err := s.authRepository.CreateUserWithPassword(ctx, claims.Email, "", name, constants.UserRoleParticipant, "", "", locale)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.authRepository.MarkEmailVerified(ctx, user.ID, now); err != nil {
+		return nil, err
+	}
+	user.EmailVerifiedAt = &now
+
+	if err := enqueueEmail(ctx, s.outboxRepository, user, constants.EmailTemplateWelcome, map[string]string{
+		"name": user.Name,
+		"link": s.config.AppURL,
+	}); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *OIDCService) provider(name string) (ports.OIDCProvider, error) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown provider %q", ErrInvalidArgument, name)
+	}
+
+	return provider, nil
+}
+
+// redirectURL is the frontend page the provider sends users back to. It has
+// to be registered with the provider.
+func (s *OIDCService) redirectURL(providerName string) string {
+	return strings.TrimSuffix(s.config.AppURL, "/") + "/auth/callback/" + providerName
+}
+
+func randomToken() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+// codeChallenge derives the S256 PKCE challenge from the verifier.
+func codeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
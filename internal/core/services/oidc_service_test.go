@@ -0,0 +1,355 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/oidc"
+
+	"github.com/google/uuid"
+)
+
+const testOIDCEmail = "guest@example.com"
+
+type fakeOIDCRepository struct {
+	mu         sync.Mutex
+	requests   map[string]models.OIDCAuthRequest
+	identities []models.UserIdentity
+}
+
+func (r *fakeOIDCRepository) CreateAuthRequest(ctx context.Context, request *models.OIDCAuthRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests[request.StateHash] = *request
+	return nil
+}
+
+func (r *fakeOIDCRepository) ConsumeAuthRequest(ctx context.Context, stateHash string, now time.Time) (*models.OIDCAuthRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	request, ok := r.requests[stateHash]
+	delete(r.requests, stateHash)
+	if !ok || !request.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	return &request, nil
+}
+
+func (r *fakeOIDCRepository) DeleteExpiredAuthRequests(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for stateHash, request := range r.requests {
+		if !request.ExpiresAt.After(now) {
+			delete(r.requests, stateHash)
+		}
+	}
+	return nil
+}
+
+func (r *fakeOIDCRepository) GetIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return &identity, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOIDCRepository) CreateIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.identities = append(r.identities, *identity)
+	return nil
+}
+
+func (r *fakeOIDCRepository) ListIdentities(ctx context.Context, userID string) ([]models.UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var identities []models.UserIdentity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+// fakeUsers backs the auth and user repositories with the same users.
+type fakeUsers struct {
+	ports.AuthRepository
+	mu    sync.Mutex
+	users map[string]*models.User
+}
+
+func (r *fakeUsers) CreateUserWithPassword(ctx context.Context, email, passwordHash, name string, role constants.UserRole, description, activityArea string, locale constants.Locale) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user := &models.User{ID: uuid.New().String(), Email: email, Name: name, Role: role, Locale: locale}
+	r.users[user.ID] = user
+	return user, nil
+}
+
+func (r *fakeUsers) GetUserByEmail(email string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+func (r *fakeUsers) MarkEmailVerified(ctx context.Context, userID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[userID].EmailVerifiedAt = &at
+	return nil
+}
+
+type fakeUserRepository struct {
+	ports.UserRepository
+	users *fakeUsers
+}
+
+func (r *fakeUserRepository) GetUserByID(userID string) (*models.User, error) {
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+	user, ok := r.users.users[userID]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+type fakeOutboxRepository struct {
+	ports.OutboxRepository
+}
+
+func (r *fakeOutboxRepository) Enqueue(ctx context.Context, kind constants.OutboxKind, payload any) error {
+	return nil
+}
+
+type fakeSessionRepository struct {
+	ports.SessionRepository
+}
+
+func (r *fakeSessionRepository) CreateSession(session *models.Session) error {
+	return nil
+}
+
+type oidcFixture struct {
+	service *services.OIDCService
+	issuer  *oidc.MockIssuer
+	repo    *fakeOIDCRepository
+	users   *fakeUsers
+}
+
+// newOIDCFixture serves a mock issuer and logs in with it through the same
+// provider client production uses.
+func newOIDCFixture(t *testing.T) *oidcFixture {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(nil)
+	issuer, err := oidc.NewMockIssuer("http://" + server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Config.Handler = issuer
+	server.Start()
+	t.Cleanup(server.Close)
+
+	cfg := newTestConfig()
+	provider := oidc.NewProvider(oidc.MockProviderName, config.OIDCProviderConfig{
+		ClientID: oidc.MockClientID,
+		Issuer:   issuer.Issuer(),
+		Scopes:   []string{"openid", "email", "profile"},
+	}, server.Client())
+
+	repo := &fakeOIDCRepository{requests: make(map[string]models.OIDCAuthRequest)}
+	users := &fakeUsers{users: make(map[string]*models.User)}
+	userRepository := &fakeUserRepository{users: users}
+	jwtService, err := services.NewJWTService(cfg)
+	if err != nil {
+		t.Fatalf("NewJWTService: %v", err)
+	}
+	sessionService := services.NewSessionService(&fakeSessionRepository{}, userRepository, nil, jwtService, cfg)
+
+	return &oidcFixture{
+		service: services.NewOIDCService(
+			cfg,
+			ports.OIDCProviders{oidc.MockProviderName: provider},
+			repo,
+			users,
+			userRepository,
+			&fakeOutboxRepository{},
+			&fakeTransactor{},
+			sessionService,
+			nil,
+		),
+		issuer: issuer,
+		repo:   repo,
+		users:  users,
+	}
+}
+
+// authorize starts a login and follows the provider's login page back to
+// the callback, returning what the frontend would post and the state the
+// browser kept.
+func (f *oidcFixture) authorize(t *testing.T) (models.OIDCCallbackRequest, string) {
+	t.Helper()
+
+	start, err := f.service.StartLogin(context.Background(), oidc.MockProviderName)
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+
+	authURL, err := url.Parse(start.AuthorizationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := authURL.Query()
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("authorization URL %s does not use S256 PKCE", authURL)
+	}
+	query.Set("login_hint", testOIDCEmail)
+	authURL.RawQuery = query.Encode()
+
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize returned %s", resp.Status)
+	}
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return models.OIDCCallbackRequest{
+		Code:  callback.Query().Get("code"),
+		State: callback.Query().Get("state"),
+	}, start.State
+}
+
+func (f *oidcFixture) complete(request models.OIDCCallbackRequest, browserState string) (*models.AuthResponse, error) {
+	return f.service.CompleteLogin(context.Background(), oidc.MockProviderName, request, browserState, models.ClientInfo{})
+}
+
+func (f *oidcFixture) assertNoUser(t *testing.T) {
+	t.Helper()
+
+	if _, err := f.users.GetUserByEmail(testOIDCEmail); err == nil {
+		t.Fatal("a rejected login created a user")
+	}
+}
+
+func TestOIDCLoginCreatesVerifiedUser(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	request, browserState := f.authorize(t)
+	response, err := f.complete(request, browserState)
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if response.AccessToken == "" {
+		t.Fatal("login returned no access token")
+	}
+
+	user, err := f.users.GetUserByEmail(testOIDCEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.EmailVerifiedAt == nil {
+		t.Error("user created from a verified provider email is unverified")
+	}
+
+	identities, _ := f.repo.ListIdentities(context.Background(), user.ID)
+	if len(identities) != 1 || identities[0].Provider != oidc.MockProviderName {
+		t.Errorf("identities = %+v, want one mock identity", identities)
+	}
+
+	if _, err := f.complete(request, browserState); !errors.Is(err, services.ErrInvalidOIDCState) {
+		t.Errorf("replayed callback: err = %v, want ErrInvalidOIDCState", err)
+	}
+}
+
+func TestOIDCLoginRequiresTheBrowserThatStartedIt(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	request, browserState := f.authorize(t)
+	_, otherState := f.authorize(t)
+
+	for name, state := range map[string]string{"no cookie": "", "another login": otherState} {
+		if _, err := f.complete(request, state); !errors.Is(err, services.ErrInvalidOIDCState) {
+			t.Errorf("%s: err = %v, want ErrInvalidOIDCState", name, err)
+		}
+	}
+	f.assertNoUser(t)
+
+	// A forged callback must not use up the login it names.
+	if _, err := f.complete(request, browserState); err != nil {
+		t.Errorf("CompleteLogin from the starting browser: %v", err)
+	}
+}
+
+func TestOIDCLoginRequiresTheCodeVerifier(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	request, browserState := f.authorize(t)
+
+	f.repo.mu.Lock()
+	for stateHash, authRequest := range f.repo.requests {
+		authRequest.CodeVerifier = "not-the-verifier"
+		f.repo.requests[stateHash] = authRequest
+	}
+	f.repo.mu.Unlock()
+
+	if _, err := f.complete(request, browserState); !errors.Is(err, services.ErrInvalidArgument) {
+		t.Fatalf("err = %v, want ErrInvalidArgument", err)
+	}
+	f.assertNoUser(t)
+}
+
+func TestOIDCLoginRejectsBadIDTokens(t *testing.T) {
+	for name, test := range map[string]struct {
+		claims map[string]interface{}
+		want   error
+	}{
+		"nonce mismatch":   {map[string]interface{}{"nonce": "replayed"}, services.ErrInvalidArgument},
+		"missing nonce":    {map[string]interface{}{"nonce": ""}, services.ErrInvalidArgument},
+		"wrong audience":   {map[string]interface{}{"aud": "another-client"}, services.ErrInvalidArgument},
+		"wrong issuer":     {map[string]interface{}{"iss": "https://attacker.example"}, services.ErrInvalidArgument},
+		"unverified email": {map[string]interface{}{"email_verified": false}, services.ErrForbidden},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newOIDCFixture(t)
+			f.issuer.OverrideClaims(test.claims)
+
+			request, browserState := f.authorize(t)
+			if _, err := f.complete(request, browserState); !errors.Is(err, test.want) {
+				t.Fatalf("err = %v, want %v", err, test.want)
+			}
+			f.assertNoUser(t)
+		})
+	}
+}
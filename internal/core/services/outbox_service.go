@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+const (
+	// outboxLease is how long a claimed message is left to its worker
+	// before another one retries it.
+	outboxLease = 5 * time.Minute
+
+	outboxRetryBase = 30 * time.Second
+	outboxRetryMax  = time.Hour
+)
+
+// errUndeliverable marks messages that retrying cannot help.
+var errUndeliverable = errors.New("undeliverable")
+
+// OutboxService delivers the messages recorded in the outbox.
+type OutboxService struct {
+	config           *config.Config
+	outboxRepository ports.OutboxRepository
+	renderer         ports.EmailRenderer
+	mailer           ports.Mailer
+}
+
+func NewOutboxService(
+	config *config.Config,
+	outboxRepository ports.OutboxRepository,
+	renderer ports.EmailRenderer,
+	mailer ports.Mailer,
+) *OutboxService {
+	return &OutboxService{
+		config:           config,
+		outboxRepository: outboxRepository,
+		renderer:         renderer,
+		mailer:           mailer,
+	}
+}
+
+// DeliverDue delivers one batch of the messages due by now. Failed
+// deliveries are retried with exponential backoff until the attempts run
+// out.
+func (s *OutboxService) DeliverDue(ctx context.Context, now time.Time) (sent, failed int, err error) {
+	messages, err := s.outboxRepository.ClaimDue(ctx, now, now.Add(outboxLease), s.config.Outbox.BatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i := range messages {
+		message := &messages[i]
+
+		deliveryErr := s.deliver(ctx, message)
+		if deliveryErr == nil {
+			if err := s.outboxRepository.MarkSent(ctx, message.ID, time.Now()); err != nil {
+				return sent, failed, err
+			}
+			sent++
+			continue
+		}
+
+		failed++
+		attempts := message.Attempts + 1
+
+		var retryAt *time.Time
+		if attempts < s.config.Outbox.MaxAttempts && !errors.Is(deliveryErr, errUndeliverable) {
+			next := now.Add(outboxBackoff(attempts))
+			retryAt = &next
+		}
+
+		if err := s.outboxRepository.MarkFailed(ctx, message.ID, attempts, deliveryErr.Error(), retryAt); err != nil {
+			return sent, failed, err
+		}
+	}
+
+	return sent, failed, nil
+}
+
+func (s *OutboxService) deliver(ctx context.Context, message *models.OutboxMessage) error {
+	switch message.Kind {
+	case constants.OutboxKindEmail:
+		var emailMessage models.EmailMessage
+		if err := json.Unmarshal([]byte(message.Payload), &emailMessage); err != nil {
+			return fmt.Errorf("%w: %v", errUndeliverable, err)
+		}
+
+		email, err := s.renderer.Render(&emailMessage)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errUndeliverable, err)
+		}
+
+		return s.mailer.Send(ctx, email)
+	default:
+		return fmt.Errorf("%w: unknown outbox message kind %q", errUndeliverable, message.Kind)
+	}
+}
+
+// outboxBackoff doubles the delay with every failed attempt.
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxRetryBase
+	for i := 1; i < attempts && delay < outboxRetryMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, outboxRetryMax)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcrypt ignores everything past the first 72 bytes of a password.
+const maxPasswordBytes = 72
+
+// checkPassword enforces the password policy on a password the user is
+// choosing.
+func (s *AuthService) checkPassword(password, email string) error {
+	minLength := s.config.Auth.PasswordMinLength
+	if len([]rune(password)) < minLength {
+		return fmt.Errorf("%w: password must be at least %d characters", ErrInvalidArgument, minLength)
+	}
+
+	if len(password) > maxPasswordBytes {
+		return fmt.Errorf("%w: password must be at most %d bytes", ErrInvalidArgument, maxPasswordBytes)
+	}
+
+	if strings.EqualFold(password, email) {
+		return fmt.Errorf("%w: password must not be the email", ErrInvalidArgument)
+	}
+
+	if s.passwordDenylist.Contains(password) {
+		return fmt.Errorf("%w: password is too common, choose another one", ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+func (s *AuthService) hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.config.Auth.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// needsRehash reports whether the hash was made with a lower cost than the
+// configured one.
+func (s *AuthService) needsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost < s.config.Auth.BcryptCost
+}
@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+type PaymentService struct {
+	orderRepository ports.OrderRepository
+	eventRepository ports.EventRepository
+	provider        ports.PaymentProvider
+	ticketService   *TicketService
+	transactor      ports.Transactor
+}
+
+// refundRetryBatchSize caps how many pending refunds one retry pass handles.
+const refundRetryBatchSize = 100
+
+func NewPaymentService(
+	orderRepository ports.OrderRepository,
+	eventRepository ports.EventRepository,
+	provider ports.PaymentProvider,
+	ticketService *TicketService,
+	transactor ports.Transactor,
+) *PaymentService {
+	return &PaymentService{
+		orderRepository: orderRepository,
+		eventRepository: eventRepository,
+		provider:        provider,
+		ticketService:   ticketService,
+		transactor:      transactor,
+	}
+}
+
+// CreateOrder starts the purchase of a paid ticket. The ticket is issued once
+// the provider reports the payment as succeeded.
+func (s *PaymentService) CreateOrder(ctx context.Context, actor *models.Principal, eventID, ticketTypeID string) (*models.OrderResponse, error) {
+	ticketType, err := s.ticketService.GetTicketTypeForPurchase(ctx, eventID, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderID := uuid.New().String()
+	intent, err := s.provider.CreateIntent(ctx, models.PaymentIntentRequest{
+		OrderID:     orderID,
+		Amount:      ticketType.Price,
+		Currency:    ticketType.Currency,
+		Description: ticketType.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order := &models.Order{
+		ID:                orderID,
+		UserID:            actor.ID,
+		EventID:           eventID,
+		TicketTypeID:      ticketType.ID,
+		Amount:            ticketType.Price,
+		Currency:          ticketType.Currency,
+		Status:            constants.OrderStatusPending,
+		Provider:          s.provider.Name(),
+		ProviderPaymentID: intent.ID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := s.orderRepository.CreateOrder(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return &models.OrderResponse{
+		Order:  order,
+		Intent: intent,
+	}, nil
+}
+
+// ConfirmOrder confirms the payment with the provider on behalf of the buyer
+// and completes the order without waiting for the webhook.
+func (s *PaymentService) ConfirmOrder(ctx context.Context, actor *models.Principal, orderID string) (*models.Order, error) {
+	order, err := s.getOwnOrder(ctx, actor, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	intent, err := s.provider.ConfirmIntent(ctx, order.ProviderPaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch intent.Status {
+	case constants.PaymentIntentStatusSucceeded:
+		err = s.completeOrder(ctx, order)
+	case constants.PaymentIntentStatusFailed:
+		err = s.failOrder(ctx, order)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.orderRepository.GetOrder(ctx, order.ID)
+}
+
+func (s *PaymentService) GetOrder(ctx context.Context, actor *models.Principal, orderID string) (*models.Order, error) {
+	return s.getOwnOrder(ctx, actor, orderID)
+}
+
+func (s *PaymentService) GetMyOrders(ctx context.Context, actor *models.Principal) ([]models.Order, error) {
+	return s.orderRepository.GetOrdersByUser(ctx, actor.ID)
+}
+
+// RefundOrder returns the money of a paid order and cancels its ticket. Only
+// the event's organizers and moderators can refund.
+func (s *PaymentService) RefundOrder(ctx context.Context, actor *models.Principal, orderID string) (*models.Order, error) {
+	order, err := s.orderRepository.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, errors.New("order not found")
+	}
+
+	event, err := s.eventRepository.GetEvent(ctx, order.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil || !canDeleteEvent(actor, event) {
+		return nil, ErrForbidden
+	}
+
+	if order.Status != constants.OrderStatusPaid {
+		return nil, errors.New("only paid orders can be refunded")
+	}
+
+	if _, err := s.provider.Refund(ctx, order.ProviderPaymentID, order.Amount); err != nil {
+		return nil, err
+	}
+
+	if err := s.refundOrder(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return s.orderRepository.GetOrder(ctx, order.ID)
+}
+
+// HandleWebhook verifies and applies a provider notification. Each event is
+// applied at most once; order status transitions are idempotent as well, so
+// a redelivery racing with ConfirmOrder is harmless.
+func (s *PaymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := s.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	processed, err := s.orderRepository.IsWebhookEventProcessed(ctx, s.provider.Name(), event.ID)
+	if err != nil {
+		return err
+	}
+
+	if processed {
+		return nil
+	}
+
+	order, err := s.orderRepository.GetOrderByProviderPaymentID(ctx, s.provider.Name(), event.PaymentID)
+	if err != nil {
+		return err
+	}
+
+	// Notifications for payments we did not initiate are acknowledged so the
+	// provider stops redelivering them.
+	if order != nil {
+		switch event.Type {
+		case constants.PaymentEventSucceeded:
+			err = s.completeOrder(ctx, order)
+		case constants.PaymentEventFailed:
+			err = s.failOrder(ctx, order)
+		case constants.PaymentEventRefunded:
+			err = s.refundOrder(ctx, order)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.orderRepository.MarkWebhookEventProcessed(ctx, s.provider.Name(), event)
+}
+
+// completeOrder marks the order paid and issues its ticket in one
+// transaction, so an order is never paid without a ticket. Any failure other
+// than the ticket being unavailable leaves the order pending and is returned,
+// which makes the provider redeliver the webhook.
+func (s *PaymentService) completeOrder(ctx context.Context, order *models.Order) error {
+	if order.Status == constants.OrderStatusRefundPending {
+		return s.refundUnfulfilled(ctx, order)
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		moved, err := s.orderRepository.TransitionOrderStatus(ctx, order.ID, constants.OrderStatusPending, constants.OrderStatusPaid)
+		if err != nil || !moved {
+			return err
+		}
+
+		ticket, err := s.ticketService.IssuePaidTicket(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		return s.orderRepository.SetOrderTicket(ctx, order.ID, ticket.ID)
+	})
+	if !errors.Is(err, ports.ErrTicketUnavailable) {
+		return err
+	}
+
+	// The ticket sold out or the buyer already has one while the payment
+	// was in flight: give the money back. The order is marked first so a
+	// failed refund is retried instead of forgotten.
+	moved, err := s.orderRepository.TransitionOrderStatus(ctx, order.ID, constants.OrderStatusPending, constants.OrderStatusRefundPending)
+	if err != nil || !moved {
+		return err
+	}
+
+	return s.refundUnfulfilled(ctx, order)
+}
+
+// refundUnfulfilled refunds a payment whose ticket could not be issued.
+// Provider refunds of an already refunded payment succeed, so retrying is
+// safe.
+func (s *PaymentService) refundUnfulfilled(ctx context.Context, order *models.Order) error {
+	if _, err := s.provider.Refund(ctx, order.ProviderPaymentID, order.Amount); err != nil {
+		return err
+	}
+
+	_, err := s.orderRepository.TransitionOrderStatus(ctx, order.ID, constants.OrderStatusRefundPending, constants.OrderStatusRefunded)
+	return err
+}
+
+// RetryPendingRefunds retries the refunds of orders that were paid but could
+// not be fulfilled. It returns how many were refunded.
+func (s *PaymentService) RetryPendingRefunds(ctx context.Context) (int, error) {
+	orders, err := s.orderRepository.GetOrdersByStatus(ctx, constants.OrderStatusRefundPending, refundRetryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	refunded := 0
+	var errs []error
+	for i := range orders {
+		if err := s.refundUnfulfilled(ctx, &orders[i]); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", orders[i].ID, err))
+			continue
+		}
+		refunded++
+	}
+
+	return refunded, errors.Join(errs...)
+}
+
+func (s *PaymentService) failOrder(ctx context.Context, order *models.Order) error {
+	_, err := s.orderRepository.TransitionOrderStatus(ctx, order.ID, constants.OrderStatusPending, constants.OrderStatusFailed)
+	return err
+}
+
+func (s *PaymentService) refundOrder(ctx context.Context, order *models.Order) error {
+	if order.Status == constants.OrderStatusRefundPending {
+		_, err := s.orderRepository.TransitionOrderStatus(ctx, order.ID, constants.OrderStatusRefundPending, constants.OrderStatusRefunded)
+		return err
+	}
+
+	// In one transaction, so a refunded order never keeps a valid ticket: if
+	// the ticket cannot be cancelled the order stays paid and the refund can
+	// be retried.
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		moved, err := s.orderRepository.TransitionOrderStatus(ctx, order.ID, constants.OrderStatusPaid, constants.OrderStatusRefunded)
+		if err != nil || !moved {
+			return err
+		}
+
+		current, err := s.orderRepository.GetOrder(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		if current.TicketID == nil {
+			return nil
+		}
+
+		return s.ticketService.CancelTicket(ctx, *current.TicketID)
+	})
+}
+
+func (s *PaymentService) getOwnOrder(ctx context.Context, actor *models.Principal, orderID string) (*models.Order, error) {
+	order, err := s.orderRepository.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, errors.New("order not found")
+	}
+
+	if order.UserID != actor.ID {
+		return nil, ErrForbidden
+	}
+
+	return order, nil
+}
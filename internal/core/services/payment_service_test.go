@@ -0,0 +1,553 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/payments"
+)
+
+const (
+	testEventID      = "event-1"
+	testTicketTypeID = "ticket-type-1"
+	testOrganizerID  = "organizer"
+)
+
+// paymentStore holds the orders and tickets the fake repositories share, so
+// the fake transactor can roll them back together.
+type paymentStore struct {
+	mu          sync.Mutex
+	orders      map[string]models.Order
+	tickets     map[string]models.Ticket
+	ticketTypes map[string]models.TicketType
+	webhooks    map[string]bool
+	// issueErr makes IssueTicket fail, like a database error would.
+	issueErr error
+	// cancelErr makes CancelTicket fail.
+	cancelErr error
+}
+
+func newPaymentStore(quota int) *paymentStore {
+	return &paymentStore{
+		orders:  make(map[string]models.Order),
+		tickets: make(map[string]models.Ticket),
+		ticketTypes: map[string]models.TicketType{
+			testTicketTypeID: {
+				ID:       testTicketTypeID,
+				EventID:  testEventID,
+				Name:     "Standard",
+				Quota:    quota,
+				Price:    1500,
+				Currency: "RUB",
+			},
+		},
+		webhooks: make(map[string]bool),
+	}
+}
+
+// snapshot saves the orders and tickets for the fake transactor to restore.
+func (s *paymentStore) snapshot() func() {
+	s.mu.Lock()
+	orders := maps.Clone(s.orders)
+	tickets := maps.Clone(s.tickets)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		s.orders = orders
+		s.tickets = tickets
+		s.mu.Unlock()
+	}
+}
+
+type fakeOrderRepository struct {
+	store *paymentStore
+}
+
+func (r *fakeOrderRepository) CreateOrder(ctx context.Context, order *models.Order) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.orders[order.ID] = *order
+	return nil
+}
+
+func (r *fakeOrderRepository) GetOrder(ctx context.Context, orderID string) (*models.Order, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	order, ok := r.store.orders[orderID]
+	if !ok {
+		return nil, nil
+	}
+	return &order, nil
+}
+
+func (r *fakeOrderRepository) GetOrderByProviderPaymentID(ctx context.Context, provider, paymentID string) (*models.Order, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, order := range r.store.orders {
+		if order.Provider == provider && order.ProviderPaymentID == paymentID {
+			return &order, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOrderRepository) GetOrdersByUser(ctx context.Context, userID string) ([]models.Order, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var orders []models.Order
+	for _, order := range r.store.orders {
+		if order.UserID == userID {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+func (r *fakeOrderRepository) EventHasOrders(ctx context.Context, eventID string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, order := range r.store.orders {
+		if order.EventID == eventID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeOrderRepository) GetOrdersByStatus(ctx context.Context, status constants.OrderStatus, limit int) ([]models.Order, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var orders []models.Order
+	for _, order := range r.store.orders {
+		if order.Status == status && len(orders) < limit {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+func (r *fakeOrderRepository) TransitionOrderStatus(ctx context.Context, orderID string, from, to constants.OrderStatus) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	order, ok := r.store.orders[orderID]
+	if !ok || order.Status != from {
+		return false, nil
+	}
+	order.Status = to
+	r.store.orders[orderID] = order
+	return true, nil
+}
+
+func (r *fakeOrderRepository) SetOrderTicket(ctx context.Context, orderID, ticketID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	order := r.store.orders[orderID]
+	order.TicketID = &ticketID
+	r.store.orders[orderID] = order
+	return nil
+}
+
+func (r *fakeOrderRepository) IsWebhookEventProcessed(ctx context.Context, provider, eventID string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.webhooks[provider+"/"+eventID], nil
+}
+
+func (r *fakeOrderRepository) MarkWebhookEventProcessed(ctx context.Context, provider string, event *models.PaymentWebhookEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.webhooks[provider+"/"+event.ID] = true
+	return nil
+}
+
+// fakeTicketRepository implements the ticket calls payments make; the rest
+// of the interface is left nil.
+type fakeTicketRepository struct {
+	ports.TicketRepository
+	store *paymentStore
+}
+
+func (r *fakeTicketRepository) GetTicketType(ctx context.Context, ticketTypeID string) (*models.TicketType, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	ticketType, ok := r.store.ticketTypes[ticketTypeID]
+	if !ok {
+		return nil, nil
+	}
+	return &ticketType, nil
+}
+
+func (r *fakeTicketRepository) IssueTicket(ctx context.Context, ticket *models.Ticket) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.issueErr != nil {
+		return r.store.issueErr
+	}
+
+	sold := 0
+	for _, existing := range r.store.tickets {
+		if existing.TicketTypeID != ticket.TicketTypeID || existing.Status != constants.TicketStatusValid {
+			continue
+		}
+		if existing.UserID == ticket.UserID {
+			return fmt.Errorf("%w: user already has a ticket of this type", ports.ErrTicketUnavailable)
+		}
+		sold++
+	}
+
+	if sold >= r.store.ticketTypes[ticket.TicketTypeID].Quota {
+		return fmt.Errorf("%w: tickets of this type are sold out", ports.ErrTicketUnavailable)
+	}
+
+	r.store.tickets[ticket.ID] = *ticket
+	return nil
+}
+
+func (r *fakeTicketRepository) CancelTicket(ctx context.Context, ticketID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.cancelErr != nil {
+		return r.store.cancelErr
+	}
+	ticket := r.store.tickets[ticketID]
+	ticket.Status = constants.TicketStatusCancelled
+	r.store.tickets[ticketID] = ticket
+	return nil
+}
+
+type fakeEventRepository struct {
+	ports.EventRepository
+}
+
+func (r *fakeEventRepository) GetEvent(ctx context.Context, eventID string) (*models.Event, error) {
+	if eventID != testEventID {
+		return nil, nil
+	}
+	return &models.Event{
+		ID:               testEventID,
+		Organizer:        testOrganizerID,
+		ModerationStatus: constants.EventModerationStatusApproved,
+	}, nil
+}
+
+// refundFailingProvider is the fake provider with refunds that fail until
+// refundErr is cleared.
+type refundFailingProvider struct {
+	*payments.FakeProvider
+	refundErr error
+}
+
+func (p *refundFailingProvider) Refund(ctx context.Context, intentID string, amount int64) (*models.PaymentIntent, error) {
+	if p.refundErr != nil {
+		return nil, p.refundErr
+	}
+	return p.FakeProvider.Refund(ctx, intentID, amount)
+}
+
+type paymentFixture struct {
+	store    *paymentStore
+	provider *refundFailingProvider
+	service  *services.PaymentService
+}
+
+func newPaymentFixture(t *testing.T, quota int) *paymentFixture {
+	t.Helper()
+
+	store := newPaymentStore(quota)
+	provider := &refundFailingProvider{FakeProvider: payments.NewFakeProvider("webhook-secret")}
+
+	eventRepository := &fakeEventRepository{}
+	ticketService, err := services.NewTicketService(
+		&fakeTicketRepository{store: store},
+		eventRepository,
+		nil,
+		newTestConfig(),
+	)
+	if err != nil {
+		t.Fatalf("NewTicketService: %v", err)
+	}
+
+	return &paymentFixture{
+		store:    store,
+		provider: provider,
+		service: services.NewPaymentService(
+			&fakeOrderRepository{store: store},
+			eventRepository,
+			provider,
+			ticketService,
+			&fakeTransactor{snapshot: store.snapshot},
+		),
+	}
+}
+
+func (f *paymentFixture) createOrder(t *testing.T, userID string) *models.Order {
+	t.Helper()
+
+	resp, err := f.service.CreateOrder(context.Background(), &models.Principal{ID: userID}, testEventID, testTicketTypeID)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	return resp.Order
+}
+
+func (f *paymentFixture) webhook(t *testing.T, order *models.Order, eventType constants.PaymentEventType) ([]byte, string) {
+	t.Helper()
+
+	payload, signature, err := f.provider.SimulateWebhook(order.ProviderPaymentID, eventType)
+	if err != nil {
+		t.Fatalf("SimulateWebhook: %v", err)
+	}
+	return payload, signature
+}
+
+func (f *paymentFixture) order(t *testing.T, orderID string) models.Order {
+	t.Helper()
+
+	f.store.mu.Lock()
+	defer f.store.mu.Unlock()
+	order, ok := f.store.orders[orderID]
+	if !ok {
+		t.Fatalf("order %s not found", orderID)
+	}
+	return order
+}
+
+func (f *paymentFixture) validTickets() int {
+	f.store.mu.Lock()
+	defer f.store.mu.Unlock()
+	valid := 0
+	for _, ticket := range f.store.tickets {
+		if ticket.Status == constants.TicketStatusValid {
+			valid++
+		}
+	}
+	return valid
+}
+
+func TestConfirmOrderIssuesTicket(t *testing.T) {
+	f := newPaymentFixture(t, 10)
+	order := f.createOrder(t, "buyer")
+
+	got, err := f.service.ConfirmOrder(context.Background(), &models.Principal{ID: "buyer"}, order.ID)
+	if err != nil {
+		t.Fatalf("ConfirmOrder: %v", err)
+	}
+
+	if got.Status != constants.OrderStatusPaid {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusPaid)
+	}
+	if got.TicketID == nil {
+		t.Fatal("paid order has no ticket")
+	}
+	if f.validTickets() != 1 {
+		t.Fatalf("valid tickets = %d, want 1", f.validTickets())
+	}
+}
+
+func TestDeclinedPaymentFailsOrder(t *testing.T) {
+	f := newPaymentFixture(t, 10)
+	order := f.createOrder(t, "buyer")
+
+	if _, err := f.provider.Decline(order.ProviderPaymentID); err != nil {
+		t.Fatalf("Decline: %v", err)
+	}
+
+	payload, signature := f.webhook(t, order, constants.PaymentEventFailed)
+	if err := f.service.HandleWebhook(context.Background(), payload, signature); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+
+	if got := f.order(t, order.ID); got.Status != constants.OrderStatusFailed {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusFailed)
+	}
+	if f.validTickets() != 0 {
+		t.Fatalf("valid tickets = %d, want 0", f.validTickets())
+	}
+}
+
+func TestWebhookRedeliveryIssuesOneTicket(t *testing.T) {
+	f := newPaymentFixture(t, 10)
+	order := f.createOrder(t, "buyer")
+
+	if _, err := f.provider.ConfirmIntent(context.Background(), order.ProviderPaymentID); err != nil {
+		t.Fatalf("ConfirmIntent: %v", err)
+	}
+
+	payload, signature := f.webhook(t, order, constants.PaymentEventSucceeded)
+	for i := 0; i < 2; i++ {
+		if err := f.service.HandleWebhook(context.Background(), payload, signature); err != nil {
+			t.Fatalf("HandleWebhook #%d: %v", i+1, err)
+		}
+	}
+
+	// A second notification with a new event ID must not issue another
+	// ticket either.
+	payload, signature = f.webhook(t, order, constants.PaymentEventSucceeded)
+	if err := f.service.HandleWebhook(context.Background(), payload, signature); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+
+	if got := f.order(t, order.ID); got.Status != constants.OrderStatusPaid {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusPaid)
+	}
+	if f.validTickets() != 1 {
+		t.Fatalf("valid tickets = %d, want 1", f.validTickets())
+	}
+}
+
+func TestWebhookWithBadSignatureIsRejected(t *testing.T) {
+	f := newPaymentFixture(t, 10)
+	order := f.createOrder(t, "buyer")
+
+	payload, _ := f.webhook(t, order, constants.PaymentEventSucceeded)
+	err := f.service.HandleWebhook(context.Background(), payload, "forged")
+	if !errors.Is(err, services.ErrInvalidWebhook) {
+		t.Fatalf("err = %v, want %v", err, services.ErrInvalidWebhook)
+	}
+	if got := f.order(t, order.ID); got.Status != constants.OrderStatusPending {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusPending)
+	}
+}
+
+func TestSoldOutPaymentIsRefunded(t *testing.T) {
+	f := newPaymentFixture(t, 1)
+	first := f.createOrder(t, "first")
+	second := f.createOrder(t, "second")
+
+	if _, err := f.service.ConfirmOrder(context.Background(), &models.Principal{ID: "first"}, first.ID); err != nil {
+		t.Fatalf("ConfirmOrder first: %v", err)
+	}
+
+	got, err := f.service.ConfirmOrder(context.Background(), &models.Principal{ID: "second"}, second.ID)
+	if err != nil {
+		t.Fatalf("ConfirmOrder second: %v", err)
+	}
+
+	if got.Status != constants.OrderStatusRefunded {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusRefunded)
+	}
+	if got.TicketID != nil {
+		t.Fatal("refunded order has a ticket")
+	}
+	if f.validTickets() != 1 {
+		t.Fatalf("valid tickets = %d, want 1", f.validTickets())
+	}
+}
+
+func TestFailedRefundIsRetried(t *testing.T) {
+	f := newPaymentFixture(t, 0)
+	order := f.createOrder(t, "buyer")
+	f.provider.refundErr = errors.New("provider unavailable")
+
+	if _, err := f.provider.ConfirmIntent(context.Background(), order.ProviderPaymentID); err != nil {
+		t.Fatalf("ConfirmIntent: %v", err)
+	}
+
+	payload, signature := f.webhook(t, order, constants.PaymentEventSucceeded)
+	if err := f.service.HandleWebhook(context.Background(), payload, signature); err == nil {
+		t.Fatal("HandleWebhook succeeded although the refund failed")
+	}
+
+	if got := f.order(t, order.ID); got.Status != constants.OrderStatusRefundPending {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusRefundPending)
+	}
+
+	// The unacknowledged webhook is redelivered while the provider is still
+	// down; the order must keep waiting for its refund.
+	if err := f.service.HandleWebhook(context.Background(), payload, signature); err == nil {
+		t.Fatal("HandleWebhook succeeded although the refund failed")
+	}
+
+	f.provider.refundErr = nil
+	refunded, err := f.service.RetryPendingRefunds(context.Background())
+	if err != nil {
+		t.Fatalf("RetryPendingRefunds: %v", err)
+	}
+	if refunded != 1 {
+		t.Fatalf("refunded = %d, want 1", refunded)
+	}
+
+	if got := f.order(t, order.ID); got.Status != constants.OrderStatusRefunded {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusRefunded)
+	}
+
+	// The redelivered webhook is now acknowledged without a second refund.
+	if err := f.service.HandleWebhook(context.Background(), payload, signature); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+}
+
+func TestTransientIssueErrorKeepsOrderPending(t *testing.T) {
+	f := newPaymentFixture(t, 10)
+	order := f.createOrder(t, "buyer")
+	f.store.issueErr = errors.New("connection reset")
+
+	if _, err := f.provider.ConfirmIntent(context.Background(), order.ProviderPaymentID); err != nil {
+		t.Fatalf("ConfirmIntent: %v", err)
+	}
+
+	payload, signature := f.webhook(t, order, constants.PaymentEventSucceeded)
+	if err := f.service.HandleWebhook(context.Background(), payload, signature); err == nil {
+		t.Fatal("HandleWebhook succeeded although the ticket was not issued")
+	}
+
+	if got := f.order(t, order.ID); got.Status != constants.OrderStatusPending {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusPending)
+	}
+
+	f.store.issueErr = nil
+	if err := f.service.HandleWebhook(context.Background(), payload, signature); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+
+	got := f.order(t, order.ID)
+	if got.Status != constants.OrderStatusPaid || got.TicketID == nil {
+		t.Fatalf("order = %s with ticket %v, want paid with a ticket", got.Status, got.TicketID)
+	}
+}
+
+func TestFailedTicketCancellationKeepsOrderPaid(t *testing.T) {
+	f := newPaymentFixture(t, 10)
+	order := f.createOrder(t, "buyer")
+	organizer := &models.Principal{ID: testOrganizerID}
+
+	if _, err := f.service.ConfirmOrder(context.Background(), &models.Principal{ID: "buyer"}, order.ID); err != nil {
+		t.Fatalf("ConfirmOrder: %v", err)
+	}
+
+	f.store.cancelErr = errors.New("connection reset")
+	if _, err := f.service.RefundOrder(context.Background(), organizer, order.ID); err == nil {
+		t.Fatal("RefundOrder succeeded although the ticket was not cancelled")
+	}
+
+	if got := f.order(t, order.ID); got.Status != constants.OrderStatusPaid {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusPaid)
+	}
+	if f.validTickets() != 1 {
+		t.Fatalf("valid tickets = %d, want 1", f.validTickets())
+	}
+
+	// The order is still paid, so the organizer can retry the refund.
+	f.store.cancelErr = nil
+	got, err := f.service.RefundOrder(context.Background(), organizer, order.ID)
+	if err != nil {
+		t.Fatalf("RefundOrder: %v", err)
+	}
+
+	if got.Status != constants.OrderStatusRefunded {
+		t.Fatalf("status = %s, want %s", got.Status, constants.OrderStatusRefunded)
+	}
+	if f.validTickets() != 0 {
+		t.Fatalf("valid tickets = %d, want 0", f.validTickets())
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+const maxRealtimeChannels = 20
+
+// RealtimeService forwards what other services publish on the message bus
+// to the realtime channels of the users and events concerned.
+type RealtimeService struct {
+	broker          ports.RealtimeBroker
+	eventRepository ports.EventRepository
+}
+
+func NewRealtimeService(broker ports.RealtimeBroker, eventRepository ports.EventRepository) *RealtimeService {
+	return &RealtimeService{
+		broker:          broker,
+		eventRepository: eventRepository,
+	}
+}
+
+// SubscribeRealtime subscribes the realtime service to the topics it
+// forwards.
+func SubscribeRealtime(bus ports.MessageBus, s *RealtimeService) {
+	bus.Subscribe(constants.TopicFriendRequestSent, s.onFriendRequestSent)
+	bus.Subscribe(constants.TopicFriendRequestAnswered, s.onFriendRequestAnswered)
+	bus.Subscribe(constants.TopicNotificationCreated, s.onNotificationCreated)
+	bus.Subscribe(constants.TopicEventStatusChanged, s.onEventStatusChanged)
+	bus.Subscribe(constants.TopicEventChanged, s.onEventChanged)
+	bus.Subscribe(constants.TopicEventCancelled, s.onEventCancelled)
+}
+
+// Subscribe subscribes the actor to the requested channels. "user" stands
+// for the actor's own channel; users may not listen to anybody else's, and
+// only to the channels of events they can see.
+func (s *RealtimeService) Subscribe(ctx context.Context, actor *models.Principal, channels []string) (ports.RealtimeSubscription, error) {
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("%w: at least one channel is required", ErrInvalidArgument)
+	}
+
+	if len(channels) > maxRealtimeChannels {
+		return nil, fmt.Errorf("%w: at most %d channels can be subscribed to", ErrInvalidArgument, maxRealtimeChannels)
+	}
+
+	resolved := make([]string, 0, len(channels))
+	seen := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		channel, err := s.resolveChannel(ctx, actor, channel)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[channel] {
+			seen[channel] = true
+			resolved = append(resolved, channel)
+		}
+	}
+
+	return s.broker.Subscribe(resolved), nil
+}
+
+func (s *RealtimeService) resolveChannel(ctx context.Context, actor *models.Principal, channel string) (string, error) {
+	kind, id, _ := strings.Cut(strings.TrimSpace(channel), ":")
+
+	switch kind {
+	case constants.RealtimeChannelUser:
+		if id != "" && id != actor.ID {
+			return "", fmt.Errorf("%w: cannot subscribe to another user's channel", ErrForbidden)
+		}
+		return models.UserChannel(actor.ID), nil
+	case constants.RealtimeChannelEvent:
+		if id == "" {
+			return "", fmt.Errorf("%w: event ID is required", ErrInvalidArgument)
+		}
+
+		event, err := s.eventRepository.GetEvent(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		if event == nil {
+			return "", fmt.Errorf("%w: event %s not found", ErrInvalidArgument, id)
+		}
+
+		if event.ModerationStatus != constants.EventModerationStatusApproved &&
+			!canEditEvent(actor, event) && !actor.Can(constants.PermissionModerateEvents) {
+			return "", fmt.Errorf("%w: event %s is not published", ErrForbidden, id)
+		}
+
+		return models.EventChannel(event.ID), nil
+	default:
+		return "", fmt.Errorf("%w: unknown channel %q", ErrInvalidArgument, channel)
+	}
+}
+
+func (s *RealtimeService) onFriendRequestSent(ctx context.Context, payload any) error {
+	notice, ok := payload.(*models.FriendRequestNotice)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	return s.publish(ctx, models.UserChannel(notice.ToID), constants.RealtimeMessageFriendRequest, notice)
+}
+
+func (s *RealtimeService) onFriendRequestAnswered(ctx context.Context, payload any) error {
+	answer, ok := payload.(*models.FriendRequestAnswer)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	return s.publish(ctx, models.UserChannel(answer.FromID), constants.RealtimeMessageFriendRequestAnswered, answer)
+}
+
+func (s *RealtimeService) onNotificationCreated(ctx context.Context, payload any) error {
+	notification, ok := payload.(*models.Notification)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	return s.publish(ctx, models.UserChannel(notification.UserID), constants.RealtimeMessageNotification, notification)
+}
+
+func (s *RealtimeService) onEventStatusChanged(ctx context.Context, payload any) error {
+	change, ok := payload.(*models.EventStatusChange)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	return s.publish(ctx, models.EventChannel(change.EventID), constants.RealtimeMessageEventStatus, change)
+}
+
+func (s *RealtimeService) onEventChanged(ctx context.Context, payload any) error {
+	change, ok := payload.(*models.EventChange)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	return s.publish(ctx, models.EventChannel(change.EventID), constants.RealtimeMessageEventChanged, change)
+}
+
+func (s *RealtimeService) onEventCancelled(ctx context.Context, payload any) error {
+	cancellation, ok := payload.(*models.EventCancellation)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T", payload)
+	}
+
+	// Attendee IDs are not for everyone watching the event.
+	return s.publish(ctx, models.EventChannel(cancellation.EventID), constants.RealtimeMessageEventCancelled, &models.EventCancellation{
+		EventID:     cancellation.EventID,
+		Title:       cancellation.Title,
+		CancelledBy: cancellation.CancelledBy,
+	})
+}
+
+func (s *RealtimeService) publish(ctx context.Context, channel string, messageType constants.RealtimeMessageType, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return s.broker.Publish(ctx, &models.RealtimeMessage{
+		Channel: channel,
+		Type:    messageType,
+		Data:    encoded,
+	})
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/teambition/rrule-go"
+)
+
+const (
+	// maxRecurrenceOccurrences bounds finite rules so that their end can be
+	// computed and they cannot flood listings.
+	maxRecurrenceOccurrences = 1000
+	// maxOccurrenceWindow bounds the window occurrences are expanded in.
+	maxOccurrenceWindow = 92 * 24 * time.Hour
+)
+
+// parseRecurrenceRule parses an RRULE value such as
+// "FREQ=WEEKLY;BYDAY=TU;COUNT=10". DTSTART comes from the event, so it may
+// not be part of the rule.
+func parseRecurrenceRule(rule string) (*rrule.ROption, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if strings.ContainsAny(rule, "\r\n") || strings.Contains(rule, "DTSTART") {
+		return nil, fmt.Errorf("%w: recurrence must be a single RRULE without DTSTART", ErrInvalidArgument)
+	}
+
+	option, err := rrule.StrToROption(rule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid recurrence: %v", ErrInvalidArgument, err)
+	}
+
+	switch option.Freq {
+	case rrule.DAILY, rrule.WEEKLY, rrule.MONTHLY, rrule.YEARLY:
+	default:
+		return nil, fmt.Errorf("%w: recurrence must be daily, weekly, monthly or yearly", ErrInvalidArgument)
+	}
+
+	if option.Count > maxRecurrenceOccurrences {
+		return nil, fmt.Errorf("%w: recurrence may have at most %d occurrences", ErrInvalidArgument, maxRecurrenceOccurrences)
+	}
+
+	return option, nil
+}
+
+// newRecurrenceRule builds the rule of a recurring event anchored at its
+// start in the venue timezone, so that "every Tuesday at 19:00" stays at
+// 19:00 local time across DST changes.
+func newRecurrenceRule(event *models.Event, option *rrule.ROption) (*rrule.RRule, error) {
+	option.Dtstart = event.Date.In(models.LoadLocation(event.Timezone))
+
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid recurrence: %v", ErrInvalidArgument, err)
+	}
+
+	return rule, nil
+}
+
+func newRecurrenceSet(event *models.Event) (*rrule.Set, error) {
+	option, err := parseRecurrenceRule(event.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := newRecurrenceRule(event, option)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &rrule.Set{}
+	set.RRule(rule)
+	for _, exception := range event.RecurrenceExceptions {
+		set.ExDate(exception)
+	}
+
+	return set, nil
+}
+
+// applyRecurrence validates the rule and exceptions and stores them on the
+// event together with the end of its last occurrence.
+func applyRecurrence(event *models.Event, rule string, exceptions models.Times) error {
+	event.Recurrence = ""
+	event.RecurrenceExceptions = models.Times{}
+	event.RecurrenceEnd = nil
+
+	if strings.TrimSpace(rule) == "" {
+		return nil
+	}
+
+	option, err := parseRecurrenceRule(rule)
+	if err != nil {
+		return err
+	}
+
+	event.Recurrence = option.RRuleString()
+	for _, exception := range exceptions {
+		event.RecurrenceExceptions = append(event.RecurrenceExceptions, exception.UTC())
+	}
+
+	if option.Count == 0 && option.Until.IsZero() {
+		return nil
+	}
+
+	recurrence, err := newRecurrenceRule(event, option)
+	if err != nil {
+		return err
+	}
+
+	var last time.Time
+	next := recurrence.Iterator()
+	for i := 0; ; i++ {
+		occurrence, ok := next()
+		if !ok {
+			break
+		}
+		if i == maxRecurrenceOccurrences {
+			return fmt.Errorf("%w: recurrence may have at most %d occurrences", ErrInvalidArgument, maxRecurrenceOccurrences)
+		}
+		last = occurrence
+	}
+
+	if last.IsZero() {
+		return fmt.Errorf("%w: recurrence has no occurrences", ErrInvalidArgument)
+	}
+
+	end := last.Add(event.EndDate.Sub(event.Date)).UTC()
+	event.RecurrenceEnd = &end
+
+	return nil
+}
+
+// isOccurrenceOf reports whether the event has an occurrence starting at the
+// given time, ignoring occurrences removed by exceptions.
+func isOccurrenceOf(event *models.Event, occurrence time.Time) (bool, error) {
+	if !event.IsRecurring() {
+		return occurrence.Equal(event.Date), nil
+	}
+
+	set, err := newRecurrenceSet(event)
+	if err != nil {
+		return false, err
+	}
+
+	return len(set.Between(occurrence, occurrence, true)) > 0, nil
+}
+
+// countOccurrencesBefore returns how many occurrences of the rule start
+// before the given time, which must be after the first one. Occurrences
+// removed by exceptions are counted too, as COUNT does.
+func countOccurrencesBefore(event *models.Event, option *rrule.ROption, before time.Time) (int, error) {
+	rule, err := newRecurrenceRule(event, option)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(rule.Between(event.Date, before, false)) + 1, nil
+}
+
+// expandOccurrences returns the occurrences of the event that overlap the
+// window [from, to), sorted by start.
+func expandOccurrences(event *models.Event, overrides []models.OccurrenceOverride, from, to time.Time) ([]models.EventOccurrence, error) {
+	duration := event.EndDate.Sub(event.Date)
+
+	var starts []time.Time
+	if event.IsRecurring() {
+		set, err := newRecurrenceSet(event)
+		if err != nil {
+			return nil, err
+		}
+		// Occurrences that started before the window may still be running.
+		starts = set.Between(from.Add(-duration), to, false)
+	} else {
+		starts = []time.Time{event.Date}
+	}
+
+	byStart := make(map[int64]*models.OccurrenceOverride, len(overrides))
+	for i := range overrides {
+		byStart[overrides[i].OccurrenceDate.Unix()] = &overrides[i]
+	}
+
+	seen := make(map[int64]bool, len(starts))
+	occurrences := make([]models.EventOccurrence, 0, len(starts))
+	add := func(start time.Time) {
+		seen[start.Unix()] = true
+
+		occurrence := newOccurrence(event, start, duration, byStart[start.Unix()])
+		if occurrence.Date.Before(to) && occurrence.EndDate.After(from) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	for _, start := range starts {
+		add(start)
+	}
+
+	// An override may move an occurrence from outside the window into it.
+	for i := range overrides {
+		override := &overrides[i]
+		if seen[override.OccurrenceDate.Unix()] || override.Date == nil {
+			continue
+		}
+
+		valid, err := isOccurrenceOf(event, override.OccurrenceDate)
+		if err != nil {
+			return nil, err
+		}
+		if valid {
+			add(override.OccurrenceDate)
+		}
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+
+	return occurrences, nil
+}
+
+func newOccurrence(event *models.Event, start time.Time, duration time.Duration, override *models.OccurrenceOverride) models.EventOccurrence {
+	occurrence := models.EventOccurrence{
+		Event:          *event,
+		OccurrenceDate: start.UTC(),
+	}
+	occurrence.Date = start
+	occurrence.EndDate = start.Add(duration)
+
+	if override != nil {
+		occurrence.Overridden = true
+		if override.Title != nil {
+			occurrence.Title = *override.Title
+		}
+		if override.Description != nil {
+			occurrence.Description = *override.Description
+		}
+		if override.Date != nil {
+			occurrence.Date = *override.Date
+		}
+		if override.EndDate != nil {
+			occurrence.EndDate = *override.EndDate
+		}
+	}
+
+	occurrence.Localize()
+
+	return occurrence
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+const testTimezone = "Europe/Berlin"
+
+// at19 returns 19:00 in Berlin on a day of 2026. Clocks go forward on
+// March 29, so 19:00 is 18:00 UTC before and 17:00 UTC after.
+func at19(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 19, 0, 0, 0, models.LoadLocation(testTimezone))
+}
+
+// weeklyEvent recurs on Tuesdays at 19:00 Berlin time for five weeks, from
+// March 24 to April 21, two hours each, without the week of March 31.
+func weeklyEvent(t *testing.T) *models.Event {
+	t.Helper()
+
+	event := &models.Event{Date: at19(time.March, 24), EndDate: at19(time.March, 24).Add(2 * time.Hour), Timezone: testTimezone}
+	if err := applyRecurrence(event, "FREQ=WEEKLY;COUNT=5", models.Times{at19(time.March, 31)}); err != nil {
+		t.Fatalf("applyRecurrence: %v", err)
+	}
+	return event
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	for _, test := range []struct {
+		rule  string
+		valid bool
+	}{
+		{"FREQ=WEEKLY;BYDAY=TU;COUNT=10", true},
+		{"RRULE:FREQ=DAILY", true},
+		{" FREQ=MONTHLY;UNTIL=20270101T000000Z ", true},
+		{"FREQ=YEARLY;COUNT=1000", true},
+		{"FREQ=HOURLY", false},
+		{"FREQ=MINUTELY;COUNT=5", false},
+		{"FREQ=DAILY;COUNT=1001", false},
+		{"FREQ=DAILY;DTSTART=20260101T000000Z", false},
+		{"DTSTART:20260101T000000Z\nRRULE:FREQ=DAILY", false},
+		{"FREQ=DAILY\r\nFREQ=WEEKLY", false},
+		{"every tuesday", false},
+	} {
+		_, err := parseRecurrenceRule(test.rule)
+		switch {
+		case test.valid && err != nil:
+			t.Errorf("%q: %v", test.rule, err)
+		case !test.valid && !errors.Is(err, ErrInvalidArgument):
+			t.Errorf("%q: err = %v, want ErrInvalidArgument", test.rule, err)
+		}
+	}
+}
+
+func TestApplyRecurrence(t *testing.T) {
+	start := at19(time.March, 24)
+
+	for _, test := range []struct {
+		name string
+		rule string
+		// end is the stored end of the last occurrence, zero when the rule
+		// has none.
+		end   time.Time
+		valid bool
+	}{
+		{"no rule", "", time.Time{}, true},
+		{"unbounded", "FREQ=DAILY", time.Time{}, true},
+		{"count across DST", "FREQ=WEEKLY;COUNT=3", at19(time.April, 7).Add(2 * time.Hour), true},
+		{"until", "FREQ=DAILY;UNTIL=20260326T230000Z", at19(time.March, 26).Add(2 * time.Hour), true},
+		{"until before the start", "FREQ=DAILY;UNTIL=20260301T000000Z", time.Time{}, false},
+		{"until too far", "FREQ=DAILY;UNTIL=20300101T000000Z", time.Time{}, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			event := &models.Event{Date: start, EndDate: start.Add(2 * time.Hour), Timezone: testTimezone}
+
+			err := applyRecurrence(event, test.rule, models.Times{start.In(time.FixedZone("", 3600))})
+			if !test.valid {
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Fatalf("err = %v, want ErrInvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyRecurrence: %v", err)
+			}
+
+			if test.rule == "" {
+				if event.IsRecurring() || len(event.RecurrenceExceptions) != 0 {
+					t.Errorf("event without a rule recurs: %q %v", event.Recurrence, event.RecurrenceExceptions)
+				}
+			} else if len(event.RecurrenceExceptions) != 1 || event.RecurrenceExceptions[0].Location() != time.UTC {
+				t.Errorf("exceptions = %v, want one in UTC", event.RecurrenceExceptions)
+			}
+
+			switch {
+			case test.end.IsZero() && event.RecurrenceEnd != nil:
+				t.Errorf("end = %v, want none", event.RecurrenceEnd)
+			case !test.end.IsZero() && (event.RecurrenceEnd == nil || !event.RecurrenceEnd.Equal(test.end)):
+				t.Errorf("end = %v, want %v", event.RecurrenceEnd, test.end.UTC())
+			}
+		})
+	}
+}
+
+func TestIsOccurrenceOf(t *testing.T) {
+	event := weeklyEvent(t)
+
+	for _, test := range []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"first", at19(time.March, 24), true},
+		{"after the DST change", at19(time.April, 7), true},
+		{"same UTC time after the DST change", at19(time.April, 7).Add(time.Hour), false},
+		{"exception", at19(time.March, 31), false},
+		{"last", at19(time.April, 21), true},
+		{"past the count", at19(time.April, 28), false},
+	} {
+		got, err := isOccurrenceOf(event, test.at)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if got != test.want {
+			t.Errorf("%s: isOccurrenceOf = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestExpandOccurrences(t *testing.T) {
+	event := weeklyEvent(t)
+
+	title := "Moved"
+	movedStart, movedEnd := at19(time.April, 1), at19(time.April, 1).Add(time.Hour)
+	overrides := []models.OccurrenceOverride{
+		{OccurrenceDate: at19(time.April, 14).UTC(), Title: &title, Date: &movedStart, EndDate: &movedEnd},
+	}
+
+	for _, test := range []struct {
+		name     string
+		from, to time.Time
+		want     []time.Time
+	}{
+		{"whole series", at19(time.March, 1), at19(time.May, 1), []time.Time{
+			at19(time.March, 24), movedStart, at19(time.April, 7), at19(time.April, 21),
+		}},
+		{"running at the window start", at19(time.March, 24).Add(time.Hour), at19(time.March, 25), []time.Time{
+			at19(time.March, 24),
+		}},
+		{"ended at the window start", at19(time.March, 24).Add(2 * time.Hour), at19(time.March, 25), nil},
+		{"moved into the window", at19(time.March, 30), at19(time.April, 2), []time.Time{movedStart}},
+		{"moved out of the window", at19(time.April, 13), at19(time.April, 15), nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			occurrences, err := expandOccurrences(event, overrides, test.from, test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(occurrences) != len(test.want) {
+				t.Fatalf("got %d occurrences, want %d", len(occurrences), len(test.want))
+			}
+			for i, occurrence := range occurrences {
+				if !occurrence.Date.Equal(test.want[i]) {
+					t.Errorf("occurrence %d starts at %v, want %v", i, occurrence.Date, test.want[i])
+				}
+				if moved := occurrence.Date.Equal(movedStart); moved != occurrence.Overridden || moved && occurrence.Title != title {
+					t.Errorf("occurrence %d = %q, overridden %v", i, occurrence.Title, occurrence.Overridden)
+				}
+			}
+		})
+	}
+}
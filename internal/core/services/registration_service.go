@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+type RegistrationService struct {
+	registrationRepository ports.RegistrationRepository
+	eventRepository        ports.EventRepository
+}
+
+func NewRegistrationService(registrationRepository ports.RegistrationRepository, eventRepository ports.EventRepository) *RegistrationService {
+	return &RegistrationService{
+		registrationRepository: registrationRepository,
+		eventRepository:        eventRepository,
+	}
+}
+
+func (s *RegistrationService) Register(ctx context.Context, actor *models.Principal, eventID string, status constants.RegistrationStatus) (*models.Registration, error) {
+	if eventID == "" {
+		return nil, errors.New("event ID is required")
+	}
+
+	if !status.IsRSVP() {
+		return nil, errors.New("invalid RSVP status")
+	}
+
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, errors.New("event not found")
+	}
+
+	if event.ModerationStatus != constants.EventModerationStatusApproved {
+		return nil, errors.New("event is not open for registration")
+	}
+
+	if event.Status == constants.EventStatusHeld {
+		return nil, errors.New("event has already taken place")
+	}
+
+	return s.registrationRepository.SaveRegistration(ctx, eventID, actor.ID, status)
+}
+
+func (s *RegistrationService) Unregister(ctx context.Context, actor *models.Principal, eventID string) error {
+	if eventID == "" {
+		return errors.New("event ID is required")
+	}
+
+	return s.registrationRepository.DeleteRegistration(ctx, eventID, actor.ID)
+}
+
+func (s *RegistrationService) GetRegistration(ctx context.Context, actor *models.Principal, eventID string) (*models.Registration, error) {
+	if eventID == "" {
+		return nil, errors.New("event ID is required")
+	}
+
+	return s.registrationRepository.GetRegistration(ctx, eventID, actor.ID)
+}
+
+func (s *RegistrationService) GetAttendees(ctx context.Context, actor *models.Principal, eventID string) ([]models.AttendeeResponse, error) {
+	if eventID == "" {
+		return nil, errors.New("event ID is required")
+	}
+
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, errors.New("event not found")
+	}
+
+	if !canEditEvent(actor, event) {
+		return nil, ErrForbidden
+	}
+
+	return s.registrationRepository.GetEventAttendees(ctx, eventID)
+}
+
+func (s *RegistrationService) GetUpcomingEvents(ctx context.Context, actor *models.Principal) ([]models.Event, error) {
+	return s.registrationRepository.GetUpcomingEventsByUser(ctx, actor.ID)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+// ReminderService emails attendees shortly before the events they are going
+// to.
+type ReminderService struct {
+	config                 *config.Config
+	eventService           *EventService
+	registrationRepository ports.RegistrationRepository
+	userRepository         ports.UserRepository
+	reminderRepository     ports.ReminderRepository
+	outboxRepository       ports.OutboxRepository
+	transactor             ports.Transactor
+}
+
+func NewReminderService(
+	config *config.Config,
+	eventService *EventService,
+	registrationRepository ports.RegistrationRepository,
+	userRepository ports.UserRepository,
+	reminderRepository ports.ReminderRepository,
+	outboxRepository ports.OutboxRepository,
+	transactor ports.Transactor,
+) *ReminderService {
+	return &ReminderService{
+		config:                 config,
+		eventService:           eventService,
+		registrationRepository: registrationRepository,
+		userRepository:         userRepository,
+		reminderRepository:     reminderRepository,
+		outboxRepository:       outboxRepository,
+		transactor:             transactor,
+	}
+}
+
+// SendReminders queues reminders for the occurrences starting within the
+// configured lead time, once per attendee and occurrence, and returns how
+// many it queued.
+func (s *ReminderService) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	occurrences, err := s.eventService.ListOccurrences(ctx, now, now.Add(s.config.Scheduler.EventReminderLead))
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for i := range occurrences {
+		occurrence := &occurrences[i]
+		// The window also holds occurrences that are already under way.
+		if occurrence.Date.Before(now) {
+			continue
+		}
+
+		attendees, err := s.registrationRepository.GetEventAttendees(ctx, occurrence.ID)
+		if err != nil {
+			return queued, err
+		}
+
+		for _, attendee := range attendees {
+			if attendee.Status != constants.RegistrationStatusGoing && attendee.Status != constants.RegistrationStatusMaybe {
+				continue
+			}
+
+			sent, err := s.remind(ctx, occurrence, attendee.UserID, now)
+			if err != nil {
+				return queued, err
+			}
+
+			if sent {
+				queued++
+			}
+		}
+	}
+
+	return queued, nil
+}
+
+func (s *ReminderService) remind(ctx context.Context, occurrence *models.EventOccurrence, userID string, now time.Time) (bool, error) {
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	recorded := false
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		recorded, err = s.reminderRepository.RecordReminder(ctx, &models.EventReminder{
+			EventID:        occurrence.ID,
+			OccurrenceDate: occurrence.OccurrenceDate,
+			UserID:         user.ID,
+			CreatedAt:      now,
+		})
+		if err != nil || !recorded {
+			return err
+		}
+
+		return enqueueEmail(ctx, s.outboxRepository, user, constants.EmailTemplateEventReminder, map[string]string{
+			"name":       user.Name,
+			"eventTitle": occurrence.Title,
+			"eventTime":  formatEmailTime(occurrence.Date, occurrence.Timezone, user.Locale),
+			"address":    occurrence.Location.Address,
+			"link":       eventLink(s.config.AppURL, occurrence.ID),
+		})
+	})
+
+	return recorded, err
+}
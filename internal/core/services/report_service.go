@@ -0,0 +1,533 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultCasePageSize = 20
+	maxCasePageSize     = 100
+
+	maxReportCommentLength = 1000
+	maxCaseNoteLength      = 2000
+)
+
+var errCaseChanged = fmt.Errorf("%w: the case was changed meanwhile, reload it", ErrConflict)
+
+type ReportService struct {
+	config               *config.Config
+	reportRepository     ports.ReportRepository
+	eventRepository      ports.EventRepository
+	userRepository       ports.UserRepository
+	moderationRepository ports.ModerationRepository
+	outboxRepository     ports.OutboxRepository
+	transactor           ports.Transactor
+	messageBus           ports.MessageBus
+}
+
+func NewReportService(
+	config *config.Config,
+	reportRepository ports.ReportRepository,
+	eventRepository ports.EventRepository,
+	userRepository ports.UserRepository,
+	moderationRepository ports.ModerationRepository,
+	outboxRepository ports.OutboxRepository,
+	transactor ports.Transactor,
+	messageBus ports.MessageBus,
+) *ReportService {
+	return &ReportService{
+		config:               config,
+		reportRepository:     reportRepository,
+		eventRepository:      eventRepository,
+		userRepository:       userRepository,
+		moderationRepository: moderationRepository,
+		outboxRepository:     outboxRepository,
+		transactor:           transactor,
+		messageBus:           messageBus,
+	}
+}
+
+// CreateReport files a report about an event or a user. Once enough distinct
+// users have reported the same content it is hidden until a moderator
+// handles the case.
+func (s *ReportService) CreateReport(ctx context.Context, actor *models.Principal, request *models.ReportRequest) (*models.Report, error) {
+	if request == nil {
+		return nil, errors.New("report is required")
+	}
+
+	if !request.TargetType.IsValid() {
+		return nil, fmt.Errorf("%w: invalid target type", ErrInvalidArgument)
+	}
+
+	if request.TargetID == "" {
+		return nil, fmt.Errorf("%w: target ID is required", ErrInvalidArgument)
+	}
+
+	if !request.Reason.IsValid() {
+		return nil, fmt.Errorf("%w: invalid reason", ErrInvalidArgument)
+	}
+
+	comment := strings.TrimSpace(request.Comment)
+	if len([]rune(comment)) > maxReportCommentLength {
+		return nil, fmt.Errorf("%w: comment may be at most %d characters", ErrInvalidArgument, maxReportCommentLength)
+	}
+
+	if err := s.validateTarget(ctx, actor, request.TargetType, request.TargetID); err != nil {
+		return nil, err
+	}
+
+	report := &models.Report{
+		ID:         uuid.New().String(),
+		ReporterID: actor.ID,
+		TargetType: request.TargetType,
+		TargetID:   request.TargetID,
+		Reason:     request.Reason,
+		Comment:    comment,
+		CreatedAt:  time.Now(),
+	}
+
+	abuseCase, added, err := s.reportRepository.AddReport(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if !added {
+		return nil, fmt.Errorf("%w: you have already reported this", ErrConflict)
+	}
+
+	threshold := s.config.Reports.HideThreshold
+	if threshold > 0 && !abuseCase.Hidden && abuseCase.ReporterCount >= threshold {
+		if err := s.hideReportedContent(ctx, abuseCase); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// validateTarget checks that the reported content exists, is visible and is
+// not the reporter's own.
+func (s *ReportService) validateTarget(ctx context.Context, actor *models.Principal, targetType constants.ReportTargetType, targetID string) error {
+	switch targetType {
+	case constants.ReportTargetEvent:
+		event, err := s.eventRepository.GetEvent(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		// Hidden events keep gathering reports in their open case.
+		if event == nil || (event.ModerationStatus != constants.EventModerationStatusApproved &&
+			event.ModerationStatus != constants.EventModerationStatusHidden) {
+			return fmt.Errorf("%w: event not found", ErrInvalidArgument)
+		}
+
+		if event.CanBeManagedBy(actor.ID) {
+			return fmt.Errorf("%w: you cannot report your own event", ErrInvalidArgument)
+		}
+	case constants.ReportTargetUser:
+		if targetID == actor.ID {
+			return fmt.Errorf("%w: you cannot report yourself", ErrInvalidArgument)
+		}
+
+		if _, err := s.userRepository.GetUserByID(targetID); err != nil {
+			return fmt.Errorf("%w: user not found", ErrInvalidArgument)
+		}
+	default:
+		return fmt.Errorf("%w: %s reports are not supported yet", ErrInvalidArgument, targetType)
+	}
+
+	return nil
+}
+
+// hideReportedContent hides the content once enough users reported it. A
+// moderator handling the case meanwhile takes precedence.
+func (s *ReportService) hideReportedContent(ctx context.Context, abuseCase *models.AbuseCase) error {
+	note := fmt.Sprintf("hidden after reports from %d users", abuseCase.ReporterCount)
+	from := abuseCase.Status
+
+	var moderation *models.EventModeration
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		hidden, eventModeration, err := s.setContentHidden(ctx, abuseCase, constants.ModerationActorSystem, true, note)
+		if err != nil || !hidden {
+			return err
+		}
+
+		abuseCase.Hidden = true
+		abuseCase.UpdatedAt = time.Now()
+
+		updated, err := s.reportRepository.UpdateCase(ctx, abuseCase, from, []models.AbuseCaseAction{
+			newCaseAction(abuseCase, constants.ModerationActorSystem, constants.AbuseCaseActionContentHidden, note),
+		})
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return errCaseChanged
+		}
+
+		moderation = eventModeration
+		return nil
+	})
+	if errors.Is(err, errCaseChanged) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.publishModeration(ctx, moderation)
+	return nil
+}
+
+// setContentHidden hides the content a case is about or shows it again and
+// reports whether it changed, with the moderation to announce for events.
+// Only approved events are hidden and only hidden ones are shown again;
+// events rejected in the meantime stay rejected.
+func (s *ReportService) setContentHidden(ctx context.Context, abuseCase *models.AbuseCase, actorID string, hidden bool, note string) (bool, *models.EventModeration, error) {
+	switch abuseCase.TargetType {
+	case constants.ReportTargetEvent:
+		from, to, action := constants.EventModerationStatusHidden, constants.EventModerationStatusApproved, constants.ModerationActionRestored
+		if hidden {
+			from, to, action = constants.EventModerationStatusApproved, constants.EventModerationStatusHidden, constants.ModerationActionHidden
+		}
+
+		moderation, err := s.moderateEvent(ctx, abuseCase.TargetID, actorID, action, note, from, to)
+		return moderation != nil, moderation, err
+	case constants.ReportTargetUser:
+		if err := s.userRepository.SetUserHidden(ctx, abuseCase.TargetID, hidden); err != nil {
+			return false, nil, err
+		}
+		return true, nil, nil
+	}
+
+	return false, nil, nil
+}
+
+// moderateEvent moves a reported event between moderation statuses, records
+// it in the event's moderation history and queues the email to its
+// organizer. It returns the moderation to announce once the caller's
+// transaction commits, or nil when the event was not in the from status.
+func (s *ReportService) moderateEvent(ctx context.Context, eventID, actorID string, action constants.ModerationAction, reason string, from, to constants.EventModerationStatus) (*models.EventModeration, error) {
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil || event.ModerationStatus != from {
+		return nil, nil
+	}
+
+	organizer, err := s.userRepository.GetUserByID(event.Organizer)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := s.moderationRepository.ApplyDecision(ctx, &models.ModerationAction{
+		ID:        uuid.New().String(),
+		EventID:   eventID,
+		ActorID:   actorID,
+		Action:    action,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}, from, to)
+	if err != nil || !applied {
+		return nil, err
+	}
+
+	if err := enqueueModerationEmail(ctx, s.outboxRepository, s.config.AppURL, organizer, event, action, reason); err != nil {
+		return nil, err
+	}
+
+	return &models.EventModeration{
+		EventID:     event.ID,
+		Title:       event.Title,
+		Organizer:   event.Organizer,
+		ModeratorID: actorID,
+		Status:      to,
+		Reason:      reason,
+	}, nil
+}
+
+func (s *ReportService) publishModeration(ctx context.Context, moderation *models.EventModeration) {
+	if moderation != nil {
+		s.messageBus.Publish(ctx, constants.TopicEventModerated, moderation)
+	}
+}
+
+// ListCases returns one page of cases, oldest first. Without a status filter
+// only open and investigated cases are listed.
+func (s *ReportService) ListCases(ctx context.Context, actor *models.Principal, filter *models.AbuseCaseFilter) (*models.AbuseCasePage, error) {
+	if !actor.Can(constants.PermissionHandleReports) {
+		return nil, ErrForbidden
+	}
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status", ErrInvalidArgument)
+	}
+
+	if filter.TargetType != "" && !filter.TargetType.IsValid() {
+		return nil, fmt.Errorf("%w: invalid target type", ErrInvalidArgument)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultCasePageSize
+	}
+
+	if filter.Limit > maxCasePageSize {
+		filter.Limit = maxCasePageSize
+	}
+
+	var cursor *models.EventCursor
+	if filter.Cursor != "" {
+		var err error
+		cursor, err = decodeEventCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// One extra case tells whether there is a next page.
+	cases, err := s.reportRepository.ListCases(ctx, filter, cursor, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.AbuseCasePage{Cases: cases}
+	if len(cases) > filter.Limit {
+		page.Cases = cases[:filter.Limit]
+		last := page.Cases[filter.Limit-1]
+		page.NextCursor, err = encodeEventCursor(&models.EventCursor{Value: last.CreatedAt, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// GetCase returns the case with its reports and audit trail.
+func (s *ReportService) GetCase(ctx context.Context, actor *models.Principal, caseID string) (*models.AbuseCaseDetails, error) {
+	if !actor.Can(constants.PermissionHandleReports) {
+		return nil, ErrForbidden
+	}
+
+	abuseCase, err := s.reportRepository.GetCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if abuseCase == nil {
+		return nil, nil
+	}
+
+	reports, err := s.reportRepository.GetReports(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := s.reportRepository.GetActions(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AbuseCaseDetails{
+		AbuseCase: *abuseCase,
+		Reports:   reports,
+		Actions:   actions,
+	}, nil
+}
+
+// UpdateCase moves a case through its workflow. Taking a case into
+// investigation assigns it to the actor; resolving it applies the resolution
+// to the reported content and dismissing it shows hidden content again.
+func (s *ReportService) UpdateCase(ctx context.Context, actor *models.Principal, caseID string, request *models.AbuseCaseUpdateRequest) (*models.AbuseCase, error) {
+	if !actor.Can(constants.PermissionHandleReports) {
+		return nil, ErrForbidden
+	}
+
+	if request == nil || !request.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status", ErrInvalidArgument)
+	}
+
+	note := strings.TrimSpace(request.Note)
+	if len([]rune(note)) > maxCaseNoteLength {
+		return nil, fmt.Errorf("%w: note may be at most %d characters", ErrInvalidArgument, maxCaseNoteLength)
+	}
+
+	abuseCase, err := s.reportRepository.GetCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if abuseCase == nil {
+		return nil, errors.New("case not found")
+	}
+
+	if abuseCase.Status.IsClosed() {
+		return nil, fmt.Errorf("%w: case is already closed", ErrConflict)
+	}
+
+	if request.Status == constants.AbuseCaseStatusOpen && abuseCase.Status == constants.AbuseCaseStatusOpen {
+		return nil, fmt.Errorf("%w: case is already open", ErrConflict)
+	}
+
+	if err := validateResolution(abuseCase, request.Status, request.Resolution, note); err != nil {
+		return nil, err
+	}
+
+	from := abuseCase.Status
+	now := time.Now()
+	abuseCase.Status = request.Status
+	abuseCase.UpdatedAt = now
+
+	switch request.Status {
+	case constants.AbuseCaseStatusOpen:
+		abuseCase.AssigneeID = nil
+	case constants.AbuseCaseStatusInvestigating:
+		abuseCase.AssigneeID = &actor.ID
+	default:
+		abuseCase.ClosedAt = &now
+	}
+
+	// The content and the case change together: if someone else moved the
+	// case meanwhile, the resolution is undone as well.
+	var moderation *models.EventModeration
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		actions := []models.AbuseCaseAction{
+			newCaseAction(abuseCase, actor.ID, constants.AbuseCaseActionStatusChanged, note),
+		}
+
+		if request.Status.IsClosed() {
+			action, eventModeration, err := s.applyResolution(ctx, actor, abuseCase, request.Resolution, note)
+			if err != nil {
+				return err
+			}
+			if action != "" {
+				actions = append(actions, newCaseAction(abuseCase, actor.ID, action, note))
+			}
+			moderation = eventModeration
+		}
+
+		updated, err := s.reportRepository.UpdateCase(ctx, abuseCase, from, actions)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return errCaseChanged
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishModeration(ctx, moderation)
+	return abuseCase, nil
+}
+
+func validateResolution(abuseCase *models.AbuseCase, status constants.AbuseCaseStatus, resolution constants.AbuseCaseResolution, note string) error {
+	if status != constants.AbuseCaseStatusResolved {
+		if resolution != "" {
+			return fmt.Errorf("%w: only resolved cases have a resolution", ErrInvalidArgument)
+		}
+		return nil
+	}
+
+	switch resolution {
+	case constants.AbuseCaseResolutionHide, constants.AbuseCaseResolutionRestore:
+		return nil
+	case constants.AbuseCaseResolutionReject:
+		if abuseCase.TargetType != constants.ReportTargetEvent {
+			return fmt.Errorf("%w: only events can be rejected", ErrInvalidArgument)
+		}
+		if note == "" {
+			return fmt.Errorf("%w: a note explaining the rejection is required", ErrInvalidArgument)
+		}
+		return nil
+	case "":
+		return fmt.Errorf("%w: resolution is required", ErrInvalidArgument)
+	default:
+		return fmt.Errorf("%w: invalid resolution", ErrInvalidArgument)
+	}
+}
+
+// applyResolution applies what the moderator decided about the reported
+// content when closing the case and returns the audit action for it, if the
+// content changed, and the event moderation to announce.
+func (s *ReportService) applyResolution(ctx context.Context, actor *models.Principal, abuseCase *models.AbuseCase, resolution constants.AbuseCaseResolution, note string) (constants.AbuseCaseAction, *models.EventModeration, error) {
+	if resolution != "" {
+		abuseCase.Resolution = &resolution
+	}
+
+	switch resolution {
+	case constants.AbuseCaseResolutionHide:
+		if abuseCase.Hidden {
+			return "", nil, nil
+		}
+
+		hidden, moderation, err := s.setContentHidden(ctx, abuseCase, actor.ID, true, note)
+		if err != nil || !hidden {
+			return "", nil, err
+		}
+
+		abuseCase.Hidden = true
+		return constants.AbuseCaseActionContentHidden, moderation, nil
+	case constants.AbuseCaseResolutionReject:
+		event, err := s.eventRepository.GetEvent(ctx, abuseCase.TargetID)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if event == nil || (event.ModerationStatus != constants.EventModerationStatusApproved &&
+			event.ModerationStatus != constants.EventModerationStatusHidden) {
+			return "", nil, nil
+		}
+
+		moderation, err := s.moderateEvent(ctx, event.ID, actor.ID, constants.ModerationActionRejected, note,
+			event.ModerationStatus, constants.EventModerationStatusRejected)
+		if err != nil || moderation == nil {
+			return "", nil, err
+		}
+
+		abuseCase.Hidden = false
+		return constants.AbuseCaseActionEventRejected, moderation, nil
+	default:
+		// Restoring and dismissing both put back what reports took down.
+		if !abuseCase.Hidden {
+			return "", nil, nil
+		}
+
+		_, moderation, err := s.setContentHidden(ctx, abuseCase, actor.ID, false, note)
+		if err != nil {
+			return "", nil, err
+		}
+
+		abuseCase.Hidden = false
+		return constants.AbuseCaseActionContentRestored, moderation, nil
+	}
+}
+
+func newCaseAction(abuseCase *models.AbuseCase, actorID string, action constants.AbuseCaseAction, note string) models.AbuseCaseAction {
+	return models.AbuseCaseAction{
+		ID:        uuid.New().String(),
+		CaseID:    abuseCase.ID,
+		ActorID:   actorID,
+		Action:    action,
+		Status:    abuseCase.Status,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+}
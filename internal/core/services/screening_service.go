@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+// ScreeningService runs automated content screening over events before they
+// reach moderators and decides whether a human needs to look at them.
+type ScreeningService struct {
+	config               *config.Config
+	screener             ports.ContentScreener
+	moderationRepository ports.ModerationRepository
+}
+
+func NewScreeningService(
+	config *config.Config,
+	screener ports.ContentScreener,
+	moderationRepository ports.ModerationRepository,
+) *ScreeningService {
+	return &ScreeningService{
+		config:               config,
+		screener:             screener,
+		moderationRepository: moderationRepository,
+	}
+}
+
+// Screen scores the event submitted by the actor. Events scoring at least the
+// reject score are rejected, clean events whose owner is a trusted organizer
+// are approved and everything else is left for review.
+func (s *ScreeningService) Screen(ctx context.Context, actor *models.Principal, event *models.Event) (*models.ScreeningResult, error) {
+	cfg := s.config.Screening
+	if !cfg.Enabled {
+		return &models.ScreeningResult{Verdict: constants.ScreeningVerdictReview}, nil
+	}
+
+	findings, err := s.screener.Screen(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ScreeningResult{
+		Verdict:  constants.ScreeningVerdictReview,
+		Score:    findings.Score(),
+		Findings: findings,
+	}
+
+	if result.Score >= cfg.RejectScore {
+		result.Verdict = constants.ScreeningVerdictReject
+		return result, nil
+	}
+
+	if result.Score < cfg.ReviewScore {
+		trusted, err := s.isTrusted(ctx, actor, event)
+		if err != nil {
+			return nil, err
+		}
+		if trusted {
+			result.Verdict = constants.ScreeningVerdictApprove
+		}
+	}
+
+	return result, nil
+}
+
+// isTrusted reports whether the event may skip manual moderation: when a
+// moderator submits it, or when its owner has had enough events approved and
+// none rejected lately. Co-organizers' edits are judged by the owner's
+// record, so that a trusted co-organizer cannot get an untrusted owner's
+// event approved.
+func (s *ScreeningService) isTrusted(ctx context.Context, actor *models.Principal, event *models.Event) (bool, error) {
+	if actor.Can(constants.PermissionModerateEvents) {
+		return true, nil
+	}
+
+	cfg := s.config.Screening
+	if cfg.TrustedMinApproved <= 0 {
+		return false, nil
+	}
+
+	stats, err := s.moderationRepository.GetOrganizerStats(ctx, event.Organizer, time.Now().Add(-cfg.TrustWindow))
+	if err != nil {
+		return false, err
+	}
+
+	return stats.Approved >= int64(cfg.TrustedMinApproved) && stats.RecentRejections == 0, nil
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+type fixedScore float64
+
+func (f fixedScore) Name() string {
+	return "fixed"
+}
+
+func (f fixedScore) Screen(ctx context.Context, event *models.Event) (models.ScreeningFindings, error) {
+	if f == 0 {
+		return nil, nil
+	}
+	return models.ScreeningFindings{{Rule: "fixed", Detail: "fixed score", Score: float64(f)}}, nil
+}
+
+// organizerRecords answers GetOrganizerStats from a map by organizer ID.
+type organizerRecords struct {
+	ports.ModerationRepository
+	stats map[string]models.OrganizerModerationStats
+}
+
+func (r *organizerRecords) GetOrganizerStats(ctx context.Context, organizerID string, since time.Time) (*models.OrganizerModerationStats, error) {
+	stats := r.stats[organizerID]
+	return &stats, nil
+}
+
+func TestScreeningVerdict(t *testing.T) {
+	cfg := &config.Config{Screening: config.ScreeningConfig{
+		Enabled:            true,
+		RejectScore:        1,
+		ReviewScore:        0.3,
+		TrustedMinApproved: 3,
+		TrustWindow:        time.Hour,
+	}}
+	records := &organizerRecords{stats: map[string]models.OrganizerModerationStats{
+		"trusted":  {Approved: 3},
+		"rejected": {Approved: 10, RecentRejections: 1},
+		"new":      {Approved: 2},
+	}}
+
+	organizer := func(id string) *models.Principal {
+		return &models.Principal{ID: id, Role: constants.UserRoleOrganizer}
+	}
+	moderator := &models.Principal{ID: "moderator", Role: constants.UserRoleModerator}
+
+	for _, test := range []struct {
+		name  string
+		actor *models.Principal
+		owner string
+		score fixedScore
+		want  constants.ScreeningVerdict
+	}{
+		{"trusted owner", organizer("trusted"), "trusted", 0, constants.ScreeningVerdictApprove},
+		{"below the review score", organizer("trusted"), "trusted", 0.2, constants.ScreeningVerdictApprove},
+		{"at the review score", organizer("trusted"), "trusted", 0.3, constants.ScreeningVerdictReview},
+		{"at the reject score", organizer("trusted"), "trusted", 1, constants.ScreeningVerdictReject},
+		{"too few approvals", organizer("new"), "new", 0, constants.ScreeningVerdictReview},
+		{"recent rejection", organizer("rejected"), "rejected", 0, constants.ScreeningVerdictReview},
+		{"trusted co-organizer of a new owner", organizer("trusted"), "new", 0, constants.ScreeningVerdictReview},
+		{"new co-organizer of a trusted owner", organizer("new"), "trusted", 0, constants.ScreeningVerdictApprove},
+		{"moderator", moderator, "new", 0, constants.ScreeningVerdictApprove},
+		{"moderator past the reject score", moderator, "new", 1, constants.ScreeningVerdictReject},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			service := NewScreeningService(cfg, test.score, records)
+
+			result, err := service.Screen(context.Background(), test.actor, &models.Event{Organizer: test.owner})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Verdict != test.want {
+				t.Errorf("verdict = %s, want %s", result.Verdict, test.want)
+			}
+		})
+	}
+}
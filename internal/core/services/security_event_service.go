@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSecurityEventPageSize = 50
+	maxSecurityEventPageSize     = 200
+
+	// maxSecurityEventEmailLength is the size of security_events.email. A
+	// failed login logs whatever was typed in, which may be longer.
+	maxSecurityEventEmailLength = 255
+)
+
+type SecurityEventService struct {
+	securityEventRepository ports.SecurityEventRepository
+}
+
+func NewSecurityEventService(securityEventRepository ports.SecurityEventRepository) *SecurityEventService {
+	return &SecurityEventService{
+		securityEventRepository: securityEventRepository,
+	}
+}
+
+// Record adds an entry to the security event log.
+func (s *SecurityEventService) Record(ctx context.Context, eventType constants.SecurityEventType, userID *string, email string, client models.ClientInfo) error {
+	return s.securityEventRepository.RecordEvent(ctx, &models.SecurityEvent{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		UserID:    userID,
+		Email:     truncateRunes(email, maxSecurityEventEmailLength),
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *SecurityEventService) ListEvents(ctx context.Context, actor *models.Principal, filter *models.SecurityEventFilter) (*models.SecurityEventPage, error) {
+	if !actor.Can(constants.PermissionViewSecurity) {
+		return nil, ErrForbidden
+	}
+
+	if filter.Type != "" && !filter.Type.IsValid() {
+		return nil, fmt.Errorf("%w: invalid security event type", ErrInvalidArgument)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSecurityEventPageSize
+	}
+
+	if filter.Limit > maxSecurityEventPageSize {
+		filter.Limit = maxSecurityEventPageSize
+	}
+
+	var cursor *models.EventCursor
+	if filter.Cursor != "" {
+		var err error
+		cursor, err = decodeEventCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// One extra event tells whether there is a next page.
+	events, err := s.securityEventRepository.ListEvents(ctx, filter, cursor, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.SecurityEventPage{Events: events}
+	if len(events) > filter.Limit {
+		page.Events = events[:filter.Limit]
+		last := page.Events[filter.Limit-1]
+		page.NextCursor, err = encodeEventCursor(&models.EventCursor{Value: last.CreatedAt, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// truncateRunes cuts s to at most n characters.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n])
+}
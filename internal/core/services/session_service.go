@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrInvalidStreamTicket = errors.New("invalid stream ticket")
+)
+
+type SessionService struct {
+	repo            ports.SessionRepository
+	userRepo        ports.UserRepository
+	tokenRepository ports.AuthTokenRepository
+	jwtService      *JWTService
+	config          *config.Config
+}
+
+func NewSessionService(
+	repo ports.SessionRepository,
+	userRepo ports.UserRepository,
+	tokenRepository ports.AuthTokenRepository,
+	jwtService *JWTService,
+	config *config.Config,
+) *SessionService {
+	return &SessionService{
+		repo:            repo,
+		userRepo:        userRepo,
+		tokenRepository: tokenRepository,
+		jwtService:      jwtService,
+		config:          config,
+	}
+}
+
+// CreateSession opens a new session for the user and returns its first pair
+// of access and refresh tokens.
+func (s *SessionService) CreateSession(user *models.User, client models.ClientInfo) (*models.AuthResponse, error) {
+	now := time.Now()
+	session := &models.Session{
+		ID:         uuid.New().String(),
+		UserID:     user.ID,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		ExpiresAt:  now.Add(s.config.JWT.RefreshTokenTTL),
+		LastUsedAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	refreshToken, err := newRefreshToken(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	session.RefreshTokenHash = hashToken(refreshToken)
+
+	if err := s.repo.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(user, session.ID, refreshToken)
+}
+
+// Refresh rotates the refresh token of a session. Presenting a token that has
+// already been rotated means it leaked, so the whole session is revoked.
+func (s *SessionService) Refresh(refreshToken string) (*models.AuthResponse, error) {
+	sessionID, _, ok := strings.Cut(refreshToken, ".")
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session, err := s.repo.GetSession(sessionID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if !session.IsActive(time.Now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	oldHash := hashToken(refreshToken)
+	if session.RefreshTokenHash != oldHash {
+		if err := s.repo.RevokeSession(session.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	user, err := s.userRepo.GetUserByID(session.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	newToken, err := newRefreshToken(session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.repo.RotateRefreshToken(session.ID, oldHash, hashToken(newToken), time.Now().Add(s.config.JWT.RefreshTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	if !rotated {
+		// Another request rotated the same token first.
+		if err := s.repo.RevokeSession(session.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	return s.issueTokens(user, session.ID, newToken)
+}
+
+func (s *SessionService) IsSessionActive(sessionID, userID string) bool {
+	session, err := s.repo.GetSession(sessionID)
+	if err != nil {
+		return false
+	}
+
+	return session.UserID == userID && session.IsActive(time.Now())
+}
+
+// IssueStreamTicket returns a short-lived, single-use ticket that opens an
+// event stream on behalf of the actor's session. Browsers cannot send the
+// Authorization header with an EventSource, and a ticket that leaks with the
+// stream URL is useless once redeemed.
+func (s *SessionService) IssueStreamTicket(ctx context.Context, actor *models.Principal) (*models.StreamTicketResponse, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	ticket := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := time.Now()
+	if err := s.tokenRepository.DeleteSpentTokens(ctx, actor.ID, constants.AuthTokenStreamTicket, now); err != nil {
+		return nil, err
+	}
+
+	ttl := s.config.Realtime.StreamTicketTTL
+	if err := s.tokenRepository.CreateToken(ctx, &models.AuthToken{
+		ID:              uuid.New().String(),
+		UserID:          actor.ID,
+		Purpose:         constants.AuthTokenStreamTicket,
+		TokenHash:       hashToken(ticket),
+		ExpiresAt:       now.Add(ttl),
+		CreatedAt:       now,
+		SessionID:       &actor.SessionID,
+		AccessExpiresAt: &actor.ExpiresAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &models.StreamTicketResponse{
+		Ticket:    ticket,
+		ExpiresIn: int(ttl.Seconds()),
+	}, nil
+}
+
+// RedeemStreamTicket uses up the ticket and returns the claims of the access
+// token it was issued with.
+func (s *SessionService) RedeemStreamTicket(ctx context.Context, ticket string) (*TokenClaims, error) {
+	token, err := s.tokenRepository.ConsumeToken(ctx, constants.AuthTokenStreamTicket, hashToken(ticket), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil || token.SessionID == nil || token.AccessExpiresAt == nil {
+		return nil, ErrInvalidStreamTicket
+	}
+
+	return &TokenClaims{
+		UserID:    token.UserID,
+		SessionID: *token.SessionID,
+		ExpiresAt: *token.AccessExpiresAt,
+	}, nil
+}
+
+func (s *SessionService) GetActiveSessions(userID, currentSessionID string) ([]*models.SessionResponse, error) {
+	sessions, err := s.repo.GetActiveSessionsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*models.SessionResponse, len(sessions))
+	for i := range sessions {
+		response[i] = sessions[i].ToResponse(currentSessionID)
+	}
+
+	return response, nil
+}
+
+func (s *SessionService) RevokeSession(userID, sessionID string) error {
+	session, err := s.repo.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID != userID {
+		return ErrForbidden
+	}
+
+	return s.repo.RevokeSession(sessionID)
+}
+
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userID string) error {
+	return s.repo.RevokeUserSessions(ctx, userID)
+}
+
+// RevokeOtherSessions signs the user out everywhere except the current
+// session.
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) error {
+	return s.repo.RevokeOtherSessions(ctx, userID, currentSessionID)
+}
+
+func (s *SessionService) issueTokens(user *models.User, sessionID, refreshToken string) (*models.AuthResponse, error) {
+	accessToken, err := s.jwtService.GenerateToken(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// newRefreshToken returns an opaque token prefixed with the session ID so the
+// session can be looked up without storing the token itself.
+func newRefreshToken(sessionID string) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return sessionID + "." + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
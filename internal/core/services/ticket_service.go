@@ -0,0 +1,412 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	defaultCurrency = "RUB"
+	qrCodeSize      = 512
+)
+
+var ErrInvalidTicketCode = errors.New("invalid ticket code")
+
+type TicketService struct {
+	ticketRepository ports.TicketRepository
+	eventRepository  ports.EventRepository
+	userRepository   ports.UserRepository
+	config           *config.Config
+}
+
+func NewTicketService(
+	ticketRepository ports.TicketRepository,
+	eventRepository ports.EventRepository,
+	userRepository ports.UserRepository,
+	config *config.Config,
+) (*TicketService, error) {
+	if err := config.Tickets.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &TicketService{
+		ticketRepository: ticketRepository,
+		eventRepository:  eventRepository,
+		userRepository:   userRepository,
+		config:           config,
+	}, nil
+}
+
+func (s *TicketService) CreateTicketType(ctx context.Context, actor *models.Principal, eventID string, req *models.TicketTypeRequest) (*models.TicketType, error) {
+	if _, err := s.getManagedEvent(ctx, actor, eventID); err != nil {
+		return nil, err
+	}
+
+	if err := validateTicketType(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ticketType := &models.TicketType{
+		ID:        uuid.New().String(),
+		EventID:   eventID,
+		CreatedAt: now,
+	}
+	applyTicketTypeRequest(ticketType, req, now)
+
+	if err := s.ticketRepository.CreateTicketType(ctx, ticketType); err != nil {
+		return nil, err
+	}
+
+	return ticketType, nil
+}
+
+func (s *TicketService) UpdateTicketType(ctx context.Context, actor *models.Principal, eventID, ticketTypeID string, req *models.TicketTypeRequest) (*models.TicketType, error) {
+	if _, err := s.getManagedEvent(ctx, actor, eventID); err != nil {
+		return nil, err
+	}
+
+	ticketType, err := s.ticketRepository.GetTicketType(ctx, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticketType == nil || ticketType.EventID != eventID {
+		return nil, errors.New("ticket type not found")
+	}
+
+	if err := validateTicketType(req); err != nil {
+		return nil, err
+	}
+
+	applyTicketTypeRequest(ticketType, req, time.Now())
+
+	if err := s.ticketRepository.UpdateTicketType(ctx, ticketType); err != nil {
+		return nil, err
+	}
+
+	return ticketType, nil
+}
+
+// GetTicketTypes returns the ticket types of the event, which must be one the
+// actor may see.
+func (s *TicketService) GetTicketTypes(ctx context.Context, actor *models.Principal, eventID string) ([]models.TicketTypeResponse, error) {
+	if eventID == "" {
+		return nil, errors.New("event ID is required")
+	}
+
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil || !canViewEvent(actor, event) {
+		return nil, fmt.Errorf("event %w", ErrNotFound)
+	}
+
+	return s.ticketRepository.GetTicketTypesByEvent(ctx, eventID)
+}
+
+// ObtainTicket issues a free ticket of the given type to the actor.
+func (s *TicketService) ObtainTicket(ctx context.Context, actor *models.Principal, eventID, ticketTypeID string) (*models.TicketResponse, error) {
+	ticketType, err := s.getTicketTypeOnSale(ctx, eventID, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticketType.Price > 0 {
+		return nil, errors.New("paid tickets must be purchased")
+	}
+
+	ticket, err := s.issueTicket(ctx, actor.ID, ticketType)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(ticket), nil
+}
+
+// GetTicketTypeForPurchase returns a paid ticket type that is currently on
+// sale for the event.
+func (s *TicketService) GetTicketTypeForPurchase(ctx context.Context, eventID, ticketTypeID string) (*models.TicketType, error) {
+	ticketType, err := s.getTicketTypeOnSale(ctx, eventID, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticketType.Price == 0 {
+		return nil, errors.New("ticket type is free")
+	}
+
+	return ticketType, nil
+}
+
+// IssuePaidTicket issues a ticket for a paid order. Sales window checks are
+// skipped because they were enforced when the order was created.
+func (s *TicketService) IssuePaidTicket(ctx context.Context, order *models.Order) (*models.Ticket, error) {
+	ticketType, err := s.ticketRepository.GetTicketType(ctx, order.TicketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticketType == nil {
+		return nil, errors.New("ticket type not found")
+	}
+
+	return s.issueTicket(ctx, order.UserID, ticketType)
+}
+
+func (s *TicketService) CancelTicket(ctx context.Context, ticketID string) error {
+	return s.ticketRepository.CancelTicket(ctx, ticketID)
+}
+
+func (s *TicketService) GetTicket(ctx context.Context, actor *models.Principal, ticketID string) (*models.TicketResponse, error) {
+	ticket, err := s.ticketRepository.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket == nil {
+		return nil, errors.New("ticket not found")
+	}
+
+	if ticket.UserID != actor.ID {
+		return nil, ErrForbidden
+	}
+
+	return s.toResponse(ticket), nil
+}
+
+func (s *TicketService) GetMyTickets(ctx context.Context, actor *models.Principal) ([]*models.TicketResponse, error) {
+	tickets, err := s.ticketRepository.GetTicketsByUser(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*models.TicketResponse, len(tickets))
+	for i := range tickets {
+		response[i] = s.toResponse(&tickets[i])
+	}
+
+	return response, nil
+}
+
+// GetTicketQR renders the ticket code as a PNG QR image.
+func (s *TicketService) GetTicketQR(ctx context.Context, actor *models.Principal, ticketID string) ([]byte, error) {
+	ticket, err := s.GetTicket(ctx, actor, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	return qrcode.Encode(ticket.Code, qrcode.Medium, qrCodeSize)
+}
+
+// CheckIn validates a scanned ticket code for the event and records the
+// attendee's arrival. A ticket can only be checked in once.
+func (s *TicketService) CheckIn(ctx context.Context, actor *models.Principal, eventID, code string) (*models.CheckInResponse, error) {
+	if _, err := s.getManagedEvent(ctx, actor, eventID); err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.verifyCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.EventID != eventID {
+		return nil, errors.New("ticket is for another event")
+	}
+
+	if ticket.Status != constants.TicketStatusValid {
+		return nil, errors.New("ticket is cancelled")
+	}
+
+	now := time.Now()
+	checkedIn, err := s.ticketRepository.CheckIn(ctx, ticket.ID, actor.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !checkedIn {
+		return nil, fmt.Errorf("%w: ticket already checked in", ErrConflict)
+	}
+
+	user, err := s.userRepository.GetUserByID(ticket.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CheckInResponse{
+		TicketID:     ticket.ID,
+		TicketTypeID: ticket.TicketTypeID,
+		UserID:       ticket.UserID,
+		UserName:     user.Name,
+		CheckedInAt:  now,
+	}, nil
+}
+
+func (s *TicketService) GetAttendanceStats(ctx context.Context, actor *models.Principal, eventID string) (*models.AttendanceStats, error) {
+	if _, err := s.getManagedEvent(ctx, actor, eventID); err != nil {
+		return nil, err
+	}
+
+	return s.ticketRepository.GetAttendanceStats(ctx, eventID)
+}
+
+func (s *TicketService) getManagedEvent(ctx context.Context, actor *models.Principal, eventID string) (*models.Event, error) {
+	if eventID == "" {
+		return nil, errors.New("event ID is required")
+	}
+
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, errors.New("event not found")
+	}
+
+	if !canEditEvent(actor, event) {
+		return nil, ErrForbidden
+	}
+
+	return event, nil
+}
+
+func (s *TicketService) getTicketTypeOnSale(ctx context.Context, eventID, ticketTypeID string) (*models.TicketType, error) {
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, errors.New("event not found")
+	}
+
+	if event.ModerationStatus != constants.EventModerationStatusApproved {
+		return nil, errors.New("event is not open for ticket sales")
+	}
+
+	ticketType, err := s.ticketRepository.GetTicketType(ctx, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticketType == nil || ticketType.EventID != eventID {
+		return nil, errors.New("ticket type not found")
+	}
+
+	if !ticketType.IsOnSale(time.Now()) {
+		return nil, errors.New("ticket sales are closed")
+	}
+
+	return ticketType, nil
+}
+
+func (s *TicketService) issueTicket(ctx context.Context, userID string, ticketType *models.TicketType) (*models.Ticket, error) {
+	now := time.Now()
+	ticket := &models.Ticket{
+		ID:           uuid.New().String(),
+		TicketTypeID: ticketType.ID,
+		EventID:      ticketType.EventID,
+		UserID:       userID,
+		Status:       constants.TicketStatusValid,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := s.ticketRepository.IssueTicket(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+func (s *TicketService) toResponse(ticket *models.Ticket) *models.TicketResponse {
+	return &models.TicketResponse{
+		Ticket: *ticket,
+		Code:   s.sign(ticket),
+	}
+}
+
+// sign produces the code printed on a ticket: its ID followed by an HMAC over
+// the ticket, event and holder, so codes cannot be forged or moved between
+// events.
+func (s *TicketService) sign(ticket *models.Ticket) string {
+	mac := hmac.New(sha256.New, []byte(s.config.Tickets.SigningSecret))
+	mac.Write([]byte(ticket.ID + ":" + ticket.EventID + ":" + ticket.UserID))
+
+	return ticket.ID + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *TicketService) verifyCode(ctx context.Context, code string) (*models.Ticket, error) {
+	ticketID, _, ok := strings.Cut(code, ".")
+	if !ok {
+		return nil, ErrInvalidTicketCode
+	}
+
+	ticket, err := s.ticketRepository.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket == nil || !hmac.Equal([]byte(s.sign(ticket)), []byte(code)) {
+		return nil, ErrInvalidTicketCode
+	}
+
+	return ticket, nil
+}
+
+func validateTicketType(req *models.TicketTypeRequest) error {
+	if req == nil {
+		return errors.New("ticket type is required")
+	}
+
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if req.Quota <= 0 {
+		return errors.New("quota must be positive")
+	}
+
+	if req.Price < 0 {
+		return errors.New("price cannot be negative")
+	}
+
+	if req.SalesStart != nil && req.SalesEnd != nil && !req.SalesEnd.After(*req.SalesStart) {
+		return errors.New("sales end must be after sales start")
+	}
+
+	return nil
+}
+
+func applyTicketTypeRequest(ticketType *models.TicketType, req *models.TicketTypeRequest, now time.Time) {
+	currency := strings.ToUpper(req.Currency)
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	ticketType.Name = req.Name
+	ticketType.Quota = req.Quota
+	ticketType.Price = req.Price
+	ticketType.Currency = currency
+	ticketType.SalesStart = req.SalesStart
+	ticketType.SalesEnd = req.SalesEnd
+	ticketType.UpdatedAt = now
+}
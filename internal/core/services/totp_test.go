@@ -0,0 +1,63 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors.
+var rfc6238Secret = []byte("12345678901234567890")
+
+// TestTOTPCodeMatchesRFC6238 checks the codes against the SHA-1 vectors of
+// RFC 6238, appendix B. The RFC lists eight digits; six-digit codes are the
+// last six of them.
+func TestTOTPCodeMatchesRFC6238(t *testing.T) {
+	for _, test := range []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	} {
+		step := totpStep(time.Unix(test.unix, 0))
+		if got := totpCode(rfc6238Secret, step); got != test.want {
+			t.Errorf("code at %d = %s, want %s", test.unix, got, test.want)
+		}
+	}
+}
+
+func TestMatchTOTP(t *testing.T) {
+	secret := totpEncoding.EncodeToString(rfc6238Secret)
+	now := time.Unix(1111111109, 0)
+	step := totpStep(now)
+
+	for _, test := range []struct {
+		name   string
+		secret string
+		code   string
+		want   bool
+		step   int64
+	}{
+		{"current step", secret, "081804", true, step},
+		{"spaces", secret, " 081 804 ", true, step},
+		{"lowercase secret", strings.ToLower(secret), "081804", true, step},
+		{"previous step", secret, totpCode(rfc6238Secret, step-1), true, step - 1},
+		{"next step", secret, totpCode(rfc6238Secret, step+1), true, step + 1},
+		{"two steps late", secret, totpCode(rfc6238Secret, step-2), false, 0},
+		{"eight digits", secret, "07081804", false, 0},
+		{"wrong code", secret, "123456", false, 0},
+		{"invalid secret", "not base32!", "081804", false, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := matchTOTP(test.secret, test.code, now)
+			if ok != test.want || got != test.step {
+				t.Errorf("matchTOTP = %d, %v, want %d, %v", got, ok, test.step, test.want)
+			}
+		})
+	}
+}
@@ -1,31 +1,32 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
 	"github.com/EventFlow-Project/backend/internal/core/ports"
 )
 
 type UserService struct {
-	repo       ports.UserRepository
-	config     *config.Config
-	jwtService *JWTService
+	repo   ports.UserRepository
+	config *config.Config
 }
 
-func NewUserService(repo ports.UserRepository, config *config.Config, jwtService *JWTService) *UserService {
+func NewUserService(repo ports.UserRepository, config *config.Config) *UserService {
 	return &UserService{
-		repo:       repo,
-		config:     config,
-		jwtService: jwtService,
+		repo:   repo,
+		config: config,
 	}
 }
 
-func (s *UserService) GetUserInfo(accessToken string) (*models.SafeUser, error) {
-	userID, err := s.jwtService.GetUserIDFromToken(accessToken)
-	if err != nil {
-		return nil, err
-	}
+func (s *UserService) GetUserByID(userID string) (*models.User, error) {
+	return s.repo.GetUserByID(userID)
+}
 
+func (s *UserService) GetUserInfo(userID string) (*models.SafeUser, error) {
 	user, err := s.repo.GetUserByID(userID)
 	if err != nil {
 		return nil, err
@@ -34,10 +35,9 @@ func (s *UserService) GetUserInfo(accessToken string) (*models.SafeUser, error)
 	return user.ToSafeUser(), nil
 }
 
-func (s *UserService) EditUserInfo(accessToken string, info *models.EditUserInfo) (*models.SafeUser, error) {
-	userID, err := s.jwtService.GetUserIDFromToken(accessToken)
-	if err != nil {
-		return nil, err
+func (s *UserService) EditUserInfo(userID string, info *models.EditUserInfo) (*models.SafeUser, error) {
+	if info.Locale != "" && !info.Locale.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported locale", ErrInvalidArgument)
 	}
 
 	user, err := s.repo.EditUserInfo(userID, info)
@@ -61,3 +61,28 @@ func (s *UserService) SearchUsersByName(name string) ([]*models.SearchUserRespon
 
 	return searchResults, nil
 }
+
+func (s *UserService) UpdateUserRole(actor *models.Principal, userID string, role constants.UserRole) (*models.SafeUser, error) {
+	if !actor.Can(constants.PermissionManageRoles) {
+		return nil, ErrForbidden
+	}
+
+	if !role.IsValid() {
+		return nil, errors.New("invalid role")
+	}
+
+	if actor.ID == userID {
+		return nil, errors.New("cannot change own role")
+	}
+
+	user, err := s.repo.UpdateUserRole(userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ToSafeUser(), nil
+}
+
+func (s *UserService) RevokeUserRole(actor *models.Principal, userID string) (*models.SafeUser, error) {
+	return s.UpdateUserRole(actor, userID, constants.UserRoleParticipant)
+}
@@ -1,36 +1,61 @@
 package handlers
 
 import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
 	"github.com/EventFlow-Project/backend/internal/config"
 	"github.com/EventFlow-Project/backend/internal/core/models"
 	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
 
 	"github.com/gofiber/fiber/v3"
 )
 
 type AuthHandler struct {
-	config      *config.Config
-	authService *services.AuthService
-	jwtService  *services.JWTService
+	config         *config.Config
+	authService    *services.AuthService
+	sessionService *services.SessionService
 }
 
 func NewAuthHandler(
 	config *config.Config,
 	authService *services.AuthService,
-	jwtService *services.JWTService,
+	sessionService *services.SessionService,
 ) *AuthHandler {
 	return &AuthHandler{
-		config:      config,
-		authService: authService,
-		jwtService:  jwtService,
+		config:         config,
+		authService:    authService,
+		sessionService: sessionService,
 	}
 }
 
-func (h *AuthHandler) RegisterRoutes(router fiber.Router) {
+func (h *AuthHandler) RegisterPublicRoutes(router fiber.Router) {
 	auth := router.Group("/auth")
 
 	auth.Post("/register", h.register)
 	auth.Post("/login", h.login)
+	auth.Post("/login/mfa", h.loginMFA)
+	auth.Post("/refresh", h.refresh)
+	auth.Post("/verify-email", h.verifyEmail)
+	auth.Post("/forgot-password", h.forgotPassword)
+	auth.Post("/reset-password", h.resetPassword)
+	auth.Post("/change-email/confirm", h.confirmEmailChange)
+}
+
+func (h *AuthHandler) RegisterRoutes(router fiber.Router) {
+	auth := router.Group("/auth")
+
+	auth.Post("/logout", h.logout)
+	auth.Post("/logout-all", h.logoutAll)
+	auth.Get("/sessions", h.getSessions)
+	auth.Delete("/sessions/:id", h.revokeSession)
+	auth.Post("/verify-email/resend", h.resendVerification)
+	auth.Post("/change-password", h.changePassword)
+	auth.Post("/change-email", h.changeEmail)
 }
 
 func (h *AuthHandler) register(c fiber.Ctx) error {
@@ -39,19 +64,17 @@ func (h *AuthHandler) register(c fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	user, err := h.authService.Register(req)
+	user, err := h.authService.Register(c.Context(), req)
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	token, err := h.jwtService.GenerateToken(user)
+	response, err := h.sessionService.CreateSession(user, clientInfo(c))
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return c.JSON(models.AuthResponse{
-		AccessToken: token,
-	})
+	return c.JSON(response)
 }
 
 func (h *AuthHandler) login(c fiber.Ctx) error {
@@ -60,10 +83,218 @@ func (h *AuthHandler) login(c fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	token, err := h.authService.Login(req.Email, req.Password)
+	response, err := h.authService.Login(c.Context(), req.Email, req.Password, clientInfo(c))
+	if err != nil {
+		return loginError(c, err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *AuthHandler) loginMFA(c fiber.Ctx) error {
+	var req models.MFALoginRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.authService.CompleteMFALogin(c.Context(), req, clientInfo(c))
+	if err != nil {
+		return loginError(c, err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *AuthHandler) refresh(c fiber.Ctx) error {
+	var req models.RefreshTokenRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.sessionService.Refresh(req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrRefreshTokenReused) {
+			return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(response)
+}
+
+func (h *AuthHandler) verifyEmail(c fiber.Ctx) error {
+	var req models.VerifyEmailRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authService.VerifyEmail(c.Context(), req.Token); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *AuthHandler) resendVerification(c fiber.Ctx) error {
+	if err := h.authService.ResendVerification(c.Context(), middleware.GetPrincipal(c)); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *AuthHandler) forgotPassword(c fiber.Ctx) error {
+	var req models.ForgotPasswordRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authService.ForgotPassword(c.Context(), req.Email); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *AuthHandler) resetPassword(c fiber.Ctx) error {
+	var req models.ResetPasswordRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authService.ResetPassword(c.Context(), req.Token, req.Password); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *AuthHandler) changePassword(c fiber.Ctx) error {
+	var req models.ChangePasswordRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authService.ChangePassword(c.Context(), middleware.GetPrincipal(c), req.CurrentPassword, req.NewPassword, clientInfo(c)); err != nil {
+		return throttledError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *AuthHandler) changeEmail(c fiber.Ctx) error {
+	var req models.ChangeEmailRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authService.ChangeEmail(c.Context(), middleware.GetPrincipal(c), req.Password, req.NewEmail, clientInfo(c)); err != nil {
+		return throttledError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+func (h *AuthHandler) confirmEmailChange(c fiber.Ctx) error {
+	var req models.ConfirmEmailChangeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authService.ConfirmEmailChange(c.Context(), req.Token); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *AuthHandler) logout(c fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	if err := h.sessionService.RevokeSession(principal.ID, principal.SessionID); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *AuthHandler) logoutAll(c fiber.Ctx) error {
+	if err := h.sessionService.RevokeAllSessions(c.Context(), middleware.GetPrincipal(c).ID); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *AuthHandler) getSessions(c fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	sessions, err := h.sessionService.GetActiveSessions(principal.ID, principal.SessionID)
 	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(sessions)
+}
+
+func (h *AuthHandler) revokeSession(c fiber.Ctx) error {
+	sessionID := c.Params("id")
+	if sessionID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "session ID is required")
+	}
+
+	if err := h.sessionService.RevokeSession(middleware.GetPrincipal(c).ID, sessionID); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// loginError maps the errors of both login steps: wrong credentials are
+// 401, and throttled attempts say when to retry.
+func loginError(c fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrInvalidCredentials) || errors.Is(err, services.ErrInvalidMFACode) {
 		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
 	}
 
-	return c.JSON(models.AuthResponse{AccessToken: token})
+	return throttledError(c, err)
+}
+
+// throttledError maps the error of a request that checks a password or a
+// code, telling throttled clients when to retry.
+func throttledError(c fiber.Ctx, err error) error {
+	var retryAfter *services.RetryAfterError
+	if errors.As(err, &retryAfter) {
+		setRetryAfter(c, retryAfter.RetryAfter)
+	}
+	return serviceError(err)
+}
+
+// setRetryAfter tells the client how many seconds to wait, rounded up.
+func setRetryAfter(c fiber.Ctx, wait time.Duration) {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+}
+
+// maxUserAgentLength fits sessions.user_agent, which holds 512 characters,
+// so an overlong header cannot fail the login.
+const maxUserAgentLength = 512
+
+func clientInfo(c fiber.Ctx) models.ClientInfo {
+	return models.ClientInfo{
+		UserAgent: truncateUTF8(c.Get(fiber.HeaderUserAgent), maxUserAgentLength),
+		IP:        c.IP(),
+	}
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
 }
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type CalendarHandler struct {
+	config          *config.Config
+	authMiddleware  *middleware.AuthMiddleware
+	calendarService *services.CalendarService
+}
+
+func NewCalendarHandler(
+	config *config.Config,
+	authMiddleware *middleware.AuthMiddleware,
+	calendarService *services.CalendarService,
+) *CalendarHandler {
+	return &CalendarHandler{
+		config:          config,
+		authMiddleware:  authMiddleware,
+		calendarService: calendarService,
+	}
+}
+
+func (h *CalendarHandler) RegisterPublicRoutes(router fiber.Router) {
+	router.Get("/events/:id/ics", h.getEventCalendar, h.authMiddleware.OptionalAuth)
+	router.Get("/calendar/:token.ics", h.getFeed)
+}
+
+func (h *CalendarHandler) RegisterRoutes(router fiber.Router) {
+	calendar := router.Group("/calendar")
+	calendar.Post("/token", h.issueToken)
+	calendar.Delete("/token", h.revokeToken)
+}
+
+func (h *CalendarHandler) getEventCalendar(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	calendar, err := h.calendarService.GetEventCalendar(c.Context(), middleware.GetPrincipal(c), eventID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	if calendar == nil {
+		return fiber.NewError(fiber.StatusNotFound, "event not found")
+	}
+
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="event-`+eventID+`.ics"`)
+
+	return sendCalendar(c, calendar)
+}
+
+func (h *CalendarHandler) getFeed(c fiber.Ctx) error {
+	calendar, err := h.calendarService.GetFeedCalendar(c.Context(), c.Params("token"))
+	if errors.Is(err, services.ErrInvalidCalendarToken) {
+		return fiber.NewError(fiber.StatusNotFound, "calendar not found")
+	}
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return sendCalendar(c, calendar)
+}
+
+func (h *CalendarHandler) issueToken(c fiber.Ctx) error {
+	token, err := h.calendarService.IssueToken(c.Context(), middleware.GetPrincipal(c).ID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(models.CalendarTokenResponse{
+		Token: token,
+		URL:   strings.TrimSuffix(c.BaseURL(), "/") + "/calendar/" + token + ".ics",
+	})
+}
+
+func (h *CalendarHandler) revokeToken(c fiber.Ctx) error {
+	if err := h.calendarService.RevokeToken(c.Context(), middleware.GetPrincipal(c).ID); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// sendCalendar writes the calendar, or 304 Not Modified when the client
+// already holds this version.
+func sendCalendar(c fiber.Ctx, calendar *models.Calendar) error {
+	c.Set(fiber.HeaderETag, calendar.ETag)
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+
+	if etagMatches(c.Get(fiber.HeaderIfNoneMatch), calendar.ETag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, calendarContentType)
+
+	return c.Send(calendar.Body)
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -1,62 +1,84 @@
 package handlers
 
 import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/EventFlow-Project/backend/internal/config"
 	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
 	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
 	"github.com/gofiber/fiber/v3"
 )
 
 type EventHandler struct {
-	config       *config.Config
-	eventService *services.EventService
-	jwtService   *services.JWTService
-	minioService *services.MinioService
+	config         *config.Config
+	authMiddleware *middleware.AuthMiddleware
+	eventService   *services.EventService
+	minioService   *services.MinioService
 }
 
 func NewEventHandler(
 	config *config.Config,
+	authMiddleware *middleware.AuthMiddleware,
 	eventService *services.EventService,
-	jwtService *services.JWTService,
 	minioService *services.MinioService,
 ) *EventHandler {
 	return &EventHandler{
-		config:       config,
-		eventService: eventService,
-		jwtService:   jwtService,
-		minioService: minioService,
+		config:         config,
+		authMiddleware: authMiddleware,
+		eventService:   eventService,
+		minioService:   minioService,
 	}
 }
 
+func (h *EventHandler) RegisterPublicRoutes(router fiber.Router) {
+	events := router.Group("/events")
+
+	events.Get("/", h.listEvents, h.authMiddleware.OptionalAuth)
+	events.Get("/occurrences", h.listOccurrences)
+	events.Get("/search", h.searchEvents)
+	events.Get("/nearby", h.getEventsNearby)
+	events.Get("/map", h.getEventsInViewport)
+	events.Get("/:id", h.getEvent, h.authMiddleware.OptionalAuth)
+	events.Get("/:id/occurrences", h.getEventOccurrences, h.authMiddleware.OptionalAuth)
+	events.Get("/organizer/:organizerId", h.getEventsByOrganizer, h.authMiddleware.OptionalAuth)
+	events.Get("/status/:status", h.getEventsByStatus, h.authMiddleware.OptionalAuth)
+}
+
 func (h *EventHandler) RegisterRoutes(router fiber.Router) {
 	events := router.Group("/events")
 
 	events.Post("/", h.createEvent)
 	events.Put("/:id", h.updateEvent)
 	events.Delete("/:id", h.deleteEvent)
-	events.Get("/:id", h.getEvent)
-	events.Get("/organizer/:organizerId", h.getEventsByOrganizer)
-	events.Get("/status/:status", h.getEventsByStatus)
-	events.Get("/moderation/:status", h.getEventsByModerationStatus)
-	events.Put("/:id/approve", h.approveEvent)
-	events.Put("/:id/reject", h.rejectEvent)
+	events.Put("/:id/occurrences", h.updateOccurrence)
+	events.Delete("/:id/occurrences", h.deleteOccurrence)
+	events.Get("/moderation/:status", h.getEventsByModerationStatus, middleware.RequirePermission(constants.PermissionModerateEvents))
 	events.Post("/uploadImage", h.uploadImage)
 }
 
 func (h *EventHandler) createEvent(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
+	var event models.EventRequest
+	if err := c.Bind().Body(&event); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
+	createdEvent, err := h.eventService.CreateEvent(c.Context(), middleware.GetPrincipal(c), &event)
+	if err != nil {
+		return serviceError(err)
 	}
 
-	userID, err := h.jwtService.GetUserIDFromToken(token)
-	if err != nil {
-		return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+	return c.JSON(createdEvent)
+}
+
+func (h *EventHandler) updateEvent(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
 	}
 
 	var event models.EventRequest
@@ -64,72 +86,197 @@ func (h *EventHandler) createEvent(c fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	event.Organizer = userID
-	createdEvent, err := h.eventService.CreateEvent(c.Context(), &event)
+	event.ID = eventID
+
+	if err := h.eventService.UpdateEvent(c.Context(), middleware.GetPrincipal(c), &event); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *EventHandler) deleteEvent(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	if err := h.eventService.DeleteEvent(c.Context(), middleware.GetPrincipal(c), eventID); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *EventHandler) listEvents(c fiber.Ctx) error {
+	filter := models.EventFilter{
+		ModerationStatus: constants.EventModerationStatus(c.Query("moderation_status")),
+		TagIDs:           splitQuery(c.Query("tags")),
+		Organizer:        c.Query("organizer"),
+		Query:            strings.TrimSpace(c.Query("q")),
+		Sort:             constants.EventSort(c.Query("sort")),
+		Cursor:           c.Query("cursor"),
+	}
+
+	for _, status := range splitQuery(c.Query("status")) {
+		filter.Statuses = append(filter.Statuses, constants.EventStatus(status))
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		return err
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		return err
+	}
+
+	if filter.Limit, err = parseIntQuery(c, "limit"); err != nil {
+		return err
+	}
+
+	page, err := h.eventService.ListEvents(c.Context(), middleware.GetPrincipal(c), &filter)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return serviceError(err)
 	}
 
-	return c.JSON(createdEvent)
+	return c.JSON(page)
 }
 
-func (h *EventHandler) updateEvent(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
+func (h *EventHandler) searchEvents(c fiber.Ctx) error {
+	limit, err := parseIntQuery(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	offset, err := parseIntQuery(c, "offset")
+	if err != nil {
+		return err
+	}
+
+	results, err := h.eventService.SearchEvents(c.Context(), c.Query("q"), limit, offset)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(results)
+}
+
+func (h *EventHandler) getEventsNearby(c fiber.Ctx) error {
+	lat, err := parseFloatQuery(c, "lat", true)
+	if err != nil {
+		return err
+	}
+
+	lng, err := parseFloatQuery(c, "lng", true)
+	if err != nil {
+		return err
+	}
+
+	radiusKm, err := parseFloatQuery(c, "radius_km", false)
+	if err != nil {
+		return err
+	}
+
+	events, err := h.eventService.GetEventsNearby(c.Context(), lat, lng, radiusKm)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(events)
+}
+
+func (h *EventHandler) getEventsInViewport(c fiber.Ctx) error {
+	parts := strings.Split(c.Query("bbox"), ",")
+	if len(parts) != 4 {
+		return fiber.NewError(fiber.StatusBadRequest, "bbox must be minLng,minLat,maxLng,maxLat")
+	}
+
+	coords := make([]float64, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "bbox must be minLng,minLat,maxLng,maxLat")
+		}
+		coords[i] = value
+	}
+
+	viewport, err := h.eventService.GetEventsInViewport(c.Context(), models.BoundingBox{
+		MinLng: coords[0],
+		MinLat: coords[1],
+		MaxLng: coords[2],
+		MaxLat: coords[3],
+	})
+	if err != nil {
+		return serviceError(err)
 	}
 
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
+	return c.JSON(viewport)
+}
+
+func (h *EventHandler) listOccurrences(c fiber.Ctx) error {
+	from, to, err := parseWindowQuery(c)
+	if err != nil {
+		return err
 	}
 
-	userID, err := h.jwtService.GetUserIDFromToken(token)
+	occurrences, err := h.eventService.ListOccurrences(c.Context(), from, to)
 	if err != nil {
-		return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+		return serviceError(err)
 	}
 
+	return c.JSON(occurrences)
+}
+
+func (h *EventHandler) getEventOccurrences(c fiber.Ctx) error {
 	eventID := c.Params("id")
 	if eventID == "" {
 		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
 	}
 
-	var event models.EventRequest
-	if err := c.Bind().Body(&event); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	from, to, err := parseWindowQuery(c)
+	if err != nil {
+		return err
 	}
 
-	event.ID = eventID
-	event.Organizer = userID
-
-	if err := h.eventService.UpdateEvent(c.Context(), &event); err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	occurrences, err := h.eventService.GetEventOccurrences(c.Context(), middleware.GetPrincipal(c), eventID, from, to)
+	if err != nil {
+		return serviceError(err)
 	}
 
-	return c.SendStatus(fiber.StatusOK)
+	return c.JSON(occurrences)
 }
 
-func (h *EventHandler) deleteEvent(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
+func (h *EventHandler) updateOccurrence(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
 	}
 
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
+	var request models.OccurrenceUpdateRequest
+	if err := c.Bind().Body(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	_, err := h.jwtService.GetUserIDFromToken(token)
-	if err != nil {
-		return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+	if err := h.eventService.UpdateOccurrence(c.Context(), middleware.GetPrincipal(c), eventID, &request); err != nil {
+		return serviceError(err)
 	}
 
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *EventHandler) deleteOccurrence(c fiber.Ctx) error {
 	eventID := c.Params("id")
 	if eventID == "" {
 		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
 	}
 
-	if err := h.eventService.DeleteEvent(c.Context(), eventID); err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	var request models.OccurrenceDeleteRequest
+	if err := c.Bind().Body(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.eventService.DeleteOccurrence(c.Context(), middleware.GetPrincipal(c), eventID, &request); err != nil {
+		return serviceError(err)
 	}
 
 	return c.SendStatus(fiber.StatusOK)
@@ -141,7 +288,7 @@ func (h *EventHandler) getEvent(c fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
 	}
 
-	event, err := h.eventService.GetEvent(c.Context(), eventID)
+	event, err := h.eventService.GetEvent(c.Context(), middleware.GetPrincipal(c), eventID)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -159,7 +306,7 @@ func (h *EventHandler) getEventsByOrganizer(c fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "organizer ID is required")
 	}
 
-	events, err := h.eventService.GetEventsByOrganizer(c.Context(), organizerID)
+	events, err := h.eventService.GetEventsByOrganizer(c.Context(), middleware.GetPrincipal(c), organizerID)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -173,7 +320,7 @@ func (h *EventHandler) getEventsByStatus(c fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "status is required")
 	}
 
-	events, err := h.eventService.GetEventsByStatus(c.Context(), constants.EventStatus(status))
+	events, err := h.eventService.GetEventsByStatus(c.Context(), middleware.GetPrincipal(c), constants.EventStatus(status))
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -187,81 +334,103 @@ func (h *EventHandler) getEventsByModerationStatus(c fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "status is required")
 	}
 
-	events, err := h.eventService.GetEventsByModerationStatus(c.Context(), constants.EventModerationStatus(status))
+	events, err := h.eventService.GetEventsByModerationStatus(c.Context(), middleware.GetPrincipal(c), constants.EventModerationStatus(status))
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return serviceError(err)
 	}
 
 	return c.JSON(events)
 }
 
-func (h *EventHandler) approveEvent(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
+func (h *EventHandler) uploadImage(c fiber.Ctx) error {
+	var req struct {
+		Base64Data string `json:"base64_data"`
 	}
-
-	eventID := c.Params("id")
-	if eventID == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	if err := h.eventService.ApproveEvent(c.Context(), eventID); err != nil {
+	fileURL, err := h.minioService.UploadImage(req.Base64Data)
+	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return c.SendStatus(fiber.StatusOK)
+	return c.JSON(fiber.Map{
+		"url": fileURL,
+	})
 }
 
-func (h *EventHandler) rejectEvent(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
+// splitQuery splits a comma-separated query parameter, dropping empty items.
+func splitQuery(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
+}
 
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
+func parseTimeQuery(c fiber.Ctx, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
 	}
 
-	eventID := c.Params("id")
-	if eventID == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid "+key+": expected RFC3339")
 	}
 
-	if err := h.eventService.RejectEvent(c.Context(), eventID); err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	return &t, nil
+}
+
+func parseFloatQuery(c fiber.Ctx, key string, required bool) (float64, error) {
+	value := c.Query(key)
+	if value == "" {
+		if required {
+			return 0, fiber.NewError(fiber.StatusBadRequest, key+" is required")
+		}
+		return 0, nil
 	}
 
-	return c.SendStatus(fiber.StatusOK)
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid "+key)
+	}
+
+	return f, nil
 }
 
-func (h *EventHandler) uploadImage(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
+func parseIntQuery(c fiber.Ctx, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
 	}
 
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid "+key)
 	}
 
-	var req struct {
-		Base64Data string `json:"base64_data"`
-	}
-	if err := c.Bind().Body(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	return i, nil
+}
+
+// parseWindowQuery reads the required from and to query parameters.
+func parseWindowQuery(c fiber.Ctx) (time.Time, time.Time, error) {
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
 	}
 
-	fileURL, err := h.minioService.UploadImage(req.Base64Data)
+	to, err := parseTimeQuery(c, "to")
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return time.Time{}, time.Time{}, err
 	}
 
-	return c.JSON(fiber.Map{
-		"url": fileURL,
-	})
+	if from == nil || to == nil {
+		return time.Time{}, time.Time{}, fiber.NewError(fiber.StatusBadRequest, "from and to are required")
+	}
+
+	return *from, *to, nil
 }
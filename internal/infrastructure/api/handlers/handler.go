@@ -1,40 +1,220 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
 
 	"github.com/gofiber/fiber/v3"
 )
 
 type HTTPHandler struct {
-	cfg          *config.Config
-	authHandler  *AuthHandler
-	userHandler  *UserHandler
-	eventHandler *EventHandler
+	cfg                 *config.Config
+	authMiddleware      *middleware.AuthMiddleware
+	authHandler         *AuthHandler
+	userHandler         *UserHandler
+	eventHandler        *EventHandler
+	registrationHandler *RegistrationHandler
+	ticketHandler       *TicketHandler
+	paymentHandler      *PaymentHandler
+	calendarHandler     *CalendarHandler
+	moderationHandler   *ModerationHandler
+	reportHandler       *ReportHandler
+	notificationHandler *NotificationHandler
+	realtimeHandler     *RealtimeHandler
+	securityHandler     *SecurityHandler
+	mfaHandler          *MFAHandler
+	oidcHandler         *OIDCHandler
 }
 
 func NewHTTPHandler(
 	cfg *config.Config,
+	authMiddleware *middleware.AuthMiddleware,
 	authHandler *AuthHandler,
 	userHandler *UserHandler,
 	eventHandler *EventHandler,
+	registrationHandler *RegistrationHandler,
+	ticketHandler *TicketHandler,
+	paymentHandler *PaymentHandler,
+	calendarHandler *CalendarHandler,
+	moderationHandler *ModerationHandler,
+	reportHandler *ReportHandler,
+	notificationHandler *NotificationHandler,
+	realtimeHandler *RealtimeHandler,
+	securityHandler *SecurityHandler,
+	mfaHandler *MFAHandler,
+	oidcHandler *OIDCHandler,
 ) *HTTPHandler {
 	return &HTTPHandler{
-		cfg:          cfg,
-		authHandler:  authHandler,
-		userHandler:  userHandler,
-		eventHandler: eventHandler,
+		cfg:                 cfg,
+		authMiddleware:      authMiddleware,
+		authHandler:         authHandler,
+		userHandler:         userHandler,
+		eventHandler:        eventHandler,
+		registrationHandler: registrationHandler,
+		ticketHandler:       ticketHandler,
+		paymentHandler:      paymentHandler,
+		calendarHandler:     calendarHandler,
+		moderationHandler:   moderationHandler,
+		reportHandler:       reportHandler,
+		notificationHandler: notificationHandler,
+		realtimeHandler:     realtimeHandler,
+		securityHandler:     securityHandler,
+		mfaHandler:          mfaHandler,
+		oidcHandler:         oidcHandler,
 	}
 }
 
+// RegisterRoutes mounts every handler on either the public or the
+// authenticated router.
 func (h *HTTPHandler) RegisterRoutes(app *fiber.App) {
-	app.Get("/health", func(c fiber.Ctx) error {
+	public := app.Group("")
+
+	public.Get("/health", func(c fiber.Ctx) error {
 		return c.JSON(fiber.Map{
 			"status": "ok",
 		})
 	})
 
-	h.authHandler.RegisterRoutes(app)
-	h.userHandler.RegisterRoutes(app)
-	h.eventHandler.RegisterRoutes(app)
+	h.authHandler.RegisterPublicRoutes(public)
+	h.eventHandler.RegisterPublicRoutes(public)
+	h.ticketHandler.RegisterPublicRoutes(public)
+	h.paymentHandler.RegisterPublicRoutes(public)
+	h.calendarHandler.RegisterPublicRoutes(public)
+	h.realtimeHandler.RegisterPublicRoutes(public)
+	h.oidcHandler.RegisterPublicRoutes(public)
+
+	private := newAuthenticatedRouter(app.Group(""), h.authMiddleware.RequireAuth)
+
+	h.authHandler.RegisterRoutes(private)
+	h.userHandler.RegisterRoutes(private)
+	h.eventHandler.RegisterRoutes(private)
+	h.registrationHandler.RegisterRoutes(private)
+	h.ticketHandler.RegisterRoutes(private)
+	h.paymentHandler.RegisterRoutes(private)
+	h.calendarHandler.RegisterRoutes(private)
+	h.moderationHandler.RegisterRoutes(private)
+	h.reportHandler.RegisterRoutes(private)
+	h.notificationHandler.RegisterRoutes(private)
+	h.securityHandler.RegisterRoutes(private)
+	h.mfaHandler.RegisterRoutes(private)
+	h.oidcHandler.RegisterRoutes(private)
+	h.realtimeHandler.RegisterRoutes(private)
+}
+
+// authenticatedRouter adds its middleware, RequireAuth first, to each route
+// registered through it. A group with the middleware would install it for
+// every path under the group's prefix, so that paths matching no route
+// would answer 401 instead of 404. Use and Route would bypass the middleware
+// and panic.
+type authenticatedRouter struct {
+	fiber.Router
+	middleware []fiber.Handler
+}
+
+func newAuthenticatedRouter(router fiber.Router, requireAuth fiber.Handler) fiber.Router {
+	return &authenticatedRouter{
+		Router:     router,
+		middleware: []fiber.Handler{requireAuth},
+	}
+}
+
+func (r *authenticatedRouter) Add(methods []string, path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	r.Router.Add(methods, path, handler, r.handlers(middleware)...)
+	return r
+}
+
+func (r *authenticatedRouter) All(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	r.Router.All(path, handler, r.handlers(middleware)...)
+	return r
+}
+
+func (r *authenticatedRouter) Get(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodGet}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Post(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodPost}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Put(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodPut}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Patch(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodPatch}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Delete(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodDelete}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Head(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodHead}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Options(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodOptions}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Connect(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodConnect}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Trace(path string, handler fiber.Handler, middleware ...fiber.Handler) fiber.Router {
+	return r.Add([]string{fiber.MethodTrace}, path, handler, middleware...)
+}
+
+func (r *authenticatedRouter) Name(name string) fiber.Router {
+	r.Router.Name(name)
+	return r
+}
+
+func (r *authenticatedRouter) Use(args ...any) fiber.Router {
+	panic("authenticatedRouter: Use would install middleware without authentication; register routes instead")
+}
+
+func (r *authenticatedRouter) Route(path string) fiber.Register {
+	panic("authenticatedRouter: Route would register routes without authentication; use Group instead")
+}
+
+// Group adds the group's handlers to the middleware of its routes, after
+// RequireAuth, instead of installing them for the whole prefix.
+func (r *authenticatedRouter) Group(prefix string, handlers ...fiber.Handler) fiber.Router {
+	return &authenticatedRouter{
+		Router:     r.Router.Group(prefix),
+		middleware: r.handlers(handlers),
+	}
+}
+
+// handlers returns the router's middleware followed by the given handlers.
+func (r *authenticatedRouter) handlers(middleware []fiber.Handler) []fiber.Handler {
+	return append(append([]fiber.Handler{}, r.middleware...), middleware...)
+}
+
+// serviceError maps an error returned by a core service to an HTTP error.
+func serviceError(err error) error {
+	if errors.Is(err, services.ErrForbidden) {
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	}
+
+	if errors.Is(err, services.ErrInvalidArgument) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errors.Is(err, services.ErrNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+
+	if errors.Is(err, services.ErrConflict) {
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+
+	if errors.Is(err, services.ErrTooManyRequests) {
+		return fiber.NewError(fiber.StatusTooManyRequests, err.Error())
+	}
+
+	return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 }
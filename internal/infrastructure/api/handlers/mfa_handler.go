@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type MFAHandler struct {
+	mfaService *services.MFAService
+}
+
+func NewMFAHandler(mfaService *services.MFAService) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+	}
+}
+
+func (h *MFAHandler) RegisterRoutes(router fiber.Router) {
+	mfa := router.Group("/auth/mfa")
+
+	mfa.Get("/", h.getStatus)
+	mfa.Post("/setup", h.beginSetup)
+	mfa.Post("/confirm", h.confirmSetup)
+	mfa.Post("/disable", h.disable)
+	mfa.Post("/recovery-codes", h.regenerateRecoveryCodes)
+
+	admin := router.Group("/admin/mfa-policies", middleware.RequirePermission(constants.PermissionManageRoles))
+
+	admin.Get("/", h.getPolicies)
+	admin.Put("/:role", h.updatePolicy)
+}
+
+func (h *MFAHandler) getStatus(c fiber.Ctx) error {
+	status, err := h.mfaService.GetStatus(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(status)
+}
+
+func (h *MFAHandler) beginSetup(c fiber.Ctx) error {
+	var req models.MFASetupRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	setup, err := h.mfaService.BeginSetup(c.Context(), middleware.GetPrincipal(c), req.Password, clientInfo(c))
+	if err != nil {
+		return throttledError(c, err)
+	}
+
+	return c.JSON(setup)
+}
+
+func (h *MFAHandler) confirmSetup(c fiber.Ctx) error {
+	var req models.MFACodeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	codes, err := h.mfaService.ConfirmSetup(c.Context(), middleware.GetPrincipal(c), req.Code, clientInfo(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(codes)
+}
+
+func (h *MFAHandler) disable(c fiber.Ctx) error {
+	var req models.MFADisableRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.mfaService.Disable(c.Context(), middleware.GetPrincipal(c), req.Password, req.Code, clientInfo(c)); err != nil {
+		return throttledError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *MFAHandler) regenerateRecoveryCodes(c fiber.Ctx) error {
+	var req models.MFACodeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.Context(), middleware.GetPrincipal(c), req.Code, clientInfo(c))
+	if err != nil {
+		return throttledError(c, err)
+	}
+
+	return c.JSON(codes)
+}
+
+func (h *MFAHandler) getPolicies(c fiber.Ctx) error {
+	policies, err := h.mfaService.GetPolicies(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(policies)
+}
+
+func (h *MFAHandler) updatePolicy(c fiber.Ctx) error {
+	var req models.UpdateMFAPolicyRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	policy, err := h.mfaService.UpdatePolicy(c.Context(), middleware.GetPrincipal(c), constants.UserRole(c.Params("role")), req.Required)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(policy)
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+	"github.com/gofiber/fiber/v3"
+)
+
+type ModerationHandler struct {
+	config            *config.Config
+	moderationService *services.ModerationService
+}
+
+func NewModerationHandler(
+	config *config.Config,
+	moderationService *services.ModerationService,
+) *ModerationHandler {
+	return &ModerationHandler{
+		config:            config,
+		moderationService: moderationService,
+	}
+}
+
+func (h *ModerationHandler) RegisterRoutes(router fiber.Router) {
+	moderate := middleware.RequirePermission(constants.PermissionModerateEvents)
+
+	moderation := router.Group("/moderation")
+	moderation.Get("/queue", h.getQueue, moderate)
+	moderation.Post("/queue/next", h.claimNextEvent, moderate)
+	moderation.Post("/events/:id/claim", h.claimEvent, moderate)
+	moderation.Delete("/events/:id/claim", h.releaseClaim, moderate)
+
+	events := router.Group("/events")
+	events.Put("/:id/approve", h.approveEvent, moderate)
+	events.Put("/:id/reject", h.rejectEvent, moderate)
+	events.Post("/:id/resubmit", h.resubmitEvent)
+	events.Get("/:id/moderation", h.getHistory)
+}
+
+func (h *ModerationHandler) getQueue(c fiber.Ctx) error {
+	limit, err := parseIntQuery(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	page, err := h.moderationService.GetQueue(c.Context(), middleware.GetPrincipal(c), c.Query("cursor"), limit)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(page)
+}
+
+func (h *ModerationHandler) claimNextEvent(c fiber.Ctx) error {
+	event, err := h.moderationService.ClaimNextEvent(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	if event == nil {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	return c.JSON(event)
+}
+
+func (h *ModerationHandler) claimEvent(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	claim, err := h.moderationService.ClaimEvent(c.Context(), middleware.GetPrincipal(c), eventID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(claim)
+}
+
+func (h *ModerationHandler) releaseClaim(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	if err := h.moderationService.ReleaseClaim(c.Context(), middleware.GetPrincipal(c), eventID); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *ModerationHandler) approveEvent(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	var request models.ModerationDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().Body(&request); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	}
+
+	if err := h.moderationService.ApproveEvent(c.Context(), middleware.GetPrincipal(c), eventID, &request); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *ModerationHandler) rejectEvent(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	var request models.ModerationDecisionRequest
+	if err := c.Bind().Body(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.moderationService.RejectEvent(c.Context(), middleware.GetPrincipal(c), eventID, &request); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *ModerationHandler) resubmitEvent(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	if err := h.moderationService.ResubmitEvent(c.Context(), middleware.GetPrincipal(c), eventID); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *ModerationHandler) getHistory(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	actions, err := h.moderationService.GetHistory(c.Context(), middleware.GetPrincipal(c), eventID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(actions)
+}
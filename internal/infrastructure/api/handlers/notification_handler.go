@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+	"github.com/gofiber/fiber/v3"
+)
+
+type NotificationHandler struct {
+	config              *config.Config
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(
+	config *config.Config,
+	notificationService *services.NotificationService,
+) *NotificationHandler {
+	return &NotificationHandler{
+		config:              config,
+		notificationService: notificationService,
+	}
+}
+
+func (h *NotificationHandler) RegisterRoutes(router fiber.Router) {
+	notifications := router.Group("/notifications")
+	notifications.Get("/", h.getInbox)
+	notifications.Post("/read", h.markRead)
+	notifications.Post("/read-all", h.markAllRead)
+	notifications.Get("/preferences", h.getPreferences)
+	notifications.Put("/preferences", h.updatePreferences)
+}
+
+func (h *NotificationHandler) getInbox(c fiber.Ctx) error {
+	limit, err := parseIntQuery(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	inbox, err := h.notificationService.GetInbox(c.Context(), middleware.GetPrincipal(c), &models.NotificationFilter{
+		UnreadOnly: c.Query("unread") == "true",
+		Category:   constants.NotificationCategory(c.Query("category")),
+		Cursor:     c.Query("cursor"),
+		Limit:      limit,
+	})
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(inbox)
+}
+
+func (h *NotificationHandler) markRead(c fiber.Ctx) error {
+	var request models.MarkNotificationsReadRequest
+	if err := c.Bind().Body(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.notificationService.MarkRead(c.Context(), middleware.GetPrincipal(c), request.IDs); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *NotificationHandler) markAllRead(c fiber.Ctx) error {
+	category := constants.NotificationCategory(c.Query("category"))
+	if err := h.notificationService.MarkAllRead(c.Context(), middleware.GetPrincipal(c), category); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *NotificationHandler) getPreferences(c fiber.Ctx) error {
+	preferences, err := h.notificationService.GetPreferences(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(preferences)
+}
+
+func (h *NotificationHandler) updatePreferences(c fiber.Ctx) error {
+	var request models.NotificationPreferences
+	if err := c.Bind().Body(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	preferences, err := h.notificationService.UpdatePreferences(c.Context(), middleware.GetPrincipal(c), request)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(preferences)
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// oidcStateCookie keeps the state of a started login in the browser.
+const oidcStateCookie = "oidc_state"
+
+type OIDCHandler struct {
+	config      *config.Config
+	oidcService *services.OIDCService
+}
+
+func NewOIDCHandler(config *config.Config, oidcService *services.OIDCService) *OIDCHandler {
+	return &OIDCHandler{
+		config:      config,
+		oidcService: oidcService,
+	}
+}
+
+func (h *OIDCHandler) RegisterPublicRoutes(router fiber.Router) {
+	oidc := router.Group("/auth/oidc")
+
+	oidc.Get("/providers", h.getProviders)
+	oidc.Post("/:provider/start", h.startLogin)
+	oidc.Post("/:provider/callback", h.completeLogin)
+}
+
+func (h *OIDCHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/auth/identities", h.getIdentities)
+}
+
+func (h *OIDCHandler) getProviders(c fiber.Ctx) error {
+	return c.JSON(models.OIDCProvidersResponse{Providers: h.oidcService.Providers()})
+}
+
+func (h *OIDCHandler) startLogin(c fiber.Ctx) error {
+	response, err := h.oidcService.StartLogin(c.Context(), c.Params("provider"))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	h.setStateCookie(c, response.State, time.Now().Add(h.config.OIDC.StateTTL))
+
+	return c.JSON(response)
+}
+
+func (h *OIDCHandler) completeLogin(c fiber.Ctx) error {
+	var req models.OIDCCallbackRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	browserState := c.Cookies(oidcStateCookie)
+	// The state is single-use, so the cookie goes whatever the outcome.
+	h.setStateCookie(c, "", time.Unix(0, 0))
+
+	response, err := h.oidcService.CompleteLogin(c.Context(), c.Params("provider"), req, browserState, clientInfo(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *OIDCHandler) getIdentities(c fiber.Ctx) error {
+	identities, err := h.oidcService.ListIdentities(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(identities)
+}
+
+// setStateCookie scopes the cookie to the provider's routes, where the
+// callback reads it; other sites cannot send it with their requests.
+func (h *OIDCHandler) setStateCookie(c fiber.Ctx, state string, expires time.Time) {
+	path := c.Path()
+	path = path[:strings.LastIndex(path, "/")]
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     path,
+		Expires:  expires,
+		Secure:   c.Secure(),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
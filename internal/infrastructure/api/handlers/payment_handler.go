@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const paymentSignatureHeader = "X-Payment-Signature"
+
+type PaymentHandler struct {
+	config         *config.Config
+	paymentService *services.PaymentService
+}
+
+func NewPaymentHandler(
+	config *config.Config,
+	paymentService *services.PaymentService,
+) *PaymentHandler {
+	return &PaymentHandler{
+		config:         config,
+		paymentService: paymentService,
+	}
+}
+
+func (h *PaymentHandler) RegisterPublicRoutes(router fiber.Router) {
+	router.Post("/payments/webhook", h.handleWebhook)
+}
+
+func (h *PaymentHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/events/:id/orders", h.createOrder)
+	router.Get("/users/orders", h.getMyOrders)
+
+	orders := router.Group("/orders")
+	orders.Get("/:id", h.getOrder)
+	orders.Post("/:id/confirm", h.confirmOrder)
+	orders.Post("/:id/refund", h.refundOrder)
+}
+
+func (h *PaymentHandler) handleWebhook(c fiber.Ctx) error {
+	err := h.paymentService.HandleWebhook(c.Context(), c.Body(), c.Get(paymentSignatureHeader))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidWebhook) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *PaymentHandler) createOrder(c fiber.Ctx) error {
+	var req models.CreateOrderRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.paymentService.CreateOrder(c.Context(), middleware.GetPrincipal(c), c.Params("id"), req.TicketTypeID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *PaymentHandler) getMyOrders(c fiber.Ctx) error {
+	orders, err := h.paymentService.GetMyOrders(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(orders)
+}
+
+func (h *PaymentHandler) getOrder(c fiber.Ctx) error {
+	order, err := h.paymentService.GetOrder(c.Context(), middleware.GetPrincipal(c), c.Params("id"))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(order)
+}
+
+func (h *PaymentHandler) confirmOrder(c fiber.Ctx) error {
+	order, err := h.paymentService.ConfirmOrder(c.Context(), middleware.GetPrincipal(c), c.Params("id"))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(order)
+}
+
+func (h *PaymentHandler) refundOrder(c fiber.Ctx) error {
+	order, err := h.paymentService.RefundOrder(c.Context(), middleware.GetPrincipal(c), c.Params("id"))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(order)
+}
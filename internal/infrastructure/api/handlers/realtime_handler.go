@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+	"github.com/gofiber/fiber/v3"
+)
+
+// realtimeRetry tells EventSource clients how long to wait before
+// reconnecting, in milliseconds.
+const realtimeRetry = 3000
+
+type RealtimeHandler struct {
+	config          *config.Config
+	authMiddleware  *middleware.AuthMiddleware
+	realtimeService *services.RealtimeService
+	sessionService  *services.SessionService
+}
+
+func NewRealtimeHandler(
+	config *config.Config,
+	authMiddleware *middleware.AuthMiddleware,
+	realtimeService *services.RealtimeService,
+	sessionService *services.SessionService,
+) *RealtimeHandler {
+	return &RealtimeHandler{
+		config:          config,
+		authMiddleware:  authMiddleware,
+		realtimeService: realtimeService,
+		sessionService:  sessionService,
+	}
+}
+
+// RegisterPublicRoutes mounts the stream outside the authenticated group,
+// which only accepts the Authorization header; the stream authenticates
+// itself.
+func (h *RealtimeHandler) RegisterPublicRoutes(router fiber.Router) {
+	router.Get("/realtime/stream", h.stream, h.authMiddleware.RequireStreamAuth)
+}
+
+func (h *RealtimeHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/realtime/tickets", h.issueTicket)
+}
+
+func (h *RealtimeHandler) issueTicket(c fiber.Ctx) error {
+	ticket, err := h.sessionService.IssueStreamTicket(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(ticket)
+}
+
+// stream sends the messages of the requested channels as Server-Sent
+// Events until the client disconnects or its access token or session
+// expires.
+func (h *RealtimeHandler) stream(c fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var channels []string
+	for _, channel := range strings.Split(c.Query("channels", "user"), ",") {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+
+	subscription, err := h.realtimeService.Subscribe(c.Context(), principal, channels)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The server write timeout covers the whole response, so the deadline
+	// is pushed back before every write to keep the stream open.
+	conn := c.RequestCtx().Conn()
+	writeTimeout := c.App().Config().WriteTimeout
+	heartbeat := h.config.Realtime.HeartbeatInterval
+
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		defer subscription.Close()
+
+		flush := func() error {
+			if writeTimeout > 0 {
+				if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+					return err
+				}
+			}
+			return w.Flush()
+		}
+
+		fmt.Fprintf(w, "retry: %d\n\n", realtimeRetry)
+		if err := flush(); err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case message, ok := <-subscription.Messages():
+				if !ok {
+					return
+				}
+
+				if err := writeRealtimeMessage(w, &message); err != nil {
+					return
+				}
+			case <-ticker.C:
+				// The stream outlives the request that authenticated it,
+				// so the credentials are checked again on every heartbeat.
+				if !time.Now().Before(principal.ExpiresAt) ||
+					!h.sessionService.IsSessionActive(principal.SessionID, principal.ID) {
+					return
+				}
+
+				// Comments keep proxies from closing an idle stream and
+				// reveal clients that are gone.
+				w.WriteString(": ping\n\n")
+			}
+
+			if err := flush(); err != nil {
+				return
+			}
+		}
+	})
+}
+
+// writeRealtimeMessage writes the message as an event named after its type.
+func writeRealtimeMessage(w *bufio.Writer, message *models.RealtimeMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Type, data)
+	return err
+}
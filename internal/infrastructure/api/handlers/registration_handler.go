@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type RegistrationHandler struct {
+	config              *config.Config
+	registrationService *services.RegistrationService
+}
+
+func NewRegistrationHandler(
+	config *config.Config,
+	registrationService *services.RegistrationService,
+) *RegistrationHandler {
+	return &RegistrationHandler{
+		config:              config,
+		registrationService: registrationService,
+	}
+}
+
+func (h *RegistrationHandler) RegisterRoutes(router fiber.Router) {
+	events := router.Group("/events")
+	events.Get("/:id/registration", h.getRegistration)
+	events.Put("/:id/registration", h.register)
+	events.Delete("/:id/registration", h.unregister)
+	events.Get("/:id/attendees", h.getAttendees)
+
+	users := router.Group("/users")
+	users.Get("/events/upcoming", h.getUpcomingEvents)
+}
+
+func (h *RegistrationHandler) getRegistration(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	registration, err := h.registrationService.GetRegistration(c.Context(), middleware.GetPrincipal(c), eventID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	if registration == nil {
+		return fiber.NewError(fiber.StatusNotFound, "registration not found")
+	}
+
+	return c.JSON(registration)
+}
+
+func (h *RegistrationHandler) register(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	var req models.RegistrationRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	registration, err := h.registrationService.Register(c.Context(), middleware.GetPrincipal(c), eventID, req.Status)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(registration)
+}
+
+func (h *RegistrationHandler) unregister(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	if err := h.registrationService.Unregister(c.Context(), middleware.GetPrincipal(c), eventID); err != nil {
+		return serviceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *RegistrationHandler) getAttendees(c fiber.Ctx) error {
+	eventID := c.Params("id")
+	if eventID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "event ID is required")
+	}
+
+	attendees, err := h.registrationService.GetAttendees(c.Context(), middleware.GetPrincipal(c), eventID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(attendees)
+}
+
+func (h *RegistrationHandler) getUpcomingEvents(c fiber.Ctx) error {
+	events, err := h.registrationService.GetUpcomingEvents(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(events)
+}
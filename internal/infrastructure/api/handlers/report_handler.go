@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+	"github.com/gofiber/fiber/v3"
+)
+
+type ReportHandler struct {
+	config        *config.Config
+	reportService *services.ReportService
+}
+
+func NewReportHandler(
+	config *config.Config,
+	reportService *services.ReportService,
+) *ReportHandler {
+	return &ReportHandler{
+		config:        config,
+		reportService: reportService,
+	}
+}
+
+func (h *ReportHandler) RegisterRoutes(router fiber.Router) {
+	handleReports := middleware.RequirePermission(constants.PermissionHandleReports)
+
+	reports := router.Group("/reports")
+	reports.Post("/", h.createReport)
+	reports.Get("/cases", h.listCases, handleReports)
+	reports.Get("/cases/:id", h.getCase, handleReports)
+	reports.Put("/cases/:id", h.updateCase, handleReports)
+}
+
+func (h *ReportHandler) createReport(c fiber.Ctx) error {
+	var request models.ReportRequest
+	if err := c.Bind().Body(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	report, err := h.reportService.CreateReport(c.Context(), middleware.GetPrincipal(c), &request)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(report)
+}
+
+func (h *ReportHandler) listCases(c fiber.Ctx) error {
+	limit, err := parseIntQuery(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	page, err := h.reportService.ListCases(c.Context(), middleware.GetPrincipal(c), &models.AbuseCaseFilter{
+		Status:     constants.AbuseCaseStatus(c.Query("status")),
+		TargetType: constants.ReportTargetType(c.Query("targetType")),
+		Cursor:     c.Query("cursor"),
+		Limit:      limit,
+	})
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(page)
+}
+
+func (h *ReportHandler) getCase(c fiber.Ctx) error {
+	caseID := c.Params("id")
+	if caseID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "case ID is required")
+	}
+
+	details, err := h.reportService.GetCase(c.Context(), middleware.GetPrincipal(c), caseID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	if details == nil {
+		return fiber.NewError(fiber.StatusNotFound, "case not found")
+	}
+
+	return c.JSON(details)
+}
+
+func (h *ReportHandler) updateCase(c fiber.Ctx) error {
+	caseID := c.Params("id")
+	if caseID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "case ID is required")
+	}
+
+	var request models.AbuseCaseUpdateRequest
+	if err := c.Bind().Body(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	abuseCase, err := h.reportService.UpdateCase(c.Context(), middleware.GetPrincipal(c), caseID, &request)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(abuseCase)
+}
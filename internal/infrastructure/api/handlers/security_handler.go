@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type SecurityHandler struct {
+	securityEventService *services.SecurityEventService
+}
+
+func NewSecurityHandler(securityEventService *services.SecurityEventService) *SecurityHandler {
+	return &SecurityHandler{
+		securityEventService: securityEventService,
+	}
+}
+
+func (h *SecurityHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/admin/security-events", h.listEvents, middleware.RequirePermission(constants.PermissionViewSecurity))
+}
+
+func (h *SecurityHandler) listEvents(c fiber.Ctx) error {
+	limit, err := parseIntQuery(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	page, err := h.securityEventService.ListEvents(c.Context(), middleware.GetPrincipal(c), &models.SecurityEventFilter{
+		Type:   constants.SecurityEventType(c.Query("type")),
+		UserID: c.Query("user_id"),
+		Email:  c.Query("email"),
+		IP:     c.Query("ip"),
+		Cursor: c.Query("cursor"),
+		Limit:  limit,
+	})
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(page)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type TicketHandler struct {
+	config         *config.Config
+	authMiddleware *middleware.AuthMiddleware
+	ticketService  *services.TicketService
+}
+
+func NewTicketHandler(
+	config *config.Config,
+	authMiddleware *middleware.AuthMiddleware,
+	ticketService *services.TicketService,
+) *TicketHandler {
+	return &TicketHandler{
+		config:         config,
+		authMiddleware: authMiddleware,
+		ticketService:  ticketService,
+	}
+}
+
+func (h *TicketHandler) RegisterPublicRoutes(router fiber.Router) {
+	events := router.Group("/events")
+	events.Get("/:id/ticket-types", h.getTicketTypes, h.authMiddleware.OptionalAuth)
+}
+
+func (h *TicketHandler) RegisterRoutes(router fiber.Router) {
+	events := router.Group("/events")
+	events.Post("/:id/ticket-types", h.createTicketType)
+	events.Put("/:id/ticket-types/:typeId", h.updateTicketType)
+	events.Post("/:id/tickets", h.obtainTicket)
+	events.Post("/:id/checkin", h.checkIn)
+	events.Get("/:id/attendance", h.getAttendanceStats)
+
+	router.Get("/users/tickets", h.getMyTickets)
+
+	tickets := router.Group("/tickets")
+	tickets.Get("/:id", h.getTicket)
+	tickets.Get("/:id/qr", h.getTicketQR)
+}
+
+func (h *TicketHandler) getTicketTypes(c fiber.Ctx) error {
+	ticketTypes, err := h.ticketService.GetTicketTypes(c.Context(), middleware.GetPrincipal(c), c.Params("id"))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(ticketTypes)
+}
+
+func (h *TicketHandler) createTicketType(c fiber.Ctx) error {
+	var req models.TicketTypeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	ticketType, err := h.ticketService.CreateTicketType(c.Context(), middleware.GetPrincipal(c), c.Params("id"), &req)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(ticketType)
+}
+
+func (h *TicketHandler) updateTicketType(c fiber.Ctx) error {
+	var req models.TicketTypeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	ticketType, err := h.ticketService.UpdateTicketType(c.Context(), middleware.GetPrincipal(c), c.Params("id"), c.Params("typeId"), &req)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(ticketType)
+}
+
+func (h *TicketHandler) obtainTicket(c fiber.Ctx) error {
+	var req models.IssueTicketRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	ticket, err := h.ticketService.ObtainTicket(c.Context(), middleware.GetPrincipal(c), c.Params("id"), req.TicketTypeID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(ticket)
+}
+
+func (h *TicketHandler) checkIn(c fiber.Ctx) error {
+	var req models.CheckInRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.ticketService.CheckIn(c.Context(), middleware.GetPrincipal(c), c.Params("id"), req.Code)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTicketCode) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return serviceError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *TicketHandler) getAttendanceStats(c fiber.Ctx) error {
+	stats, err := h.ticketService.GetAttendanceStats(c.Context(), middleware.GetPrincipal(c), c.Params("id"))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(stats)
+}
+
+func (h *TicketHandler) getMyTickets(c fiber.Ctx) error {
+	tickets, err := h.ticketService.GetMyTickets(c.Context(), middleware.GetPrincipal(c))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(tickets)
+}
+
+func (h *TicketHandler) getTicket(c fiber.Ctx) error {
+	ticket, err := h.ticketService.GetTicket(c.Context(), middleware.GetPrincipal(c), c.Params("id"))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(ticket)
+}
+
+func (h *TicketHandler) getTicketQR(c fiber.Ctx) error {
+	png, err := h.ticketService.GetTicketQR(c.Context(), middleware.GetPrincipal(c), c.Params("id"))
+	if err != nil {
+		return serviceError(err)
+	}
+
+	c.Set(fiber.HeaderContentType, "image/png")
+	return c.Send(png)
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
 	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -11,7 +13,6 @@ import (
 type UserHandler struct {
 	config        *config.Config
 	userService   *services.UserService
-	jwtService    *services.JWTService
 	minioService  *services.MinioService
 	friendService *services.FriendService
 }
@@ -19,14 +20,12 @@ type UserHandler struct {
 func NewUserHandler(
 	config *config.Config,
 	userService *services.UserService,
-	jwtService *services.JWTService,
 	minioService *services.MinioService,
 	friendService *services.FriendService,
 ) *UserHandler {
 	return &UserHandler{
 		config:        config,
 		userService:   userService,
-		jwtService:    jwtService,
 		minioService:  minioService,
 		friendService: friendService,
 	}
@@ -46,19 +45,14 @@ func (h *UserHandler) RegisterRoutes(router fiber.Router) {
 	friends.Post("/request", h.sendFriendRequest)
 	friends.Put("/respond", h.respondToFriendRequest)
 	friends.Delete("/:friendId", h.removeFriend)
+
+	admin := router.Group("/admin/users", middleware.RequirePermission(constants.PermissionManageRoles))
+	admin.Put("/:id/role", h.updateUserRole)
+	admin.Delete("/:id/role", h.revokeUserRole)
 }
 
 func (h *UserHandler) getUserInfo(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
-	safeUser, err := h.userService.GetUserInfo(token)
+	safeUser, err := h.userService.GetUserInfo(middleware.GetPrincipal(c).ID)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -67,38 +61,20 @@ func (h *UserHandler) getUserInfo(c fiber.Ctx) error {
 }
 
 func (h *UserHandler) editUserInfo(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
 	var req models.EditUserInfo
 	if err := c.Bind().Body(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	safeUser, err := h.userService.EditUserInfo(token, &req)
+	safeUser, err := h.userService.EditUserInfo(middleware.GetPrincipal(c).ID, &req)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return serviceError(err)
 	}
 
 	return c.JSON(safeUser)
 }
 
 func (h *UserHandler) uploadImage(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
 	var req struct {
 		Base64Data string `json:"base64_data"`
 	}
@@ -117,26 +93,14 @@ func (h *UserHandler) uploadImage(c fiber.Ctx) error {
 }
 
 func (h *UserHandler) sendFriendRequest(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
-	userID, err := h.jwtService.GetUserIDFromToken(token)
-	if err != nil {
-		return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
-	}
+	userID := middleware.GetPrincipal(c).ID
 
 	var req models.SendFriendRequest
 	if err := c.Bind().Body(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	response, err := h.friendService.SendFriendRequest(userID, req.ToID)
+	response, err := h.friendService.SendFriendRequest(c.Context(), userID, req.ToID)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -145,21 +109,12 @@ func (h *UserHandler) sendFriendRequest(c fiber.Ctx) error {
 }
 
 func (h *UserHandler) respondToFriendRequest(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
 	var req models.RespondToFriendRequest
 	if err := c.Bind().Body(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	err := h.friendService.RespondToFriendRequest(req.FriendID, req.Accept)
+	err := h.friendService.RespondToFriendRequest(c.Context(), req.FriendID, req.Accept)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -168,19 +123,7 @@ func (h *UserHandler) respondToFriendRequest(c fiber.Ctx) error {
 }
 
 func (h *UserHandler) getFriendsList(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
-	userID, err := h.jwtService.GetUserIDFromToken(token)
-	if err != nil {
-		return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
-	}
+	userID := middleware.GetPrincipal(c).ID
 
 	friends, err := h.friendService.GetFriendsList(userID)
 	if err != nil {
@@ -191,26 +134,14 @@ func (h *UserHandler) getFriendsList(c fiber.Ctx) error {
 }
 
 func (h *UserHandler) removeFriend(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
-	userID, err := h.jwtService.GetUserIDFromToken(token)
-	if err != nil {
-		return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
-	}
+	userID := middleware.GetPrincipal(c).ID
 
 	friendID := c.Params("friendId")
 	if friendID == "" {
 		return fiber.NewError(fiber.StatusBadRequest, "friend ID is required")
 	}
 
-	err = h.friendService.RemoveFriend(userID, friendID)
+	err := h.friendService.RemoveFriend(userID, friendID)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -219,15 +150,6 @@ func (h *UserHandler) removeFriend(c fiber.Ctx) error {
 }
 
 func (h *UserHandler) searchUsers(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
 	name := c.Query("name")
 	if name == "" {
 		return fiber.NewError(fiber.StatusBadRequest, "name parameter is required")
@@ -242,19 +164,7 @@ func (h *UserHandler) searchUsers(c fiber.Ctx) error {
 }
 
 func (h *UserHandler) getIncomingFriendRequests(c fiber.Ctx) error {
-	token := c.Get("Authorization")
-	if token == "" {
-		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
-	}
-
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
-	userID, err := h.jwtService.GetUserIDFromToken(token)
-	if err != nil {
-		return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
-	}
+	userID := middleware.GetPrincipal(c).ID
 
 	requests, err := h.friendService.GetIncomingFriendRequests(userID)
 	if err != nil {
@@ -272,3 +182,36 @@ func (h *UserHandler) getIncomingFriendRequests(c fiber.Ctx) error {
 
 	return c.JSON(response)
 }
+
+func (h *UserHandler) updateUserRole(c fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "user ID is required")
+	}
+
+	var req models.UpdateUserRole
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	safeUser, err := h.userService.UpdateUserRole(middleware.GetPrincipal(c), userID, req.Role)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(safeUser)
+}
+
+func (h *UserHandler) revokeUserRole(c fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "user ID is required")
+	}
+
+	safeUser, err := h.userService.RevokeUserRole(middleware.GetPrincipal(c), userID)
+	if err != nil {
+		return serviceError(err)
+	}
+
+	return c.JSON(safeUser)
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const principalKey = "principal"
+
+type AuthMiddleware struct {
+	jwtService     *services.JWTService
+	userService    *services.UserService
+	sessionService *services.SessionService
+	mfaService     *services.MFAService
+}
+
+func NewAuthMiddleware(
+	jwtService *services.JWTService,
+	userService *services.UserService,
+	sessionService *services.SessionService,
+	mfaService *services.MFAService,
+) *AuthMiddleware {
+	return &AuthMiddleware{
+		jwtService:     jwtService,
+		userService:    userService,
+		sessionService: sessionService,
+		mfaService:     mfaService,
+	}
+}
+
+// RequireAuth validates the bearer token and the session it was issued for,
+// loads the user it belongs to and stores the resulting principal in the
+// request context.
+func (m *AuthMiddleware) RequireAuth(c fiber.Ctx) error {
+	token, err := bearerToken(c)
+	if err != nil {
+		return err
+	}
+
+	principal, err := m.authenticate(c.Context(), token)
+	if err != nil {
+		return err
+	}
+
+	fiber.Locals(c, principalKey, principal)
+
+	return c.Next()
+}
+
+// OptionalAuth is RequireAuth for public routes whose response depends on
+// who is asking. Anonymous requests pass through without a principal, but a
+// bearer token, when sent, must be valid.
+func (m *AuthMiddleware) OptionalAuth(c fiber.Ctx) error {
+	if c.Get(fiber.HeaderAuthorization) == "" {
+		return c.Next()
+	}
+
+	return m.RequireAuth(c)
+}
+
+// RequireStreamAuth is RequireAuth for event streams. Browsers cannot set
+// headers on an EventSource, so a stream ticket may come in the ticket query
+// parameter instead. Tickets are short-lived and work once, so a stream URL
+// that ends up in logs grants nothing.
+func (m *AuthMiddleware) RequireStreamAuth(c fiber.Ctx) error {
+	ticket := c.Query("ticket")
+	if ticket == "" || c.Get(fiber.HeaderAuthorization) != "" {
+		return m.RequireAuth(c)
+	}
+
+	claims, err := m.sessionService.RedeemStreamTicket(c.Context(), ticket)
+	if errors.Is(err, services.ErrInvalidStreamTicket) {
+		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	principal, err := m.principal(c.Context(), claims)
+	if err != nil {
+		return err
+	}
+
+	fiber.Locals(c, principalKey, principal)
+
+	return c.Next()
+}
+
+func (m *AuthMiddleware) authenticate(ctx context.Context, token string) (*models.Principal, error) {
+	claims, err := m.jwtService.GetClaimsFromToken(token)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+	}
+
+	return m.principal(ctx, claims)
+}
+
+// principal checks that the session the claims belong to is active and
+// builds the principal of its user.
+func (m *AuthMiddleware) principal(ctx context.Context, claims *services.TokenClaims) (*models.Principal, error) {
+	if !m.sessionService.IsSessionActive(claims.SessionID, claims.UserID) {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "session expired or revoked")
+	}
+
+	user, err := m.userService.GetUserByID(claims.UserID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+	}
+
+	mfaSetupRequired, err := m.mfaService.IsSetupRequired(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Principal{
+		ID:               user.ID,
+		Email:            user.Email,
+		Role:             user.Role,
+		SessionID:        claims.SessionID,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		MFASetupRequired: mfaSetupRequired,
+		ExpiresAt:        claims.ExpiresAt,
+	}, nil
+}
+
+// GetPrincipal returns the principal stored by RequireAuth or OptionalAuth,
+// or nil for anonymous requests.
+func GetPrincipal(c fiber.Ctx) *models.Principal {
+	return fiber.Locals[*models.Principal](c, principalKey)
+}
+
+func bearerToken(c fiber.Ctx) (string, error) {
+	header := c.Get(fiber.HeaderAuthorization)
+	if header == "" {
+		return "", fiber.NewError(fiber.StatusUnauthorized, "missing authorization header")
+	}
+
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return "", fiber.NewError(fiber.StatusUnauthorized, "invalid authorization header")
+	}
+
+	return token, nil
+}
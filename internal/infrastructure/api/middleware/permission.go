@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RequirePermission rejects requests whose principal's role does not grant
+// the given permission. It must run after RequireAuth.
+func RequirePermission(permission constants.Permission) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if !GetPrincipal(c).Can(permission) {
+			return fiber.NewError(fiber.StatusForbidden, "forbidden")
+		}
+
+		return c.Next()
+	}
+}
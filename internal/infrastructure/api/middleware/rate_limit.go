@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+const defaultRateLimitRoute = "default"
+
+// rateLimitBudget allows Requests per Period to each client.
+type rateLimitBudget struct {
+	Requests int
+	Period   time.Duration
+}
+
+type rateLimitRoute struct {
+	pattern string
+	prefix  bool
+	budget  rateLimitBudget
+}
+
+// RateLimiter gives each client a budget of requests per route, counted in
+// fixed windows. Clients are told by IP address.
+type RateLimiter struct {
+	enabled bool
+	store   ports.RateLimitStore
+	log     *logger.Logger
+	// routes are ordered from the most specific, so that the first match
+	// wins.
+	routes        []rateLimitRoute
+	defaultBudget rateLimitBudget
+}
+
+func NewRateLimiter(cfg *config.Config, store ports.RateLimitStore, log *logger.Logger) (*RateLimiter, error) {
+	defaultBudget, err := parseRateLimitBudget(cfg.RateLimit.Default)
+	if err != nil {
+		return nil, fmt.Errorf("RATE_LIMIT_DEFAULT: %w", err)
+	}
+
+	routes := make([]rateLimitRoute, 0, len(cfg.RateLimit.Routes))
+	for pattern, value := range cfg.RateLimit.Routes {
+		budget, err := parseRateLimitBudget(value)
+		if err != nil {
+			return nil, fmt.Errorf("RATE_LIMIT_ROUTES %s: %w", pattern, err)
+		}
+
+		route := rateLimitRoute{pattern: normalizeRoutePath(pattern), budget: budget}
+		if strings.HasSuffix(pattern, "/*") {
+			route.pattern = strings.TrimSuffix(strings.ToLower(pattern), "*")
+			route.prefix = true
+		}
+		routes = append(routes, route)
+	}
+
+	// Exact paths come before prefixes, and longer prefixes before shorter
+	// ones.
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].prefix != routes[j].prefix {
+			return !routes[i].prefix
+		}
+		return len(routes[i].pattern) > len(routes[j].pattern)
+	})
+
+	return &RateLimiter{
+		enabled:       cfg.RateLimit.Enabled,
+		store:         store,
+		log:           log.With(zap.String("component", "rate_limit")),
+		routes:        routes,
+		defaultBudget: defaultBudget,
+	}, nil
+}
+
+// Handle counts the request against its route's budget and rejects it with
+// 429 once the budget is spent.
+func (l *RateLimiter) Handle(c fiber.Ctx) error {
+	if !l.enabled {
+		return c.Next()
+	}
+
+	route, budget := l.match(normalizeRoutePath(c.Path()))
+	now := time.Now()
+
+	counter, err := l.store.Increment(c.Context(), "rate:"+route+":"+c.IP(), now, budget.Period)
+	if err != nil {
+		// An unavailable store should not take the API down with it.
+		l.log.Error("Failed to count request", zap.Error(err))
+		return c.Next()
+	}
+
+	c.Set("X-RateLimit-Limit", strconv.Itoa(budget.Requests))
+	c.Set("X-RateLimit-Remaining", strconv.Itoa(max(budget.Requests-counter.Count, 0)))
+
+	if counter.Count > budget.Requests {
+		wait := counter.ExpiresAt.Sub(now)
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		return fiber.NewError(fiber.StatusTooManyRequests, "too many requests")
+	}
+
+	return c.Next()
+}
+
+func (l *RateLimiter) match(path string) (string, rateLimitBudget) {
+	for _, route := range l.routes {
+		if route.prefix && strings.HasPrefix(path, route.pattern) || !route.prefix && path == route.pattern {
+			return route.pattern, route.budget
+		}
+	}
+
+	return defaultRateLimitRoute, l.defaultBudget
+}
+
+// normalizeRoutePath spells the path the way the router matches it, which
+// ignores case and a trailing slash, so that "/AUTH/login/" counts against
+// the budget of "/auth/login".
+func normalizeRoutePath(path string) string {
+	path = strings.ToLower(path)
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return path
+}
+
+// parseRateLimitBudget parses a budget such as "300/1m".
+func parseRateLimitBudget(value string) (rateLimitBudget, error) {
+	requests, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return rateLimitBudget{}, fmt.Errorf("invalid budget %q, want requests/period", value)
+	}
+
+	n, err := strconv.Atoi(requests)
+	if err != nil || n <= 0 {
+		return rateLimitBudget{}, fmt.Errorf("invalid number of requests in %q", value)
+	}
+
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return rateLimitBudget{}, fmt.Errorf("invalid period in %q", value)
+	}
+
+	return rateLimitBudget{Requests: n, Period: d}, nil
+}
@@ -2,16 +2,30 @@ package api
 
 import (
 	"github.com/EventFlow-Project/backend/internal/infrastructure/api/handlers"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/api/middleware"
 
 	"go.uber.org/fx"
 )
 
 var Module = fx.Module("api",
 	fx.Provide(
+		middleware.NewAuthMiddleware,
+		middleware.NewRateLimiter,
 		handlers.NewHTTPHandler,
 		handlers.NewAuthHandler,
 		handlers.NewUserHandler,
 		handlers.NewEventHandler,
+		handlers.NewRegistrationHandler,
+		handlers.NewTicketHandler,
+		handlers.NewPaymentHandler,
+		handlers.NewCalendarHandler,
+		handlers.NewModerationHandler,
+		handlers.NewReportHandler,
+		handlers.NewNotificationHandler,
+		handlers.NewRealtimeHandler,
+		handlers.NewSecurityHandler,
+		handlers.NewMFAHandler,
+		handlers.NewOIDCHandler,
 		NewApp,
 	),
 	fx.Invoke(StartServer),
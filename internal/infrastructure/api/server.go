@@ -15,13 +15,18 @@ import (
 	"go.uber.org/fx"
 )
 
-func NewApp(handler *handlers.HTTPHandler) *fiber.App {
+func NewApp(cfg *config.Config, handler *handlers.HTTPHandler, rateLimiter *middleware.RateLimiter) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  120 * time.Second,
 		BodyLimit:    10 * 1024 * 1024,
 		ErrorHandler: middleware.ErrorHandler,
+		ProxyHeader:  cfg.ProxyHeader,
+		TrustProxy:   len(cfg.TrustedProxies) > 0,
+		TrustProxyConfig: fiber.TrustProxyConfig{
+			Proxies: cfg.TrustedProxies,
+		},
 	})
 
 	app.Use(cors.New(cors.Config{
@@ -32,6 +37,9 @@ func NewApp(handler *handlers.HTTPHandler) *fiber.App {
 		MaxAge:           300,
 	}))
 
+	// After CORS, so that preflight requests are not counted.
+	app.Use(rateLimiter.Handle)
+
 	handler.RegisterRoutes(app)
 
 	return app
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/EventFlow-Project/backend/internal/config"
@@ -17,16 +18,7 @@ type Database struct {
 }
 
 func NewDatabase(cfg *config.Config, log *logger.Logger) (*Database, error) {
-	dsn := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Name,
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	db, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{
 		Logger: gormlogger.Default.LogMode(gormlogger.Info),
 	})
 	if err != nil {
@@ -42,6 +34,30 @@ func NewDatabase(cfg *config.Config, log *logger.Logger) (*Database, error) {
 	return &Database{DB: db}, nil
 }
 
+type txKey struct{}
+
+// Conn returns the transaction the context runs in, if any, so that
+// repositories take part in it, and the connection pool otherwise.
+func (d *Database) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+
+	return d.DB.WithContext(ctx)
+}
+
+// WithinTransaction runs fn in a transaction that repositories using Conn
+// join. It joins the surrounding transaction when there is one.
+func (d *Database) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
 func (d *Database) Close() error {
 	sqlDB, err := d.DB.DB()
 	if err != nil {
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/google/uuid"
+)
+
+// FileMailer writes every email to its own .eml file instead of sending it,
+// for development. The files open in any mail client.
+type FileMailer struct {
+	from *mail.Address
+	dir  string
+}
+
+func NewFileMailer(dir string, from *mail.Address) *FileMailer {
+	return &FileMailer{from: from, dir: dir}
+}
+
+func (m *FileMailer) Send(ctx context.Context, email *models.Email) error {
+	now := time.Now()
+	message, err := buildMessage(m.from, email, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000"), uuid.New().String())
+	return os.WriteFile(filepath.Join(m.dir, name), message, 0o644)
+}
@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"context"
+	"sync"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+// MemoryMailer keeps sent emails in memory, for tests and local runs where
+// nobody reads them.
+type MemoryMailer struct {
+	mu   sync.Mutex
+	sent []models.Email
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, email *models.Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, *email)
+	return nil
+}
+
+// Sent returns the emails sent so far, oldest first.
+func (m *MemoryMailer) Sent() []models.Email {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]models.Email(nil), m.sent...)
+}
@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"fmt"
+	"net/mail"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("mail",
+	fx.Provide(
+		NewMailer,
+		NewTemplateRenderer,
+	),
+)
+
+// NewMailer returns the mailer selected by MAIL_DRIVER.
+func NewMailer(cfg *config.Config) (ports.Mailer, error) {
+	from, err := mail.ParseAddress(cfg.Mail.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_FROM: %w", err)
+	}
+
+	switch cfg.Mail.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg.Mail, from)
+	case "file":
+		return NewFileMailer(cfg.Mail.FileDir, from), nil
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Mail.Driver)
+	}
+}
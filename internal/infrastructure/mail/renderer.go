@@ -0,0 +1,103 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+// Every email has a subject and plain text body in <locale>/<name>.txt and
+// an HTML body in <locale>/<name>.html, which is laid out by layout.html.
+//
+//go:embed templates
+var templateFiles embed.FS
+
+var emailTemplates = []constants.EmailTemplate{
+	constants.EmailTemplateVerifyEmail,
+	constants.EmailTemplateWelcome,
+	constants.EmailTemplatePasswordReset,
+	constants.EmailTemplateConfirmEmailChange,
+	constants.EmailTemplateEmailChanged,
+	constants.EmailTemplateEventApproved,
+	constants.EmailTemplateEventRejected,
+	constants.EmailTemplateEventReminder,
+}
+
+var locales = []constants.Locale{constants.LocaleRU, constants.LocaleEN}
+
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+type TemplateRenderer struct {
+	templates map[constants.Locale]map[constants.EmailTemplate]*emailTemplate
+}
+
+// NewTemplateRenderer parses all templates up front, so that a broken one
+// stops the application from starting rather than an email from being sent.
+func NewTemplateRenderer() (ports.EmailRenderer, error) {
+	renderer := &TemplateRenderer{
+		templates: make(map[constants.Locale]map[constants.EmailTemplate]*emailTemplate),
+	}
+
+	for _, locale := range locales {
+		renderer.templates[locale] = make(map[constants.EmailTemplate]*emailTemplate)
+
+		for _, name := range emailTemplates {
+			path := fmt.Sprintf("templates/%s/%s", locale, name)
+
+			text, err := texttemplate.New(string(name)).Option("missingkey=zero").ParseFS(templateFiles, path+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %w", path, err)
+			}
+
+			html, err := htmltemplate.New(string(name)).Option("missingkey=zero").ParseFS(templateFiles, "templates/layout.html", path+".html")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %w", path, err)
+			}
+
+			renderer.templates[locale][name] = &emailTemplate{text: text, html: html}
+		}
+	}
+
+	return renderer, nil
+}
+
+func (r *TemplateRenderer) Render(message *models.EmailMessage) (*models.Email, error) {
+	template, ok := r.templates[message.Locale][message.Template]
+	if !ok {
+		template, ok = r.templates[constants.DefaultLocale][message.Template]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", message.Template)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := template.text.ExecuteTemplate(&subject, "subject", message.Data); err != nil {
+		return nil, err
+	}
+
+	if err := template.text.ExecuteTemplate(&text, "text", message.Data); err != nil {
+		return nil, err
+	}
+
+	if err := template.html.ExecuteTemplate(&html, "layout", message.Data); err != nil {
+		return nil, err
+	}
+
+	return &models.Email{
+		To:      message.To,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
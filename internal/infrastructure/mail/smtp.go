@@ -0,0 +1,131 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+const (
+	smtpTLSNone     = "none"
+	smtpTLSStartTLS = "starttls"
+	smtpTLSImplicit = "tls"
+
+	// smtpTimeout bounds a whole delivery when the context has no deadline.
+	smtpTimeout = 30 * time.Second
+)
+
+type SMTPMailer struct {
+	from     *mail.Address
+	host     string
+	addr     string
+	username string
+	password string
+	tlsMode  string
+}
+
+func NewSMTPMailer(cfg config.MailConfig, from *mail.Address) (*SMTPMailer, error) {
+	if cfg.SMTPHost == "" {
+		return nil, errors.New("SMTP_HOST is required for the smtp mail driver")
+	}
+
+	switch cfg.SMTPTLS {
+	case smtpTLSNone, smtpTLSStartTLS, smtpTLSImplicit:
+	default:
+		return nil, errors.New("SMTP_TLS must be one of none, starttls, tls")
+	}
+
+	return &SMTPMailer{
+		from:     from,
+		host:     cfg.SMTPHost,
+		addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		tlsMode:  cfg.SMTPTLS,
+	}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, email *models.Email) error {
+	message, err := buildMessage(m.from, email, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	if m.tlsMode == smtpTLSImplicit {
+		conn = tls.Client(conn, &tls.Config{ServerName: m.host})
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if m.tlsMode == smtpTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP server does not support STARTTLS")
+		}
+
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return err
+		}
+	}
+
+	recipient, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Mail(m.from.Address); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(recipient.Address); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,64 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+
+	"go.uber.org/zap"
+)
+
+// Bus is an in-process ports.MessageBus. Handlers run synchronously in the
+// publisher's goroutine, so they should hand long work off.
+type Bus struct {
+	log      *logger.Logger
+	mu       sync.RWMutex
+	handlers map[constants.Topic][]ports.MessageHandler
+}
+
+func NewBus(log *logger.Logger) ports.MessageBus {
+	return &Bus{
+		log:      log,
+		handlers: make(map[constants.Topic][]ports.MessageHandler),
+	}
+}
+
+func (b *Bus) Subscribe(topic constants.Topic, handler ports.MessageHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[topic] = append(b.handlers[topic], handler)
+}
+
+func (b *Bus) Publish(ctx context.Context, topic constants.Topic, payload any) {
+	b.mu.RLock()
+	handlers := b.handlers[topic]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.dispatch(ctx, topic, handler, payload)
+	}
+}
+
+// dispatch keeps a failing or panicking handler from breaking the publisher
+// or the remaining handlers.
+func (b *Bus) dispatch(ctx context.Context, topic constants.Topic, handler ports.MessageHandler, payload any) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.log.Error("Message handler panicked",
+				zap.String("topic", string(topic)),
+				zap.Any("panic", r),
+			)
+		}
+	}()
+
+	if err := handler(ctx, payload); err != nil {
+		b.log.Error("Message handler failed",
+			zap.String("topic", string(topic)),
+			zap.Error(err),
+		)
+	}
+}
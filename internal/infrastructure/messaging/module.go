@@ -0,0 +1,7 @@
+package messaging
+
+import "go.uber.org/fx"
+
+var Module = fx.Module("messaging",
+	fx.Provide(NewBus),
+)
@@ -0,0 +1,237 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	MockProviderName = "mock"
+	MockClientID     = "eventflow"
+
+	mockKeyID        = "mock"
+	mockDefaultEmail = "user@example.com"
+	mockCodeTTL      = time.Minute
+	mockTokenTTL     = 5 * time.Minute
+)
+
+// MockIssuer is an OpenID Connect provider for local development and tests.
+// It skips the login page and logs in as the address in the login_hint
+// parameter, or user@example.com, always with a verified email. The flow is
+// otherwise checked as a real provider would: PKCE, redirect URI, single-use
+// codes and signed ID tokens.
+type MockIssuer struct {
+	issuer string
+	key    *rsa.PrivateKey
+	mux    *http.ServeMux
+
+	mu        sync.Mutex
+	codes     map[string]mockGrant
+	overrides map[string]interface{}
+}
+
+type mockGrant struct {
+	email         string
+	nonce         string
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+func NewMockIssuer(issuer string) (*MockIssuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MockIssuer{
+		issuer: strings.TrimSuffix(issuer, "/"),
+		key:    key,
+		mux:    http.NewServeMux(),
+		codes:  make(map[string]mockGrant),
+	}
+
+	m.mux.HandleFunc("GET /.well-known/openid-configuration", m.discovery)
+	m.mux.HandleFunc("GET /authorize", m.authorize)
+	m.mux.HandleFunc("POST /token", m.token)
+	m.mux.HandleFunc("GET /jwks", m.jwks)
+
+	return m, nil
+}
+
+func (m *MockIssuer) Issuer() string {
+	return m.issuer
+}
+
+// OverrideClaims replaces claims of the ID tokens issued from now on, to
+// test clients against a provider that gets them wrong, such as one that has
+// not verified the email.
+func (m *MockIssuer) OverrideClaims(claims map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.overrides = claims
+}
+
+func (m *MockIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mux.ServeHTTP(w, r)
+}
+
+func (m *MockIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                m.issuer,
+		"authorization_endpoint":                m.issuer + "/authorize",
+		"token_endpoint":                        m.issuer + "/token",
+		"jwks_uri":                              m.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (m *MockIssuer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	if query.Get("response_type") != "code" || query.Get("client_id") == "" ||
+		query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "expected the authorization code flow with S256 PKCE", http.StatusBadRequest)
+		return
+	}
+
+	email := query.Get("login_hint")
+	if email == "" {
+		email = mockDefaultEmail
+	}
+
+	code := randomString()
+	m.mu.Lock()
+	m.codes[code] = mockGrant{
+		email:         email,
+		nonce:         query.Get("nonce"),
+		clientID:      query.Get("client_id"),
+		redirectURI:   redirectURI.String(),
+		codeChallenge: query.Get("code_challenge"),
+		expiresAt:     time.Now().Add(mockCodeTTL),
+	}
+	m.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (m *MockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, "invalid_request")
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeTokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	m.mu.Lock()
+	grant, ok := m.codes[code]
+	delete(m.codes, code)
+	overrides := m.overrides
+	m.mu.Unlock()
+
+	if !ok || time.Now().After(grant.expiresAt) ||
+		grant.clientID != r.PostForm.Get("client_id") ||
+		grant.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(challenge[:]) != grant.codeChallenge {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	subject := sha256.Sum256([]byte(strings.ToLower(grant.email)))
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            m.issuer,
+		"sub":            hex.EncodeToString(subject[:16]),
+		"aud":            grant.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(mockTokenTTL).Unix(),
+		"email":          grant.email,
+		"email_verified": true,
+		"name":           strings.SplitN(grant.email, "@", 2)[0],
+	}
+	if grant.nonce != "" {
+		claims["nonce"] = grant.nonce
+	}
+	for name, value := range overrides {
+		claims[name] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = mockKeyID
+	idToken, err := token.SignedString(m.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   int(mockTokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (m *MockIssuer) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []jsonWebKey{{
+			Kty: "RSA",
+			Use: "sig",
+			Kid: mockKeyID,
+			N:   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+		}},
+	})
+}
+
+func writeTokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	random := make([]byte, 24)
+	_, _ = rand.Read(random)
+	return base64.RawURLEncoding.EncodeToString(random)
+}
@@ -0,0 +1,106 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+
+	"go.uber.org/fx"
+)
+
+const (
+	googleIssuer = "https://accounts.google.com"
+	httpTimeout  = 10 * time.Second
+)
+
+var Module = fx.Module("oidc",
+	fx.Provide(NewProviders),
+)
+
+// NewProviders returns the providers that have a client ID configured, and
+// the mock provider when OIDC_MOCK_ADDRESS is set outside production.
+func NewProviders(lc fx.Lifecycle, cfg *config.Config) (ports.OIDCProviders, error) {
+	client := &http.Client{Timeout: httpTimeout}
+	providers := ports.OIDCProviders{}
+
+	google := cfg.OIDC.Google
+	if google.Issuer == "" {
+		google.Issuer = googleIssuer
+	}
+
+	for name, providerConfig := range map[string]config.OIDCProviderConfig{
+		"google": google,
+		"vk":     cfg.OIDC.VK,
+		"yandex": cfg.OIDC.Yandex,
+	} {
+		if providerConfig.ClientID == "" {
+			continue
+		}
+
+		if providerConfig.Issuer == "" {
+			return nil, fmt.Errorf("OIDC provider %s has no issuer configured", name)
+		}
+
+		providers[name] = NewProvider(name, providerConfig, client)
+	}
+
+	if cfg.OIDC.MockAddress != "" {
+		if !cfg.IsDevelopment() {
+			return nil, errors.New("OIDC_MOCK_ADDRESS is only allowed when APP_ENV is development or test")
+		}
+
+		issuer, err := startMockIssuer(lc, cfg.OIDC.MockAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		providers[MockProviderName] = NewProvider(MockProviderName, config.OIDCProviderConfig{
+			ClientID: MockClientID,
+			Issuer:   issuer.Issuer(),
+			Scopes:   []string{"openid", "email", "profile"},
+		}, client)
+	}
+
+	return providers, nil
+}
+
+func startMockIssuer(lc fx.Lifecycle, address string) (*MockIssuer, error) {
+	issuer, err := NewMockIssuer("http://" + address)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           issuer,
+		ReadHeaderTimeout: httpTimeout,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", address)
+			if err != nil {
+				return fmt.Errorf("failed to start the mock OIDC provider: %w", err)
+			}
+
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					fmt.Printf("Mock OIDC provider error: %v\n", err)
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	})
+
+	return issuer, nil
+}
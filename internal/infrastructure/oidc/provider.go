@@ -0,0 +1,224 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Provider is a standard OpenID Connect provider, such as Google.
+type Provider struct {
+	name   string
+	config config.OIDCProviderConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	endpoints *endpoints
+	keys      *keySet
+}
+
+type endpoints struct {
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+func NewProvider(name string, cfg config.OIDCProviderConfig, client *http.Client) *Provider {
+	return &Provider{
+		name:   name,
+		config: cfg,
+		client: client,
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge, redirectURL string) (string, error) {
+	endpoints, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(endpoints.AuthURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", redirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, redirectURL string) (*models.OIDCClaims, error) {
+	endpoints, keys, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"client_id":     {p.config.ClientID},
+		"client_secret": {p.config.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoints.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tokens struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+
+	if tokens.Error != "" {
+		return nil, fmt.Errorf("provider rejected the code: %s", strings.TrimSpace(tokens.Error+" "+tokens.ErrorDescription))
+	}
+
+	if resp.StatusCode != http.StatusOK || tokens.IDToken == "" {
+		return nil, fmt.Errorf("provider returned no ID token (%s)", resp.Status)
+	}
+
+	return p.verifyIDToken(ctx, keys, tokens.IDToken)
+}
+
+// verifyIDToken checks the token's signature against the provider's keys,
+// and its issuer, audience and expiry.
+func (p *Provider) verifyIDToken(ctx context.Context, keys *keySet, idToken string) (*models.OIDCClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			// HMAC would take the public key as the secret, and none is no
+			// signature at all.
+			return nil, fmt.Errorf("unexpected signing method %q", token.Method.Alg())
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		return keys.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+
+	if !claims.VerifyIssuer(p.config.Issuer, true) {
+		return nil, errors.New("invalid ID token: wrong issuer")
+	}
+
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, errors.New("invalid ID token: wrong audience")
+	}
+
+	if !claims.VerifyExpiresAt(jwt.TimeFunc().Unix(), true) {
+		return nil, errors.New("invalid ID token: no expiry")
+	}
+
+	if azp, ok := claims["azp"].(string); ok && azp != p.config.ClientID {
+		return nil, errors.New("invalid ID token: wrong authorized party")
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("invalid ID token: no subject")
+	}
+
+	result := &models.OIDCClaims{Subject: subject}
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.Locale, _ = claims["locale"].(string)
+	result.Nonce, _ = claims["nonce"].(string)
+
+	// Some providers send booleans as strings.
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+
+	return result, nil
+}
+
+// discover returns the provider's endpoints, reading those not configured
+// from its discovery document the first time they are needed.
+func (p *Provider) discover(ctx context.Context) (*endpoints, *keySet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.endpoints != nil {
+		return p.endpoints, p.keys, nil
+	}
+
+	found := &endpoints{
+		AuthURL:  p.config.AuthURL,
+		TokenURL: p.config.TokenURL,
+		JWKSURL:  p.config.JWKSURL,
+	}
+
+	if found.AuthURL == "" || found.TokenURL == "" || found.JWKSURL == "" {
+		var document struct {
+			Issuer string `json:"issuer"`
+			endpoints
+		}
+		discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := getJSON(ctx, p.client, discoveryURL, &document); err != nil {
+			return nil, nil, fmt.Errorf("failed to discover %s endpoints: %w", p.name, err)
+		}
+
+		if document.Issuer != p.config.Issuer {
+			return nil, nil, fmt.Errorf("discovery document of %s is for issuer %q", p.name, document.Issuer)
+		}
+
+		if found.AuthURL == "" {
+			found.AuthURL = document.AuthURL
+		}
+		if found.TokenURL == "" {
+			found.TokenURL = document.TokenURL
+		}
+		if found.JWKSURL == "" {
+			found.JWKSURL = document.JWKSURL
+		}
+	}
+
+	p.endpoints = found
+	p.keys = newKeySet(found.JWKSURL, p.client)
+
+	return p.endpoints, p.keys, nil
+}
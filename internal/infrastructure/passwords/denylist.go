@@ -0,0 +1,63 @@
+package passwords
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+//go:embed denylists/common.txt
+var denylists embed.FS
+
+// Denylist is a set of passwords that must not be used, held in memory.
+type Denylist struct {
+	passwords map[string]struct{}
+}
+
+func NewDenylist(list io.Reader) (*Denylist, error) {
+	denylist := &Denylist{passwords: make(map[string]struct{})}
+
+	scanner := bufio.NewScanner(list)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		denylist.passwords[strings.ToLower(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse password denylist: %w", err)
+	}
+
+	return denylist, nil
+}
+
+func (d *Denylist) Contains(password string) bool {
+	_, ok := d.passwords[strings.ToLower(password)]
+	return ok
+}
+
+// NewPasswordDenylist loads the configured denylist file, or the built-in
+// list when none is configured.
+func NewPasswordDenylist(cfg *config.Config) (ports.PasswordDenylist, error) {
+	var data []byte
+	var err error
+	if cfg.Auth.PasswordDenylistFile == "" {
+		data, err = denylists.ReadFile("denylists/common.txt")
+	} else {
+		data, err = os.ReadFile(cfg.Auth.PasswordDenylistFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password denylist: %w", err)
+	}
+
+	return NewDenylist(bytes.NewReader(data))
+}
@@ -0,0 +1,7 @@
+package passwords
+
+import "go.uber.org/fx"
+
+var Module = fx.Module("passwords",
+	fx.Provide(NewPasswordDenylist),
+)
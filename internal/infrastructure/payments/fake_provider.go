@@ -0,0 +1,139 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+
+	"github.com/google/uuid"
+)
+
+const FakeProviderName = "fake"
+
+// FakeProvider is an in-memory payment provider for local development and
+// tests. Payments never leave the process; the Simulate* helpers produce
+// signed webhooks exactly as a real provider would send them.
+type FakeProvider struct {
+	mu      sync.Mutex
+	secret  []byte
+	intents map[string]*models.PaymentIntent
+}
+
+func NewFakeProvider(webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		secret:  []byte(webhookSecret),
+		intents: make(map[string]*models.PaymentIntent),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *FakeProvider) CreateIntent(ctx context.Context, req models.PaymentIntentRequest) (*models.PaymentIntent, error) {
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+
+	intent := &models.PaymentIntent{
+		ID:           "fake_pi_" + uuid.New().String(),
+		Amount:       req.Amount,
+		Currency:     req.Currency,
+		Status:       constants.PaymentIntentStatusRequiresConfirmation,
+		ClientSecret: uuid.New().String(),
+	}
+
+	p.mu.Lock()
+	p.intents[intent.ID] = intent
+	p.mu.Unlock()
+
+	copied := *intent
+	return &copied, nil
+}
+
+func (p *FakeProvider) ConfirmIntent(ctx context.Context, intentID string) (*models.PaymentIntent, error) {
+	return p.setStatus(intentID, constants.PaymentIntentStatusRequiresConfirmation, constants.PaymentIntentStatusSucceeded)
+}
+
+// Decline makes a pending intent fail, as if the card was rejected.
+func (p *FakeProvider) Decline(intentID string) (*models.PaymentIntent, error) {
+	return p.setStatus(intentID, constants.PaymentIntentStatusRequiresConfirmation, constants.PaymentIntentStatusFailed)
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, intentID string, amount int64) (*models.PaymentIntent, error) {
+	p.mu.Lock()
+	intent, ok := p.intents[intentID]
+	p.mu.Unlock()
+
+	if ok && amount != intent.Amount {
+		return nil, errors.New("partial refunds are not supported")
+	}
+
+	return p.setStatus(intentID, constants.PaymentIntentStatusSucceeded, constants.PaymentIntentStatusRefunded)
+}
+
+func (p *FakeProvider) VerifyWebhook(payload []byte, signature string) (*models.PaymentWebhookEvent, error) {
+	expected := p.Sign(payload)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, errors.New("invalid webhook signature")
+	}
+
+	var event models.PaymentWebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	return &event, nil
+}
+
+// Sign returns the signature the provider attaches to a webhook payload.
+func (p *FakeProvider) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SimulateWebhook builds a signed webhook of the given type for an intent.
+func (p *FakeProvider) SimulateWebhook(intentID string, eventType constants.PaymentEventType) ([]byte, string, error) {
+	payload, err := json.Marshal(models.PaymentWebhookEvent{
+		ID:        "fake_evt_" + uuid.New().String(),
+		Type:      eventType,
+		PaymentID: intentID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, p.Sign(payload), nil
+}
+
+func (p *FakeProvider) setStatus(intentID string, from, to constants.PaymentIntentStatus) (*models.PaymentIntent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return nil, errors.New("payment intent not found")
+	}
+
+	if intent.Status == to {
+		copied := *intent
+		return &copied, nil
+	}
+
+	if intent.Status != from {
+		return nil, fmt.Errorf("payment intent is %s", intent.Status)
+	}
+
+	intent.Status = to
+	copied := *intent
+	return &copied, nil
+}
@@ -0,0 +1,7 @@
+package payments
+
+import "go.uber.org/fx"
+
+var Module = fx.Module("payments",
+	fx.Provide(NewPaymentProvider),
+)
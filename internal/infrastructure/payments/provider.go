@@ -0,0 +1,21 @@
+package payments
+
+import (
+	"fmt"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+func NewPaymentProvider(cfg *config.Config) (ports.PaymentProvider, error) {
+	if err := cfg.Payments.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Payments.Provider {
+	case FakeProviderName:
+		return NewFakeProvider(cfg.Payments.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider: %q", cfg.Payments.Provider)
+	}
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+// MemoryStore keeps counters in the process. Each replica counts on its
+// own, so limits are per replica.
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]models.RateLimitCounter
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]models.RateLimitCounter)}
+}
+
+func (s *MemoryStore) Increment(_ context.Context, key string, now time.Time, ttl time.Duration) (models.RateLimitCounter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.ExpiresAt) {
+		counter = models.RateLimitCounter{ExpiresAt: now.Add(ttl)}
+	}
+	counter.Count++
+	counter.UpdatedAt = now
+	s.counters[key] = counter
+
+	return counter, nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string, now time.Time) (models.RateLimitCounter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.ExpiresAt) {
+		return models.RateLimitCounter{}, nil
+	}
+
+	return counter, nil
+}
+
+func (s *MemoryStore) Decrement(_ context.Context, key string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if ok && now.Before(counter.ExpiresAt) && counter.Count > 0 {
+		counter.Count--
+		s.counters[key] = counter
+	}
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, key)
+	return nil
+}
+
+func (s *MemoryStore) DeleteExpired(_ context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, counter := range s.counters {
+		if !now.Before(counter.ExpiresAt) {
+			delete(s.counters, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package ratelimit
+
+import (
+	"fmt"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+
+	"go.uber.org/fx"
+)
+
+const (
+	storeMemory   = "memory"
+	storePostgres = "postgres"
+)
+
+var Module = fx.Module("ratelimit",
+	fx.Provide(NewStore),
+)
+
+// NewStore picks the counter store by RATE_LIMIT_STORE: "memory" for a
+// single replica, or "postgres" to share counters between replicas.
+func NewStore(cfg *config.Config, db *database.Database) (ports.RateLimitStore, error) {
+	switch cfg.RateLimit.Store {
+	case storeMemory:
+		return NewMemoryStore(), nil
+	case storePostgres:
+		return NewPostgresStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.RateLimit.Store)
+	}
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+)
+
+// PostgresStore keeps counters in the rate_limit_counters table, so that
+// all replicas share them.
+type PostgresStore struct {
+	db *database.Database
+}
+
+func NewPostgresStore(db *database.Database) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Increment(ctx context.Context, key string, now time.Time, ttl time.Duration) (models.RateLimitCounter, error) {
+	if s.db == nil || s.db.DB == nil {
+		return models.RateLimitCounter{}, errors.New("database connection is not initialized")
+	}
+
+	var counter models.RateLimitCounter
+	err := s.db.DB.WithContext(ctx).Raw(`
+		INSERT INTO rate_limit_counters (key, count, updated_at, expires_at)
+		VALUES (@key, 1, @now, @expiresAt)
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN rate_limit_counters.expires_at <= @now THEN 1 ELSE rate_limit_counters.count + 1 END,
+			expires_at = CASE WHEN rate_limit_counters.expires_at <= @now THEN @expiresAt ELSE rate_limit_counters.expires_at END,
+			updated_at = @now
+		RETURNING count, updated_at, expires_at`,
+		sql.Named("key", key),
+		sql.Named("now", now),
+		sql.Named("expiresAt", now.Add(ttl)),
+	).Scan(&counter).Error
+
+	return counter, err
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string, now time.Time) (models.RateLimitCounter, error) {
+	if s.db == nil || s.db.DB == nil {
+		return models.RateLimitCounter{}, errors.New("database connection is not initialized")
+	}
+
+	var counter models.RateLimitCounter
+	err := s.db.DB.WithContext(ctx).Raw(`
+		SELECT count, updated_at, expires_at FROM rate_limit_counters
+		WHERE key = ? AND expires_at > ?`, key, now,
+	).Scan(&counter).Error
+
+	return counter, err
+}
+
+func (s *PostgresStore) Decrement(ctx context.Context, key string, now time.Time) error {
+	if s.db == nil || s.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return s.db.DB.WithContext(ctx).Exec(`
+		UPDATE rate_limit_counters SET count = count - 1
+		WHERE key = ? AND expires_at > ? AND count > 0`, key, now,
+	).Error
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, key string) error {
+	if s.db == nil || s.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return s.db.DB.WithContext(ctx).Exec(`DELETE FROM rate_limit_counters WHERE key = ?`, key).Error
+}
+
+func (s *PostgresStore) DeleteExpired(ctx context.Context, now time.Time) error {
+	if s.db == nil || s.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return s.db.DB.WithContext(ctx).Exec(`DELETE FROM rate_limit_counters WHERE expires_at <= ?`, now).Error
+}
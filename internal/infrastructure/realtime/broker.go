@@ -0,0 +1,159 @@
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	fanoutPostgres = "postgres"
+	fanoutMemory   = "memory"
+
+	// notifyChannel is the Postgres channel replicas relay messages over.
+	notifyChannel = "eventflow_realtime"
+	// maxNotifyPayload is the largest payload Postgres accepts in NOTIFY.
+	maxNotifyPayload = 7999
+
+	minListenBackoff = time.Second
+	maxListenBackoff = 30 * time.Second
+)
+
+// Broker is a ports.RealtimeBroker. With Postgres fan-out every message goes
+// through NOTIFY, including to the replica that published it, so that all
+// replicas deliver it exactly once; otherwise messages are delivered
+// in-process.
+type Broker struct {
+	hub    *hub
+	fanout string
+	dsn    string
+	db     *database.Database
+	log    *logger.Logger
+}
+
+func NewBroker(cfg *config.Config, db *database.Database, log *logger.Logger) (*Broker, error) {
+	if cfg.Realtime.Fanout != fanoutPostgres && cfg.Realtime.Fanout != fanoutMemory {
+		return nil, fmt.Errorf("unknown realtime fanout %q", cfg.Realtime.Fanout)
+	}
+
+	return &Broker{
+		hub:    newHub(cfg.Realtime.BufferSize),
+		fanout: cfg.Realtime.Fanout,
+		dsn:    cfg.Database.DSN(),
+		db:     db,
+		log:    log.With(zap.String("component", "realtime")),
+	}, nil
+}
+
+func NewRealtimeBroker(broker *Broker) ports.RealtimeBroker {
+	return broker
+}
+
+func (b *Broker) Publish(ctx context.Context, message *models.RealtimeMessage) error {
+	if b.fanout == fanoutMemory {
+		b.hub.deliver(message)
+		return nil
+	}
+
+	if b.db == nil || b.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	if len(payload) > maxNotifyPayload {
+		return fmt.Errorf("realtime message of %d bytes exceeds the NOTIFY limit", len(payload))
+	}
+
+	return b.db.DB.WithContext(ctx).Exec(
+		"SELECT pg_notify(@channel, @payload)",
+		sql.Named("channel", notifyChannel),
+		sql.Named("payload", string(payload)),
+	).Error
+}
+
+func (b *Broker) Subscribe(channels []string) ports.RealtimeSubscription {
+	return b.hub.subscribe(channels)
+}
+
+// Listen relays messages other replicas publish until the context is
+// cancelled, reconnecting when the connection drops. Messages published
+// while disconnected are lost; clients catch up through the REST API.
+func (b *Broker) Listen(ctx context.Context) {
+	if b.fanout != fanoutPostgres {
+		return
+	}
+
+	backoff := minListenBackoff
+	for {
+		connected, err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if connected {
+			backoff = minListenBackoff
+		}
+
+		b.log.Error("Realtime listener disconnected", zap.Error(err), zap.Duration("retryIn", backoff))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxListenBackoff)
+	}
+}
+
+// listen holds a dedicated connection, as LISTEN does not survive being
+// returned to the pool, and reports whether it got as far as listening.
+func (b *Broker) listen(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return false, err
+	}
+
+	b.log.Info("Listening for realtime messages", zap.String("channel", notifyChannel))
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		var message models.RealtimeMessage
+		if err := json.Unmarshal([]byte(notification.Payload), &message); err != nil {
+			b.log.Warn("Skipping malformed realtime message", zap.Error(err))
+			continue
+		}
+
+		b.hub.deliver(&message)
+	}
+}
+
+// Close disconnects every subscriber.
+func (b *Broker) Close() {
+	b.hub.close()
+}
@@ -0,0 +1,132 @@
+package realtime
+
+import (
+	"sync"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+// hub delivers messages to the subscriptions of this replica.
+type hub struct {
+	bufferSize int
+
+	mu          sync.RWMutex
+	subscribers map[string]map[*subscription]struct{}
+	closed      bool
+}
+
+func newHub(bufferSize int) *hub {
+	return &hub{
+		bufferSize:  bufferSize,
+		subscribers: make(map[string]map[*subscription]struct{}),
+	}
+}
+
+type subscription struct {
+	hub      *hub
+	channels []string
+	messages chan models.RealtimeMessage
+}
+
+func (s *subscription) Messages() <-chan models.RealtimeMessage {
+	return s.messages
+}
+
+func (s *subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+func (h *hub) subscribe(channels []string) *subscription {
+	sub := &subscription{
+		hub:      h,
+		channels: channels,
+		messages: make(chan models.RealtimeMessage, h.bufferSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(sub.messages)
+		return sub
+	}
+
+	for _, channel := range channels {
+		if h.subscribers[channel] == nil {
+			h.subscribers[channel] = make(map[*subscription]struct{})
+		}
+		h.subscribers[channel][sub] = struct{}{}
+	}
+
+	return sub
+}
+
+func (h *hub) unsubscribe(sub *subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(sub)
+}
+
+// remove drops the subscription and closes its messages. It must be called
+// with the lock held.
+func (h *hub) remove(sub *subscription) {
+	removed := false
+	for _, channel := range sub.channels {
+		subscribers := h.subscribers[channel]
+		if _, ok := subscribers[sub]; !ok {
+			continue
+		}
+
+		removed = true
+		delete(subscribers, sub)
+		if len(subscribers) == 0 {
+			delete(h.subscribers, channel)
+		}
+	}
+
+	if removed {
+		close(sub.messages)
+	}
+}
+
+// deliver hands the message to the channel's subscriptions without
+// blocking. Subscribers whose buffer is full are dropped rather than
+// holding up everybody else; their clients reconnect.
+func (h *hub) deliver(message *models.RealtimeMessage) {
+	var slow []*subscription
+
+	h.mu.RLock()
+	for sub := range h.subscribers[message.Channel] {
+		select {
+		case sub.messages <- *message:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(slow) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, sub := range slow {
+		h.remove(sub)
+	}
+}
+
+// close drops every subscription and refuses new ones.
+func (h *hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, subscribers := range h.subscribers {
+		for sub := range subscribers {
+			h.remove(sub)
+		}
+	}
+}
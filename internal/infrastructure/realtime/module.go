@@ -0,0 +1,44 @@
+package realtime
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("realtime",
+	fx.Provide(
+		NewBroker,
+		NewRealtimeBroker,
+	),
+	fx.Invoke(StartBroker),
+)
+
+// StartBroker runs the Postgres listener and, on shutdown, disconnects the
+// realtime clients. Streams keep their connections busy, so this has to run
+// before the HTTP server shuts down; the module is registered after the API
+// for that reason.
+func StartBroker(lc fx.Lifecycle, broker *Broker) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				broker.Listen(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			broker.Close()
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
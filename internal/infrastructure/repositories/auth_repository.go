@@ -1,15 +1,16 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"time"
 
+	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
 	"github.com/EventFlow-Project/backend/internal/core/ports"
 	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -23,30 +24,26 @@ func NewAuthRepository(db *database.Database) ports.AuthRepository {
 	}
 }
 
-func (r *AuthRepositoryImpl) CreateUserWithPassword(email, password, name, role, description, activityArea string) (*models.User, error) {
+func (r *AuthRepositoryImpl) CreateUserWithPassword(ctx context.Context, email, passwordHash, name string, role constants.UserRole, description, activityArea string, locale constants.Locale) (*models.User, error) {
 	if r.db == nil || r.db.DB == nil {
 		return nil, errors.New("database connection is not initialized")
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return nil, err
-	}
-
 	user := &models.User{
 		ID:           uuid.New().String(),
 		Email:        email,
 		Name:         name,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: passwordHash,
 		Avatar:       "",
 		Role:         role,
 		Description:  description,
 		ActivityArea: activityArea,
+		Locale:       locale,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
-	result := r.db.DB.Create(user)
+	result := r.db.Conn(ctx).Create(user)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -68,6 +65,61 @@ func (r *AuthRepositoryImpl) UpdateUser(user *models.User) error {
 	return nil
 }
 
+func (r *AuthRepositoryImpl) MarkEmailVerified(ctx context.Context, userID string, at time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Model(&models.User{}).
+		Where("id = ? AND email_verified_at IS NULL", userID).
+		Update("email_verified_at", at).Error
+}
+
+func (r *AuthRepositoryImpl) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	result := r.db.Conn(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"password_hash": passwordHash,
+			"updated_at":    time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+func (r *AuthRepositoryImpl) UpdateEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	result := r.db.Conn(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"email":             email,
+			"email_verified_at": verifiedAt,
+			"updated_at":        time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
 func (r *AuthRepositoryImpl) GetUserByName(name string) (*models.User, error) {
 	if r.db == nil || r.db.DB == nil {
 		return nil, errors.New("database connection is not initialized")
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+)
+
+type AuthTokenRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewAuthTokenRepository(db *database.Database) ports.AuthTokenRepository {
+	return &AuthTokenRepositoryImpl{db: db}
+}
+
+func (r *AuthTokenRepositoryImpl) CreateToken(ctx context.Context, token *models.AuthToken) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Create(token).Error
+}
+
+func (r *AuthTokenRepositoryImpl) RevokeTokens(ctx context.Context, userID string, purpose constants.AuthTokenPurpose, at time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Model(&models.AuthToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", at).Error
+}
+
+func (r *AuthTokenRepositoryImpl) FindToken(ctx context.Context, purpose constants.AuthTokenPurpose, tokenHash string, now time.Time) (*models.AuthToken, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var tokens []models.AuthToken
+	if err := r.db.Conn(ctx).
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+		Limit(1).
+		Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	return &tokens[0], nil
+}
+
+func (r *AuthTokenRepositoryImpl) ConsumeToken(ctx context.Context, purpose constants.AuthTokenPurpose, tokenHash string, now time.Time) (*models.AuthToken, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var tokens []models.AuthToken
+	if err := r.db.Conn(ctx).Raw(`
+		UPDATE auth_tokens SET used_at = @now
+		WHERE token_hash = @hash AND purpose = @purpose AND used_at IS NULL AND expires_at > @now
+		RETURNING *`,
+		sql.Named("now", now),
+		sql.Named("hash", tokenHash),
+		sql.Named("purpose", purpose),
+	).Scan(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	return &tokens[0], nil
+}
+
+func (r *AuthTokenRepositoryImpl) DeleteSpentTokens(ctx context.Context, userID string, purpose constants.AuthTokenPurpose, now time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).
+		Where("user_id = ? AND purpose = ? AND (used_at IS NOT NULL OR expires_at <= ?)", userID, purpose, now).
+		Delete(&models.AuthToken{}).Error
+}
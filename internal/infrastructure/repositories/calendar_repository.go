@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewCalendarRepository(db *database.Database) ports.CalendarRepository {
+	return &CalendarRepositoryImpl{db: db}
+}
+
+func (r *CalendarRepositoryImpl) SaveToken(ctx context.Context, token *models.CalendarToken) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+	}).Create(token).Error
+}
+
+func (r *CalendarRepositoryImpl) GetTokenByHash(ctx context.Context, tokenHash string) (*models.CalendarToken, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var token models.CalendarToken
+	if err := r.db.DB.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *CalendarRepositoryImpl) DeleteToken(ctx context.Context, userID string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.DB.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.CalendarToken{}).Error
+}
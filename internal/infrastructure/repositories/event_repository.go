@@ -2,7 +2,12 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"github.com/EventFlow-Project/backend/internal/core/constants"
@@ -11,6 +16,7 @@ import (
 	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type EventRepositoryImpl struct {
@@ -38,7 +44,7 @@ func (r *EventRepositoryImpl) CreateEvent(ctx context.Context, event *models.Eve
 		event.UpdatedAt = time.Now()
 	}
 
-	if err := r.db.DB.WithContext(ctx).Create(event).Error; err != nil {
+	if err := r.db.Conn(ctx).Create(event).Error; err != nil {
 		return nil, err
 	}
 
@@ -52,7 +58,11 @@ func (r *EventRepositoryImpl) UpdateEvent(ctx context.Context, event *models.Eve
 
 	event.UpdatedAt = time.Now()
 
-	result := r.db.DB.WithContext(ctx).Model(&models.Event{}).Where("id = ?", event.ID).Updates(event)
+	result := r.db.Conn(ctx).Model(&models.Event{}).
+		Where("id = ?", event.ID).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(event)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -81,76 +91,150 @@ func (r *EventRepositoryImpl) DeleteEvent(ctx context.Context, eventID string) e
 	return nil
 }
 
-func (r *EventRepositoryImpl) ApproveEvent(ctx context.Context, eventID string) error {
+func (r *EventRepositoryImpl) GetEvent(ctx context.Context, eventID string) (*models.Event, error) {
 	if r.db == nil || r.db.DB == nil {
-		return errors.New("database connection is not initialized")
+		return nil, errors.New("database connection is not initialized")
 	}
 
-	result := r.db.DB.WithContext(ctx).Model(&models.Event{}).
-		Where("id = ?", eventID).
-		Updates(map[string]interface{}{
-			"moderation_status": constants.EventModerationStatusApproved,
-			"updated_at":        time.Now(),
-		})
+	var event models.Event
+	if err := r.db.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
 
-	if result.Error != nil {
-		return result.Error
+	return &event, nil
+}
+
+func (r *EventRepositoryImpl) GetEventsByOrganizer(ctx context.Context, organizerID string) ([]models.Event, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
 	}
 
-	if result.RowsAffected == 0 {
-		return errors.New("event not found")
+	var events []models.Event
+	if err := r.db.DB.WithContext(ctx).Where("organizer = ?", organizerID).
+		Order("date DESC").
+		Find(&events).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return events, nil
 }
 
-func (r *EventRepositoryImpl) RejectEvent(ctx context.Context, eventID string) error {
+func (r *EventRepositoryImpl) GetRecentEventsByOrganizer(ctx context.Context, organizerID string, since time.Time) ([]models.Event, error) {
 	if r.db == nil || r.db.DB == nil {
-		return errors.New("database connection is not initialized")
+		return nil, errors.New("database connection is not initialized")
 	}
 
-	result := r.db.DB.WithContext(ctx).Model(&models.Event{}).
-		Where("id = ?", eventID).
-		Updates(map[string]interface{}{
-			"moderation_status": constants.EventModerationStatusRejected,
-			"updated_at":        time.Now(),
-		})
+	var events []models.Event
+	if err := r.db.DB.WithContext(ctx).
+		Where("organizer = ? AND created_at >= ?", organizerID, since).
+		Order("created_at DESC").
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
 
-	if result.Error != nil {
-		return result.Error
+	return events, nil
+}
+
+func (r *EventRepositoryImpl) GetEventsByStatus(ctx context.Context, status constants.EventStatus) ([]models.Event, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
 	}
 
-	if result.RowsAffected == 0 {
-		return errors.New("event not found")
+	var events []models.Event
+	if err := r.db.DB.WithContext(ctx).Where("status = ?", status).
+		Order("date DESC").
+		Find(&events).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return events, nil
 }
 
-func (r *EventRepositoryImpl) GetEvent(ctx context.Context, eventID string) (*models.Event, error) {
+func (r *EventRepositoryImpl) GetEventsByModerationStatus(ctx context.Context, status constants.EventModerationStatus) ([]models.Event, error) {
 	if r.db == nil || r.db.DB == nil {
 		return nil, errors.New("database connection is not initialized")
 	}
 
-	var event models.Event
-	if err := r.db.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
+	var events []models.Event
+	if err := r.db.DB.WithContext(ctx).Where("moderation_status = ?", status).
+		Order("date DESC").
+		Find(&events).Error; err != nil {
 		return nil, err
 	}
 
-	return &event, nil
+	return events, nil
 }
 
-func (r *EventRepositoryImpl) GetEventsByOrganizer(ctx context.Context, organizerID string) ([]models.Event, error) {
+func (r *EventRepositoryImpl) ListEvents(ctx context.Context, filter *models.EventFilter, cursor *models.EventCursor, limit int) ([]models.Event, error) {
 	if r.db == nil || r.db.DB == nil {
 		return nil, errors.New("database connection is not initialized")
 	}
 
+	query := r.db.DB.WithContext(ctx).Model(&models.Event{})
+
+	if !filter.IncludeUnapproved {
+		query = query.Where("moderation_status = ?", constants.EventModerationStatusApproved)
+	} else if filter.ModerationStatus != "" {
+		query = query.Where("moderation_status = ?", filter.ModerationStatus)
+	}
+
+	if filter.From != nil {
+		query = query.Where("date >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("date < ?", *filter.To)
+	}
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	if filter.Organizer != "" {
+		query = query.Where("organizer = ?", filter.Organizer)
+	}
+
+	if len(filter.TagIDs) > 0 {
+		tags := make([]map[string]string, len(filter.TagIDs))
+		for i, id := range filter.TagIDs {
+			tags[i] = map[string]string{"id": id}
+		}
+
+		tagsJSON, err := json.Marshal(tags)
+		if err != nil {
+			return nil, err
+		}
+
+		// Containment keeps the lookup on the GIN index over tags.
+		query = query.Where("tags @> ?::jsonb", string(tagsJSON))
+	}
+
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		query = query.Where("(title ILIKE ? OR description ILIKE ?)", pattern, pattern)
+	}
+
+	column, descending := eventSortColumn(filter.Sort)
+	if cursor != nil {
+		operator := ">"
+		if descending {
+			operator = "<"
+		}
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, operator), cursor.Value, cursor.ID)
+	}
+
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
 	var events []models.Event
-	if err := r.db.DB.WithContext(ctx).Where("organizer = ?", organizerID).
-		Order("date DESC").
+	if err := query.
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(limit).
 		Find(&events).Error; err != nil {
 		return nil, err
 	}
@@ -158,14 +242,17 @@ func (r *EventRepositoryImpl) GetEventsByOrganizer(ctx context.Context, organize
 	return events, nil
 }
 
-func (r *EventRepositoryImpl) GetEventsByStatus(ctx context.Context, status constants.EventStatus) ([]models.Event, error) {
+func (r *EventRepositoryImpl) GetEventsInWindow(ctx context.Context, from, to time.Time) ([]models.Event, error) {
 	if r.db == nil || r.db.DB == nil {
 		return nil, errors.New("database connection is not initialized")
 	}
 
 	var events []models.Event
-	if err := r.db.DB.WithContext(ctx).Where("status = ?", status).
-		Order("date DESC").
+	if err := r.db.DB.WithContext(ctx).
+		Where("moderation_status = ?", constants.EventModerationStatusApproved).
+		Where("date < ?", to).
+		Where("(recurrence = '' AND end_date > ?) OR (recurrence <> '' AND (recurrence_end IS NULL OR recurrence_end > ?))", from, from).
+		Order("date, id").
 		Find(&events).Error; err != nil {
 		return nil, err
 	}
@@ -173,17 +260,317 @@ func (r *EventRepositoryImpl) GetEventsByStatus(ctx context.Context, status cons
 	return events, nil
 }
 
-func (r *EventRepositoryImpl) GetEventsByModerationStatus(ctx context.Context, status constants.EventModerationStatus) ([]models.Event, error) {
+func (r *EventRepositoryImpl) GetCalendarEvents(ctx context.Context, userID string, friendIDs []string) ([]models.Event, error) {
 	if r.db == nil || r.db.DB == nil {
 		return nil, errors.New("database connection is not initialized")
 	}
 
+	coOrganizer, err := json.Marshal([]string{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.DB.WithContext(ctx).
+		Where("organizer = ? OR co_organizers @> ?::jsonb", userID, string(coOrganizer))
+
+	if len(friendIDs) > 0 {
+		query = query.Or("organizer IN ? AND moderation_status = ?", friendIDs, constants.EventModerationStatusApproved)
+	}
+
 	var events []models.Event
-	if err := r.db.DB.WithContext(ctx).Where("moderation_status = ?", status).
-		Order("date DESC").
+	if err := query.Order("date, id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *EventRepositoryImpl) GetOccurrenceOverrides(ctx context.Context, eventIDs []string) ([]models.OccurrenceOverride, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var overrides []models.OccurrenceOverride
+	if len(eventIDs) == 0 {
+		return overrides, nil
+	}
+
+	if err := r.db.DB.WithContext(ctx).
+		Where("event_id IN ?", eventIDs).
+		Order("event_id, occurrence_date").
+		Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+func (r *EventRepositoryImpl) UpsertOccurrenceOverride(ctx context.Context, override *models.OccurrenceOverride) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "occurrence_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "date", "end_date", "updated_at"}),
+	}).Create(override).Error
+}
+
+func (r *EventRepositoryImpl) DeleteOccurrenceOverrides(ctx context.Context, eventID string, from *time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	query := r.db.Conn(ctx).Where("event_id = ?", eventID)
+	if from != nil {
+		query = query.Where("occurrence_date >= ?", *from)
+	}
+
+	return query.Delete(&models.OccurrenceOverride{}).Error
+}
+
+func (r *EventRepositoryImpl) SplitEventSeries(ctx context.Context, truncated *models.Event, continuation *models.Event, splitAt time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		truncated.UpdatedAt = time.Now()
+
+		result := tx.Model(&models.Event{}).
+			Where("id = ?", truncated.ID).
+			Select("*").
+			Omit("id", "created_at").
+			Updates(truncated)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.New("event not found")
+		}
+
+		if err := tx.Create(continuation).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.OccurrenceOverride{}).
+			Where("event_id = ? AND occurrence_date >= ?", truncated.ID, splitAt).
+			Update("event_id", continuation.ID).Error
+	})
+}
+
+func (r *EventRepositoryImpl) GetEventsToTransition(ctx context.Context, now time.Time) ([]models.Event, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	// Underway events are loaded only once they have ended, so series
+	// without an end are not reloaded on every tick. This mirrors
+	// Event.StatusAt.
+	var events []models.Event
+	if err := r.db.DB.WithContext(ctx).
+		Where("status <> ? AND date <= ?", constants.EventStatusHeld, now).
+		Where("status <> ? OR (recurrence = '' AND end_date <= ?) OR (recurrence <> '' AND recurrence_end <= ?)",
+			constants.EventStatusUnderway, now, now).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *EventRepositoryImpl) UpdateEventStatus(ctx context.Context, eventID string, from, to constants.EventStatus) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	result := r.db.DB.WithContext(ctx).Model(&models.Event{}).
+		Where("id = ? AND status = ?", eventID, from).
+		Updates(map[string]interface{}{
+			"status":     to,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Headlines are built with control characters as delimiters and turned into
+// <mark> tags only after the text around them is HTML-escaped, so event
+// titles and descriptions cannot inject markup into search results.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+const searchEventsQuery = `
+SELECT events.*, ranked.rank,
+	ts_headline('russian', events.title, query, @title_options) AS title_highlight,
+	ts_headline('russian', coalesce(events.description, ''), query, @snippet_options) AS snippet
+FROM (
+	SELECT id, ts_rank_cd(search_vector, query) AS rank
+	FROM events, to_tsquery('russian', @query) AS query
+	WHERE search_vector @@ query AND moderation_status = @status
+	ORDER BY rank DESC, id
+	LIMIT @limit OFFSET @offset
+) AS ranked
+JOIN events ON events.id = ranked.id, to_tsquery('russian', @query) AS query
+ORDER BY ranked.rank DESC, events.id`
+
+func (r *EventRepositoryImpl) SearchEvents(ctx context.Context, tsquery string, limit, offset int) ([]models.EventSearchResult, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var results []models.EventSearchResult
+	// Headlines are costly, so they are built only for the requested page.
+	if err := r.db.DB.WithContext(ctx).Raw(searchEventsQuery,
+		sql.Named("query", tsquery),
+		sql.Named("status", constants.EventModerationStatusApproved),
+		sql.Named("limit", limit),
+		sql.Named("offset", offset),
+		sql.Named("title_options", "HighlightAll=true, StartSel="+highlightStart+", StopSel="+highlightStop),
+		sql.Named("snippet_options", "MaxFragments=2, MaxWords=20, MinWords=5, StartSel="+highlightStart+", StopSel="+highlightStop),
+	).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].TitleHighlight = markHighlights(results[i].TitleHighlight)
+		results[i].Snippet = markHighlights(results[i].Snippet)
+	}
+
+	return results, nil
+}
+
+func markHighlights(headline string) string {
+	return highlightReplacer.Replace(html.EscapeString(headline))
+}
+
+// distanceKmExpr is the haversine distance in kilometres from the point bound
+// to its placeholders (lat, lat, lng) to the event location.
+const distanceKmExpr = `6371 * 2 * ASIN(SQRT(
+	POWER(SIN(RADIANS(lat - ?) / 2), 2) +
+	COS(RADIANS(?)) * COS(RADIANS(lat)) * POWER(SIN(RADIANS(lng - ?) / 2), 2)
+))`
+
+func (r *EventRepositoryImpl) FindEventsNearby(ctx context.Context, query *models.NearbyQuery) ([]models.EventWithDistance, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	candidates := r.approvedInBox(ctx, query.Box).
+		Select("events.*, "+distanceKmExpr+" AS distance_km", query.Lat, query.Lat, query.Lng)
+
+	var events []models.EventWithDistance
+	if err := r.db.DB.WithContext(ctx).
+		Table("(?) AS events", candidates).
+		Where("distance_km <= ?", query.RadiusKm).
+		Order("distance_km, id").
+		Limit(query.Limit).
 		Find(&events).Error; err != nil {
 		return nil, err
 	}
 
 	return events, nil
 }
+
+func (r *EventRepositoryImpl) CountEventsInBox(ctx context.Context, box models.BoundingBox) (int64, error) {
+	if r.db == nil || r.db.DB == nil {
+		return 0, errors.New("database connection is not initialized")
+	}
+
+	var count int64
+	if err := r.approvedInBox(ctx, box).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *EventRepositoryImpl) FindEventsInBox(ctx context.Context, box models.BoundingBox) ([]models.Event, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var events []models.Event
+	if err := r.approvedInBox(ctx, box).Order("date, id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *EventRepositoryImpl) ClusterEventsInBox(ctx context.Context, box models.BoundingBox, cellLat, cellLng float64) ([]models.EventCluster, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	// Longitudes west of the antimeridian are shifted by 360 degrees so that
+	// cells and averages continue across it, then wrapped back.
+	lng := "lng"
+	if box.CrossesAntimeridian() {
+		lng = fmt.Sprintf("(CASE WHEN lng < %f THEN lng + 360 ELSE lng END)", box.MinLng)
+	}
+
+	var clusters []models.EventCluster
+	if err := r.approvedInBox(ctx, box).
+		Select(fmt.Sprintf(`AVG(lat) AS lat, AVG(%[1]s) AS lng, COUNT(*) AS count,
+			MIN(lat) AS min_lat, MIN(%[1]s) AS min_lng, MAX(lat) AS max_lat, MAX(%[1]s) AS max_lng`, lng)).
+		Group(fmt.Sprintf("FLOOR((lat - %f) / %f), FLOOR((%s - %f) / %f)", box.MinLat, cellLat, lng, box.MinLng, cellLng)).
+		Scan(&clusters).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range clusters {
+		clusters[i].Lng = wrapLng(clusters[i].Lng)
+		clusters[i].MinLng = wrapLng(clusters[i].MinLng)
+		clusters[i].MaxLng = wrapLng(clusters[i].MaxLng)
+	}
+
+	return clusters, nil
+}
+
+// approvedInBox selects approved events inside the box. The range conditions
+// are served by the (lat, lng) index; a box crossing the antimeridian is split
+// into two longitude ranges.
+func (r *EventRepositoryImpl) approvedInBox(ctx context.Context, box models.BoundingBox) *gorm.DB {
+	query := r.db.DB.WithContext(ctx).
+		Model(&models.Event{}).
+		Where("moderation_status = ?", constants.EventModerationStatusApproved).
+		Where("lat BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+
+	if box.CrossesAntimeridian() {
+		return query.Where("(lng BETWEEN ? AND 180 OR lng BETWEEN -180 AND ?)", box.MinLng, box.MaxLng)
+	}
+
+	return query.Where("lng BETWEEN ? AND ?", box.MinLng, box.MaxLng)
+}
+
+func wrapLng(lng float64) float64 {
+	if lng > 180 {
+		return lng - 360
+	}
+	return lng
+}
+
+func eventSortColumn(sort constants.EventSort) (column string, descending bool) {
+	switch sort {
+	case constants.EventSortDateDesc:
+		return "date", true
+	case constants.EventSortCreatedDesc:
+		return "created_at", true
+	default:
+		return "date", false
+	}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -87,7 +87,7 @@ func (r *FriendRepositoryImpl) GetFriendsList(userID string) ([]models.SafeUser,
 	err := r.db.DB.Model(&models.User{}).
 		Distinct("users.*").
 		Joins("JOIN friendships ON ((friendships.user_id = ? AND friendships.friend_id = users.id) OR (friendships.friend_id = ? AND friendships.user_id = users.id))", userID, userID).
-		Where("friendships.deleted_at IS NULL").
+		Where("friendships.deleted_at IS NULL AND users.hidden_at IS NULL").
 		Find(&users).Error
 
 	if err != nil {
@@ -237,8 +237,13 @@ func (r *FriendRepositoryImpl) GetIncomingFriendRequests(userID string) ([]model
 		return nil, errors.New("database connection is not initialized")
 	}
 
+	// Requests from hidden profiles are left out until the profile is
+	// restored.
 	var requests []models.FriendRequest
-	if err := r.db.DB.Where("to_id = ? AND status = ?", userID, "pending").Find(&requests).Error; err != nil {
+	if err := r.db.DB.
+		Joins("JOIN users ON users.id = friend_requests.from_id AND users.hidden_at IS NULL").
+		Where("friend_requests.to_id = ? AND friend_requests.status = ?", userID, "pending").
+		Find(&requests).Error; err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type LockRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewLockRepository(db *database.Database) ports.LockRepository {
+	return &LockRepositoryImpl{db: db}
+}
+
+// TryWithLock takes a transaction-scoped Postgres advisory lock, so the lock
+// is released when fn returns even if the process dies midway.
+func (r *LockRepositoryImpl) TryWithLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	acquired := false
+	err := r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&acquired).Error; err != nil {
+			return err
+		}
+
+		if !acquired {
+			return nil
+		}
+
+		return fn(ctx)
+	})
+
+	return acquired, err
+}
@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MFARepositoryImpl struct {
+	db *database.Database
+}
+
+func NewMFARepository(db *database.Database) ports.MFARepository {
+	return &MFARepositoryImpl{db: db}
+}
+
+func (r *MFARepositoryImpl) SetTOTPSecret(ctx context.Context, userID, encryptedSecret string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.updateUser(ctx, userID, map[string]interface{}{
+		"totp_secret":    encryptedSecret,
+		"totp_last_step": 0,
+		"mfa_enabled_at": nil,
+	})
+}
+
+func (r *MFARepositoryImpl) EnableMFA(ctx context.Context, userID string, at time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.updateUser(ctx, userID, map[string]interface{}{
+		"mfa_enabled_at": at,
+	})
+}
+
+func (r *MFARepositoryImpl) DisableMFA(ctx context.Context, userID string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"totp_secret":    "",
+			"totp_last_step": 0,
+			"mfa_enabled_at": nil,
+			"updated_at":     time.Now(),
+		}).Error
+	})
+}
+
+func (r *MFARepositoryImpl) AdvanceTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	result := r.db.Conn(ctx).Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *MFARepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []models.MFARecoveryCode) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		if len(codes) == 0 {
+			return nil
+		}
+
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *MFARepositoryImpl) UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	result := r.db.Conn(ctx).Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *MFARepositoryImpl) CountRecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	if r.db == nil || r.db.DB == nil {
+		return 0, errors.New("database connection is not initialized")
+	}
+
+	var count int64
+	err := r.db.DB.WithContext(ctx).Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+
+	return count, err
+}
+
+func (r *MFARepositoryImpl) GetMFAPolicies(ctx context.Context) ([]models.RoleMFAPolicy, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var policies []models.RoleMFAPolicy
+	if err := r.db.DB.WithContext(ctx).Order("role").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (r *MFARepositoryImpl) IsMFARequired(ctx context.Context, role constants.UserRole) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	var policies []models.RoleMFAPolicy
+	if err := r.db.DB.WithContext(ctx).Where("role = ?", role).Limit(1).Find(&policies).Error; err != nil {
+		return false, err
+	}
+
+	return len(policies) > 0 && policies[0].Required, nil
+}
+
+func (r *MFARepositoryImpl) SaveMFAPolicy(ctx context.Context, policy *models.RoleMFAPolicy) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role"}},
+		DoUpdates: clause.AssignmentColumns([]string{"required", "updated_at"}),
+	}).Create(policy).Error
+}
+
+func (r *MFARepositoryImpl) updateUser(ctx context.Context, userID string, values map[string]interface{}) error {
+	values["updated_at"] = time.Now()
+
+	result := r.db.Conn(ctx).Model(&models.User{}).Where("id = ?", userID).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,275 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ModerationRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewModerationRepository(db *database.Database) ports.ModerationRepository {
+	return &ModerationRepositoryImpl{db: db}
+}
+
+func (r *ModerationRepositoryImpl) RecordAction(ctx context.Context, action *models.ModerationAction) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Create(action).Error
+}
+
+func (r *ModerationRepositoryImpl) GetActions(ctx context.Context, eventID string) ([]models.ModerationAction, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var actions []models.ModerationAction
+	if err := r.db.DB.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("created_at, id").
+		Find(&actions).Error; err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+const organizerStatsQuery = `
+SELECT
+	COUNT(DISTINCT events.id) FILTER (
+		WHERE moderation_actions.action = @approved
+		AND moderation_actions.actor_id <> @system
+		AND events.moderation_status = @approvedStatus
+	) AS approved,
+	COUNT(*) FILTER (
+		WHERE moderation_actions.action = @rejected
+		AND moderation_actions.created_at >= @since
+	) AS recent_rejections
+FROM moderation_actions
+JOIN events ON events.id = moderation_actions.event_id
+WHERE events.organizer = @organizer`
+
+func (r *ModerationRepositoryImpl) GetOrganizerStats(ctx context.Context, organizerID string, since time.Time) (*models.OrganizerModerationStats, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var stats models.OrganizerModerationStats
+	if err := r.db.DB.WithContext(ctx).Raw(organizerStatsQuery,
+		sql.Named("organizer", organizerID),
+		sql.Named("approved", constants.ModerationActionApproved),
+		sql.Named("rejected", constants.ModerationActionRejected),
+		sql.Named("system", constants.ModerationActorSystem),
+		sql.Named("approvedStatus", constants.EventModerationStatusApproved),
+		sql.Named("since", since),
+	).Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func (r *ModerationRepositoryImpl) GetQueue(ctx context.Context, moderatorID string, cursor *models.EventCursor, limit int, now time.Time) ([]models.ModerationQueueItem, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := r.db.DB.WithContext(ctx).Model(&models.Event{}).
+		Select("events.*, moderation_claims.moderator_id AS claimed_by, moderation_claims.expires_at AS claim_expires_at").
+		Joins("LEFT JOIN moderation_claims ON moderation_claims.event_id = events.id AND moderation_claims.expires_at > ?", now).
+		Where("events.moderation_status = ?", constants.EventModerationStatusPending).
+		Where("(moderation_claims.event_id IS NULL OR moderation_claims.moderator_id = ?)", moderatorID)
+
+	if cursor != nil {
+		query = query.Where("(events.submitted_at, events.id) > (?, ?)", cursor.Value, cursor.ID)
+	}
+
+	var items []models.ModerationQueueItem
+	if err := query.
+		Order("events.submitted_at, events.id").
+		Limit(limit).
+		Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+const claimEventQuery = `
+INSERT INTO moderation_claims (event_id, moderator_id, expires_at)
+SELECT id, @moderator, @expires FROM events WHERE id = @event AND moderation_status = @pending
+ON CONFLICT (event_id) DO UPDATE
+SET moderator_id = EXCLUDED.moderator_id, expires_at = EXCLUDED.expires_at
+WHERE moderation_claims.moderator_id = EXCLUDED.moderator_id OR moderation_claims.expires_at <= @now`
+
+func (r *ModerationRepositoryImpl) ClaimEvent(ctx context.Context, claim *models.ModerationClaim, now time.Time) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	result := r.db.DB.WithContext(ctx).Exec(claimEventQuery,
+		sql.Named("event", claim.EventID),
+		sql.Named("moderator", claim.ModeratorID),
+		sql.Named("expires", claim.ExpiresAt),
+		sql.Named("pending", constants.EventModerationStatusPending),
+		sql.Named("now", now),
+	)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ModerationRepositoryImpl) ClaimNextEvent(ctx context.Context, moderatorID string, expiresAt, now time.Time) (string, error) {
+	if r.db == nil || r.db.DB == nil {
+		return "", errors.New("database connection is not initialized")
+	}
+
+	var eventID string
+	err := r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// An event can be claimed through ClaimEvent between the lookup and
+		// the claim, in which case the claim does not apply and the next
+		// event is tried.
+		for {
+			var ids []string
+			// SKIP LOCKED lets concurrent moderators claim different events
+			// instead of queueing on the oldest one.
+			if err := tx.Model(&models.Event{}).
+				Select("events.id").
+				Joins("LEFT JOIN moderation_claims ON moderation_claims.event_id = events.id").
+				Where("events.moderation_status = ?", constants.EventModerationStatusPending).
+				Where("(moderation_claims.event_id IS NULL OR moderation_claims.expires_at <= ?)", now).
+				Order("events.submitted_at, events.id").
+				Limit(1).
+				Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "events"}, Options: "SKIP LOCKED"}).
+				Pluck("events.id", &ids).Error; err != nil {
+				return err
+			}
+
+			if len(ids) == 0 {
+				return nil
+			}
+
+			result := tx.Exec(claimEventQuery,
+				sql.Named("event", ids[0]),
+				sql.Named("moderator", moderatorID),
+				sql.Named("expires", expiresAt),
+				sql.Named("pending", constants.EventModerationStatusPending),
+				sql.Named("now", now),
+			)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected > 0 {
+				eventID = ids[0]
+				return nil
+			}
+		}
+	})
+
+	return eventID, err
+}
+
+func (r *ModerationRepositoryImpl) GetClaim(ctx context.Context, eventID string) (*models.ModerationClaim, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var claim models.ModerationClaim
+	if err := r.db.DB.WithContext(ctx).Where("event_id = ?", eventID).First(&claim).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &claim, nil
+}
+
+func (r *ModerationRepositoryImpl) ReleaseClaim(ctx context.Context, eventID, moderatorID string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.DB.WithContext(ctx).
+		Where("event_id = ? AND moderator_id = ?", eventID, moderatorID).
+		Delete(&models.ModerationClaim{}).Error
+}
+
+func (r *ModerationRepositoryImpl) ApplyDecision(ctx context.Context, action *models.ModerationAction, from, to constants.EventModerationStatus) (bool, error) {
+	return r.applyDecision(ctx, action, from, to, func(query *gorm.DB) *gorm.DB {
+		return query
+	})
+}
+
+func (r *ModerationRepositoryImpl) ApplyModeratorDecision(ctx context.Context, action *models.ModerationAction, from, to constants.EventModerationStatus, now time.Time) (bool, error) {
+	return r.applyDecision(ctx, action, from, to, func(query *gorm.DB) *gorm.DB {
+		return query.Where(
+			"NOT EXISTS (SELECT 1 FROM moderation_claims WHERE moderation_claims.event_id = events.id AND moderation_claims.moderator_id <> ? AND moderation_claims.expires_at > ?)",
+			action.ActorID, now,
+		)
+	})
+}
+
+// applyDecision makes the change of ApplyDecision when the event also
+// matches the conditions guard adds.
+func (r *ModerationRepositoryImpl) applyDecision(ctx context.Context, action *models.ModerationAction, from, to constants.EventModerationStatus, guard func(*gorm.DB) *gorm.DB) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	applied := false
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"moderation_status": to,
+			"moderation_reason": "",
+			"updated_at":        action.CreatedAt,
+		}
+		if to == constants.EventModerationStatusRejected {
+			updates["moderation_reason"] = action.Reason
+		}
+		// A resubmitted event joins the end of the queue.
+		if to == constants.EventModerationStatusPending {
+			updates["submitted_at"] = action.CreatedAt
+		}
+
+		result := guard(tx.Model(&models.Event{}).
+			Where("id = ? AND moderation_status = ?", action.EventID, from)).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(action).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("event_id = ?", action.EventID).Delete(&models.ModerationClaim{}).Error; err != nil {
+			return err
+		}
+
+		applied = true
+		return nil
+	})
+
+	return applied, err
+}
@@ -5,9 +5,25 @@ import "go.uber.org/fx"
 var Module = fx.Module("repositories",
 	fx.Provide(
 		NewAuthRepository,
+		NewAuthTokenRepository,
+		NewSecurityEventRepository,
+		NewMFARepository,
+		NewOIDCRepository,
 		NewUserRepository,
 		NewFriendRepository,
 		NewEventRepository,
 		NewMinioRepository,
+		NewSessionRepository,
+		NewRegistrationRepository,
+		NewTicketRepository,
+		NewOrderRepository,
+		NewLockRepository,
+		NewTransactor,
+		NewCalendarRepository,
+		NewModerationRepository,
+		NewReportRepository,
+		NewNotificationRepository,
+		NewOutboxRepository,
+		NewReminderRepository,
 	),
 )
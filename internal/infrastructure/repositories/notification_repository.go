@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewNotificationRepository(db *database.Database) ports.NotificationRepository {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+func (r *NotificationRepositoryImpl) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return r.db.DB.WithContext(ctx).Create(&notifications).Error
+}
+
+func (r *NotificationRepositoryImpl) ListNotifications(ctx context.Context, userID string, filter *models.NotificationFilter, cursor *models.EventCursor, limit int) ([]models.Notification, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := r.db.DB.WithContext(ctx).Where("user_id = ?", userID)
+
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+
+	if cursor != nil {
+		query = query.Where("(created_at, id) < (?, ?)", cursor.Value, cursor.ID)
+	}
+
+	var notifications []models.Notification
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepositoryImpl) CountUnread(ctx context.Context, userID string) (map[constants.NotificationCategory]int64, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var rows []struct {
+		Category constants.NotificationCategory
+		Count    int64
+	}
+	if err := r.db.DB.WithContext(ctx).Model(&models.Notification{}).
+		Select("category, COUNT(*) AS count").
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Group("category").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[constants.NotificationCategory]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Category] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (r *NotificationRepositoryImpl) MarkRead(ctx context.Context, userID string, ids []string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.db.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND id IN ? AND read_at IS NULL", userID, ids).
+		Update("read_at", time.Now()).Error
+}
+
+func (r *NotificationRepositoryImpl) MarkAllRead(ctx context.Context, userID string, category constants.NotificationCategory) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	query := r.db.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	return query.Update("read_at", time.Now()).Error
+}
+
+func (r *NotificationRepositoryImpl) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var preferences []models.NotificationPreference
+	if err := r.db.DB.WithContext(ctx).Where("user_id = ?", userID).Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *NotificationRepositoryImpl) SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	return r.db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "category"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&preferences).Error
+}
+
+func (r *NotificationRepositoryImpl) GetOptedOutUsers(ctx context.Context, userIDs []string, category constants.NotificationCategory) ([]string, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var optedOut []string
+	if err := r.db.DB.WithContext(ctx).Model(&models.NotificationPreference{}).
+		Where("user_id IN ? AND category = ? AND NOT enabled", userIDs, category).
+		Pluck("user_id", &optedOut).Error; err != nil {
+		return nil, err
+	}
+
+	return optedOut, nil
+}
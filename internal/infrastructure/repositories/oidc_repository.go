@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+)
+
+type OIDCRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewOIDCRepository(db *database.Database) ports.OIDCRepository {
+	return &OIDCRepositoryImpl{db: db}
+}
+
+func (r *OIDCRepositoryImpl) CreateAuthRequest(ctx context.Context, request *models.OIDCAuthRequest) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Create(request).Error
+}
+
+func (r *OIDCRepositoryImpl) ConsumeAuthRequest(ctx context.Context, stateHash string, now time.Time) (*models.OIDCAuthRequest, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var requests []models.OIDCAuthRequest
+	if err := r.db.Conn(ctx).Raw(`
+		DELETE FROM oidc_auth_requests
+		WHERE state_hash = @hash AND expires_at > @now
+		RETURNING *`,
+		sql.Named("hash", stateHash),
+		sql.Named("now", now),
+	).Scan(&requests).Error; err != nil {
+		return nil, err
+	}
+
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	return &requests[0], nil
+}
+
+func (r *OIDCRepositoryImpl) DeleteExpiredAuthRequests(ctx context.Context, now time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Where("expires_at <= ?", now).Delete(&models.OIDCAuthRequest{}).Error
+}
+
+func (r *OIDCRepositoryImpl) GetIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var identities []models.UserIdentity
+	if err := r.db.Conn(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		Limit(1).
+		Find(&identities).Error; err != nil {
+		return nil, err
+	}
+
+	if len(identities) == 0 {
+		return nil, nil
+	}
+
+	return &identities[0], nil
+}
+
+func (r *OIDCRepositoryImpl) CreateIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Create(identity).Error
+}
+
+func (r *OIDCRepositoryImpl) ListIdentities(ctx context.Context, userID string) ([]models.UserIdentity, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	identities := []models.UserIdentity{}
+	if err := r.db.Conn(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&identities).Error; err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
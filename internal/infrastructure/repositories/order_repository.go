@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepositoryImpl struct {
+	db *database.Database
+}
+
+type paymentWebhookEvent struct {
+	Provider    string `gorm:"primaryKey"`
+	EventID     string `gorm:"primaryKey"`
+	Type        constants.PaymentEventType
+	PaymentID   string
+	ProcessedAt time.Time
+}
+
+func (paymentWebhookEvent) TableName() string {
+	return "payment_webhook_events"
+}
+
+func NewOrderRepository(db *database.Database) ports.OrderRepository {
+	return &OrderRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *OrderRepositoryImpl) CreateOrder(ctx context.Context, order *models.Order) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Create(order).Error
+}
+
+func (r *OrderRepositoryImpl) GetOrder(ctx context.Context, orderID string) (*models.Order, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var order models.Order
+	if err := r.db.Conn(ctx).First(&order, "id = ?", orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *OrderRepositoryImpl) GetOrderByProviderPaymentID(ctx context.Context, provider, paymentID string) (*models.Order, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var order models.Order
+	if err := r.db.Conn(ctx).First(&order, "provider = ? AND provider_payment_id = ?", provider, paymentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *OrderRepositoryImpl) GetOrdersByUser(ctx context.Context, userID string) ([]models.Order, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var orders []models.Order
+	if err := r.db.Conn(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (r *OrderRepositoryImpl) EventHasOrders(ctx context.Context, eventID string) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	var exists bool
+	if err := r.db.Conn(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM orders WHERE event_id = ?)", eventID).
+		Scan(&exists).Error; err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (r *OrderRepositoryImpl) GetOrdersByStatus(ctx context.Context, status constants.OrderStatus, limit int) ([]models.Order, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var orders []models.Order
+	if err := r.db.Conn(ctx).
+		Where("status = ?", status).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (r *OrderRepositoryImpl) TransitionOrderStatus(ctx context.Context, orderID string, from, to constants.OrderStatus) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	result := r.db.Conn(ctx).Model(&models.Order{}).
+		Where("id = ? AND status = ?", orderID, from).
+		Updates(map[string]interface{}{
+			"status":     to,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *OrderRepositoryImpl) SetOrderTicket(ctx context.Context, orderID, ticketID string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Model(&models.Order{}).
+		Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"ticket_id":  ticketID,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *OrderRepositoryImpl) IsWebhookEventProcessed(ctx context.Context, provider, eventID string) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	var count int64
+	err := r.db.Conn(ctx).Model(&paymentWebhookEvent{}).
+		Where("provider = ? AND event_id = ?", provider, eventID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *OrderRepositoryImpl) MarkWebhookEventProcessed(ctx context.Context, provider string, event *models.PaymentWebhookEvent) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&paymentWebhookEvent{
+			Provider:    provider,
+			EventID:     event.ID,
+			Type:        event.Type,
+			PaymentID:   event.PaymentID,
+			ProcessedAt: time.Now(),
+		}).Error
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+// sentPayload replaces the payload of delivered messages: emails carry
+// single-use links, which must not outlive their delivery in the database.
+const sentPayload = "{}"
+
+type OutboxRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewOutboxRepository(db *database.Database) ports.OutboxRepository {
+	return &OutboxRepositoryImpl{db: db}
+}
+
+func (r *OutboxRepositoryImpl) Enqueue(ctx context.Context, kind constants.OutboxKind, payload any) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return r.db.Conn(ctx).Create(&models.OutboxMessage{
+		ID:            uuid.New().String(),
+		Kind:          kind,
+		Payload:       string(encoded),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}).Error
+}
+
+func (r *OutboxRepositoryImpl) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.OutboxMessage, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var messages []models.OutboxMessage
+	if err := r.db.Conn(ctx).Raw(`
+		UPDATE outbox SET next_attempt_at = @leaseUntil
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE sent_at IS NULL AND failed_at IS NULL AND next_attempt_at <= @now
+			ORDER BY next_attempt_at, id
+			LIMIT @limit
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		sql.Named("leaseUntil", leaseUntil),
+		sql.Named("now", now),
+		sql.Named("limit", limit),
+	).Scan(&messages).Error; err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *OutboxRepositoryImpl) MarkSent(ctx context.Context, messageID string, sentAt time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Model(&models.OutboxMessage{}).
+		Where("id = ?", messageID).
+		Updates(map[string]interface{}{
+			"sent_at":    sentAt,
+			"last_error": "",
+			"payload":    sentPayload,
+		}).Error
+}
+
+func (r *OutboxRepositoryImpl) MarkFailed(ctx context.Context, messageID string, attempts int, lastError string, retryAt *time.Time) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	updates := map[string]interface{}{
+		"attempts":   attempts,
+		"last_error": lastError,
+	}
+	if retryAt != nil {
+		updates["next_attempt_at"] = *retryAt
+	} else {
+		updates["failed_at"] = time.Now()
+	}
+
+	return r.db.Conn(ctx).Model(&models.OutboxMessage{}).
+		Where("id = ?", messageID).
+		Updates(updates).Error
+}
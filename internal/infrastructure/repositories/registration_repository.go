@@ -0,0 +1,284 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RegistrationRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewRegistrationRepository(db *database.Database) ports.RegistrationRepository {
+	return &RegistrationRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *RegistrationRepositoryImpl) SaveRegistration(ctx context.Context, eventID, userID string, status constants.RegistrationStatus) (*models.Registration, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var registration models.Registration
+	err := r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEvent(tx, eventID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("event_id = ? AND user_id = ?", eventID, userID).First(&registration).Error
+		isNew := errors.Is(err, gorm.ErrRecordNotFound)
+		if err != nil && !isNew {
+			return err
+		}
+
+		now := time.Now()
+		if isNew {
+			registration = models.Registration{
+				ID:        uuid.New().String(),
+				EventID:   eventID,
+				UserID:    userID,
+				CreatedAt: now,
+			}
+		}
+
+		hadSeat := !isNew && registration.Status == constants.RegistrationStatusGoing
+		wasWaitlisted := !isNew && registration.Status == constants.RegistrationStatusWaitlisted
+
+		switch {
+		case status != constants.RegistrationStatusGoing:
+			registration.Status = status
+			registration.WaitlistedAt = nil
+		case hadSeat || wasWaitlisted:
+			// Keep the seat or the place in the queue.
+		default:
+			full, err := isEventFull(tx, event)
+			if err != nil {
+				return err
+			}
+
+			registration.Status = constants.RegistrationStatusGoing
+			registration.WaitlistedAt = nil
+			if full {
+				registration.Status = constants.RegistrationStatusWaitlisted
+				registration.WaitlistedAt = &now
+			}
+		}
+		registration.UpdatedAt = now
+
+		if err := tx.Save(&registration).Error; err != nil {
+			return err
+		}
+
+		if hadSeat && registration.Status != constants.RegistrationStatusGoing {
+			return promoteWaitlist(tx, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &registration, nil
+}
+
+func (r *RegistrationRepositoryImpl) DeleteRegistration(ctx context.Context, eventID, userID string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEvent(tx, eventID)
+		if err != nil {
+			return err
+		}
+
+		var registration models.Registration
+		if err := tx.Where("event_id = ? AND user_id = ?", eventID, userID).First(&registration).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("registration not found")
+			}
+			return err
+		}
+
+		if err := tx.Delete(&registration).Error; err != nil {
+			return err
+		}
+
+		if registration.Status == constants.RegistrationStatusGoing {
+			return promoteWaitlist(tx, event)
+		}
+
+		return nil
+	})
+}
+
+func (r *RegistrationRepositoryImpl) GetRegistration(ctx context.Context, eventID, userID string) (*models.Registration, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var registration models.Registration
+	if err := r.db.DB.WithContext(ctx).Where("event_id = ? AND user_id = ?", eventID, userID).First(&registration).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &registration, nil
+}
+
+func (r *RegistrationRepositoryImpl) PromoteWaitlist(ctx context.Context, eventID string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEvent(tx, eventID)
+		if err != nil {
+			return err
+		}
+
+		return promoteWaitlist(tx, event)
+	})
+}
+
+func (r *RegistrationRepositoryImpl) GetEventAttendees(ctx context.Context, eventID string) ([]models.AttendeeResponse, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var attendees []models.AttendeeResponse
+	err := r.db.DB.WithContext(ctx).Table("registrations").
+		// Hidden profiles stay on the list, so seats add up, without their
+		// name and avatar.
+		Select("registrations.user_id, "+
+			"CASE WHEN users.hidden_at IS NULL THEN users.name ELSE '' END AS name, "+
+			"CASE WHEN users.hidden_at IS NULL THEN users.avatar ELSE '' END AS avatar, "+
+			"registrations.status, registrations.waitlisted_at, registrations.created_at AS registered_at").
+		Joins("JOIN users ON users.id = registrations.user_id AND users.deleted_at IS NULL").
+		Where("registrations.event_id = ?", eventID).
+		Order("registrations.status, registrations.waitlisted_at, registrations.created_at").
+		Scan(&attendees).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return attendees, nil
+}
+
+func (r *RegistrationRepositoryImpl) GetUpcomingEventsByUser(ctx context.Context, userID string) ([]models.Event, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	now := time.Now()
+	var events []models.Event
+	err := r.db.DB.WithContext(ctx).
+		Joins("JOIN registrations ON registrations.event_id = events.id").
+		Where("registrations.user_id = ? AND registrations.status IN ?", userID, []constants.RegistrationStatus{
+			constants.RegistrationStatusGoing,
+			constants.RegistrationStatusMaybe,
+			constants.RegistrationStatusWaitlisted,
+		}).
+		// Events under way count as upcoming, and so do series with
+		// occurrences left, however long ago they started.
+		Where("(events.recurrence = '' AND events.end_date > ?) OR (events.recurrence <> '' AND (events.recurrence_end IS NULL OR events.recurrence_end > ?))", now, now).
+		Order("events.date ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// lockEvent loads the event with a row lock so capacity checks and waitlist
+// promotion for the same event are serialized.
+func lockEvent(tx *gorm.DB, eventID string) (*models.Event, error) {
+	var event models.Event
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func countGoing(tx *gorm.DB, eventID string) (int64, error) {
+	var count int64
+	err := tx.Model(&models.Registration{}).
+		Where("event_id = ? AND status = ?", eventID, constants.RegistrationStatusGoing).
+		Count(&count).Error
+
+	return count, err
+}
+
+func isEventFull(tx *gorm.DB, event *models.Event) (bool, error) {
+	if event.Capacity == nil {
+		return false, nil
+	}
+
+	going, err := countGoing(tx, event.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return going >= int64(*event.Capacity), nil
+}
+
+// promoteWaitlist moves the oldest waitlisted registrations to "going" until
+// the event is full again. The caller must hold the event lock.
+func promoteWaitlist(tx *gorm.DB, event *models.Event) error {
+	var waitlisted []models.Registration
+	query := tx.Where("event_id = ? AND status = ?", event.ID, constants.RegistrationStatusWaitlisted).
+		Order("waitlisted_at ASC")
+
+	if event.Capacity != nil {
+		going, err := countGoing(tx, event.ID)
+		if err != nil {
+			return err
+		}
+
+		free := int64(*event.Capacity) - going
+		if free <= 0 {
+			return nil
+		}
+		query = query.Limit(int(free))
+	}
+
+	if err := query.Find(&waitlisted).Error; err != nil {
+		return err
+	}
+
+	if len(waitlisted) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(waitlisted))
+	for i, registration := range waitlisted {
+		ids[i] = registration.ID
+	}
+
+	return tx.Model(&models.Registration{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":        constants.RegistrationStatusGoing,
+			"waitlisted_at": nil,
+			"updated_at":    time.Now(),
+		}).Error
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"gorm.io/gorm/clause"
+)
+
+type ReminderRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewReminderRepository(db *database.Database) ports.ReminderRepository {
+	return &ReminderRepositoryImpl{db: db}
+}
+
+func (r *ReminderRepositoryImpl) RecordReminder(ctx context.Context, reminder *models.EventReminder) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	result := r.db.Conn(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
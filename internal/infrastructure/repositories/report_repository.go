@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var activeAbuseCaseStatuses = []constants.AbuseCaseStatus{
+	constants.AbuseCaseStatusOpen,
+	constants.AbuseCaseStatusInvestigating,
+}
+
+type ReportRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewReportRepository(db *database.Database) ports.ReportRepository {
+	return &ReportRepositoryImpl{db: db}
+}
+
+func (r *ReportRepositoryImpl) AddReport(ctx context.Context, report *models.Report) (*models.AbuseCase, bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, false, errors.New("database connection is not initialized")
+	}
+
+	var abuseCase models.AbuseCase
+	added := false
+	err := r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The predicate must be spelled out for Postgres to pick the partial
+		// unique index over active cases as the conflict target.
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "target_type"}, {Name: "target_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "status IN ('open', 'investigating')"}}},
+			DoNothing:   true,
+		}).Create(&models.AbuseCase{
+			ID:         uuid.New().String(),
+			TargetType: report.TargetType,
+			TargetID:   report.TargetID,
+			Status:     constants.AbuseCaseStatusOpen,
+			CreatedAt:  report.CreatedAt,
+			UpdatedAt:  report.CreatedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("target_type = ? AND target_id = ? AND status IN ?", report.TargetType, report.TargetID, activeAbuseCaseStatuses).
+			First(&abuseCase).Error; err != nil {
+			return err
+		}
+
+		report.CaseID = abuseCase.ID
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&abuseCase).Updates(map[string]interface{}{
+			"reporter_count": gorm.Expr("reporter_count + 1"),
+			"updated_at":     report.CreatedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		abuseCase.ReporterCount++
+		abuseCase.UpdatedAt = report.CreatedAt
+		added = true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &abuseCase, added, nil
+}
+
+func (r *ReportRepositoryImpl) GetCase(ctx context.Context, caseID string) (*models.AbuseCase, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var abuseCase models.AbuseCase
+	if err := r.db.DB.WithContext(ctx).First(&abuseCase, "id = ?", caseID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &abuseCase, nil
+}
+
+func (r *ReportRepositoryImpl) ListCases(ctx context.Context, filter *models.AbuseCaseFilter, cursor *models.EventCursor, limit int) ([]models.AbuseCase, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := r.db.DB.WithContext(ctx).Model(&models.AbuseCase{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	} else {
+		query = query.Where("status IN ?", activeAbuseCaseStatuses)
+	}
+
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+
+	if cursor != nil {
+		query = query.Where("(created_at, id) > (?, ?)", cursor.Value, cursor.ID)
+	}
+
+	var cases []models.AbuseCase
+	if err := query.
+		Order("created_at, id").
+		Limit(limit).
+		Find(&cases).Error; err != nil {
+		return nil, err
+	}
+
+	return cases, nil
+}
+
+func (r *ReportRepositoryImpl) GetReports(ctx context.Context, caseID string) ([]models.Report, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var reports []models.Report
+	if err := r.db.DB.WithContext(ctx).
+		Where("case_id = ?", caseID).
+		Order("created_at, id").
+		Find(&reports).Error; err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (r *ReportRepositoryImpl) GetActions(ctx context.Context, caseID string) ([]models.AbuseCaseAction, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var actions []models.AbuseCaseAction
+	if err := r.db.DB.WithContext(ctx).
+		Where("case_id = ?", caseID).
+		Order("created_at, id").
+		Find(&actions).Error; err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+func (r *ReportRepositoryImpl) UpdateCase(ctx context.Context, abuseCase *models.AbuseCase, from constants.AbuseCaseStatus, actions []models.AbuseCaseAction) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	updated := false
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		// Reports keep counting while a moderator works on the case, so
+		// reporter_count is left alone.
+		result := tx.Model(&models.AbuseCase{}).
+			Where("id = ? AND status = ?", abuseCase.ID, from).
+			Select("status", "hidden", "assignee_id", "resolution", "updated_at", "closed_at").
+			Updates(abuseCase)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		updated = true
+		if len(actions) == 0 {
+			return nil
+		}
+
+		return tx.Create(&actions).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+)
+
+type SecurityEventRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewSecurityEventRepository(db *database.Database) ports.SecurityEventRepository {
+	return &SecurityEventRepositoryImpl{db: db}
+}
+
+func (r *SecurityEventRepositoryImpl) RecordEvent(ctx context.Context, event *models.SecurityEvent) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Create(event).Error
+}
+
+func (r *SecurityEventRepositoryImpl) ListEvents(ctx context.Context, filter *models.SecurityEventFilter, cursor *models.EventCursor, limit int) ([]models.SecurityEvent, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	query := r.db.DB.WithContext(ctx).Model(&models.SecurityEvent{})
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	if filter.Email != "" {
+		query = query.Where("LOWER(email) = LOWER(?)", filter.Email)
+	}
+
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+
+	if cursor != nil {
+		query = query.Where("(created_at, id) < (?, ?)", cursor.Value, cursor.ID)
+	}
+
+	var events []models.SecurityEvent
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
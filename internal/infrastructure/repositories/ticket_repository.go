@@ -0,0 +1,221 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/constants"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TicketRepositoryImpl struct {
+	db *database.Database
+}
+
+func NewTicketRepository(db *database.Database) ports.TicketRepository {
+	return &TicketRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *TicketRepositoryImpl) CreateTicketType(ctx context.Context, ticketType *models.TicketType) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Create(ticketType).Error
+}
+
+func (r *TicketRepositoryImpl) UpdateTicketType(ctx context.Context, ticketType *models.TicketType) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	result := r.db.Conn(ctx).Model(&models.TicketType{}).
+		Where("id = ?", ticketType.ID).
+		Select("*").
+		Omit("id", "event_id", "created_at").
+		Updates(ticketType)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("ticket type not found")
+	}
+
+	return nil
+}
+
+func (r *TicketRepositoryImpl) GetTicketType(ctx context.Context, ticketTypeID string) (*models.TicketType, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var ticketType models.TicketType
+	if err := r.db.Conn(ctx).First(&ticketType, "id = ?", ticketTypeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &ticketType, nil
+}
+
+func (r *TicketRepositoryImpl) GetTicketTypesByEvent(ctx context.Context, eventID string) ([]models.TicketTypeResponse, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var ticketTypes []models.TicketTypeResponse
+	err := r.db.Conn(ctx).Model(&models.TicketType{}).
+		Select("ticket_types.*, (SELECT COUNT(*) FROM tickets WHERE tickets.ticket_type_id = ticket_types.id AND tickets.status = ?) AS sold", constants.TicketStatusValid).
+		Where("ticket_types.event_id = ?", eventID).
+		Order("ticket_types.price ASC, ticket_types.created_at ASC").
+		Scan(&ticketTypes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ticketTypes, nil
+}
+
+func (r *TicketRepositoryImpl) IssueTicket(ctx context.Context, ticket *models.Ticket) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var ticketType models.TicketType
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ticketType, "id = ?", ticket.TicketTypeID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("ticket type not found")
+			}
+			return err
+		}
+
+		var owned int64
+		if err := tx.Model(&models.Ticket{}).
+			Where("ticket_type_id = ? AND user_id = ? AND status = ?", ticket.TicketTypeID, ticket.UserID, constants.TicketStatusValid).
+			Count(&owned).Error; err != nil {
+			return err
+		}
+
+		if owned > 0 {
+			return fmt.Errorf("%w: user already has a ticket of this type", ports.ErrTicketUnavailable)
+		}
+
+		var sold int64
+		if err := tx.Model(&models.Ticket{}).
+			Where("ticket_type_id = ? AND status = ?", ticket.TicketTypeID, constants.TicketStatusValid).
+			Count(&sold).Error; err != nil {
+			return err
+		}
+
+		if sold >= int64(ticketType.Quota) {
+			return fmt.Errorf("%w: tickets of this type are sold out", ports.ErrTicketUnavailable)
+		}
+
+		return tx.Create(ticket).Error
+	})
+}
+
+func (r *TicketRepositoryImpl) GetTicket(ctx context.Context, ticketID string) (*models.Ticket, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var ticket models.Ticket
+	if err := r.db.Conn(ctx).First(&ticket, "id = ?", ticketID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+func (r *TicketRepositoryImpl) GetTicketsByUser(ctx context.Context, userID string) ([]models.Ticket, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var tickets []models.Ticket
+	if err := r.db.Conn(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+func (r *TicketRepositoryImpl) CancelTicket(ctx context.Context, ticketID string) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return r.db.Conn(ctx).Model(&models.Ticket{}).
+		Where("id = ?", ticketID).
+		Updates(map[string]interface{}{
+			"status":     constants.TicketStatusCancelled,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *TicketRepositoryImpl) CheckIn(ctx context.Context, ticketID, checkedInBy string, at time.Time) (bool, error) {
+	if r.db == nil || r.db.DB == nil {
+		return false, errors.New("database connection is not initialized")
+	}
+
+	result := r.db.Conn(ctx).Model(&models.Ticket{}).
+		Where("id = ? AND status = ? AND checked_in_at IS NULL", ticketID, constants.TicketStatusValid).
+		Updates(map[string]interface{}{
+			"checked_in_at": at,
+			"checked_in_by": checkedInBy,
+			"updated_at":    at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *TicketRepositoryImpl) GetAttendanceStats(ctx context.Context, eventID string) (*models.AttendanceStats, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var byType []models.TicketTypeAttendance
+	err := r.db.Conn(ctx).Table("ticket_types").
+		Select("ticket_types.id AS ticket_type_id, ticket_types.name, COUNT(tickets.id) AS issued, COUNT(tickets.checked_in_at) AS checked_in").
+		Joins("LEFT JOIN tickets ON tickets.ticket_type_id = ticket_types.id AND tickets.status = ?", constants.TicketStatusValid).
+		Where("ticket_types.event_id = ?", eventID).
+		Group("ticket_types.id, ticket_types.name").
+		Order("ticket_types.name").
+		Scan(&byType).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.AttendanceStats{
+		EventID: eventID,
+		ByType:  byType,
+	}
+	for _, t := range byType {
+		stats.Issued += t.Issued
+		stats.CheckedIn += t.CheckedIn
+	}
+
+	return stats, nil
+}
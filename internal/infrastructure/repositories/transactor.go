@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
+)
+
+type TransactorImpl struct {
+	db *database.Database
+}
+
+func NewTransactor(db *database.Database) ports.Transactor {
+	return &TransactorImpl{db: db}
+}
+
+func (t *TransactorImpl) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if t.db == nil || t.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	return t.db.WithinTransaction(ctx, fn)
+}
@@ -1,9 +1,11 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"time"
 
+	"github.com/EventFlow-Project/backend/internal/core/constants"
 	"github.com/EventFlow-Project/backend/internal/core/models"
 	"github.com/EventFlow-Project/backend/internal/core/ports"
 	"github.com/EventFlow-Project/backend/internal/infrastructure/database"
@@ -53,21 +55,11 @@ func (r *UserRepositoryImpl) EditUserInfo(userID string, info *models.EditUserIn
 		return nil, result.Error
 	}
 
-	if info.Email != user.Email {
-		var existingUser models.User
-		result := r.db.DB.Where("email = ? AND id != ?", info.Email, userID).First(&existingUser)
-
-		if result.Error == nil {
-			return nil, errors.New("email already in use")
-		}
-
-		if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, result.Error
-		}
-	}
-	user.Email = info.Email
 	user.Name = info.Name
 	user.Avatar = info.Avatar
+	if info.Locale != "" {
+		user.Locale = info.Locale
+	}
 	user.UpdatedAt = time.Now()
 
 	result = r.db.DB.Save(&user)
@@ -84,10 +76,72 @@ func (r *UserRepositoryImpl) SearchUsersByName(name string) ([]*models.User, err
 	}
 	var users []*models.User
 
-	result := r.db.DB.Where("LOWER(name) LIKE LOWER(?)", "%"+name+"%").Find(&users)
+	result := r.db.DB.Where("LOWER(name) LIKE LOWER(?) AND hidden_at IS NULL", "%"+name+"%").Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return users, nil
+}
+
+func (r *UserRepositoryImpl) UpdateUserRole(userID string, role constants.UserRole) (*models.User, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+	var user models.User
+
+	result := r.db.DB.Where("id = ?", userID).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+
+		return nil, result.Error
+	}
+
+	user.Role = role
+	user.UpdatedAt = time.Now()
+
+	result = r.db.DB.Save(&user)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	return &user, nil
+}
+
+func (r *UserRepositoryImpl) SetUserHidden(ctx context.Context, userID string, hidden bool) error {
+	if r.db == nil || r.db.DB == nil {
+		return errors.New("database connection is not initialized")
+	}
+
+	var hiddenAt *time.Time
+	if hidden {
+		now := time.Now()
+		hiddenAt = &now
+	}
+
+	result := r.db.Conn(ctx).Model(&models.User{}).Where("id = ?", userID).Update("hidden_at", hiddenAt)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryImpl) GetUsersByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	if r.db == nil || r.db.DB == nil {
+		return nil, errors.New("database connection is not initialized")
+	}
+
+	var users []*models.User
+	if err := r.db.Conn(ctx).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
 	return users, nil
 }
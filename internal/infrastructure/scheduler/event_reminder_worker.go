@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// eventReminderLockKey identifies the advisory lock that lets only one
+// replica send event reminders at a time.
+const eventReminderLockKey int64 = 0x45564e54524d // "EVNTRM"
+
+type EventReminderWorker struct {
+	interval        time.Duration
+	reminderService *services.ReminderService
+	lockRepository  ports.LockRepository
+	log             *logger.Logger
+}
+
+func NewEventReminderWorker(
+	cfg *config.Config,
+	reminderService *services.ReminderService,
+	lockRepository ports.LockRepository,
+	log *logger.Logger,
+) *EventReminderWorker {
+	return &EventReminderWorker{
+		interval:        cfg.Scheduler.EventReminderInterval,
+		reminderService: reminderService,
+		lockRepository:  lockRepository,
+		log:             log.With(zap.String("worker", "event_reminder")),
+	}
+}
+
+func (w *EventReminderWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *EventReminderWorker) tick(ctx context.Context) {
+	queued := 0
+	acquired, err := w.lockRepository.TryWithLock(ctx, eventReminderLockKey, func(ctx context.Context) error {
+		var err error
+		queued, err = w.reminderService.SendReminders(ctx, time.Now())
+		return err
+	})
+
+	if err != nil {
+		if ctx.Err() == nil {
+			w.log.Error("Failed to send event reminders", zap.Error(err))
+		}
+		return
+	}
+
+	if acquired && queued > 0 {
+		w.log.Info("Queued event reminders", zap.Int("queued", queued))
+	}
+}
+
+func StartEventReminderWorker(lc fx.Lifecycle, worker *EventReminderWorker) {
+	runWorker(lc, worker.Run)
+}
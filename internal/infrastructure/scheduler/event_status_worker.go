@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// eventStatusLockKey identifies the advisory lock that lets only one replica
+// sync event statuses at a time.
+const eventStatusLockKey int64 = 0x45564e545354 // "EVNTST"
+
+type EventStatusWorker struct {
+	interval       time.Duration
+	eventService   *services.EventService
+	lockRepository ports.LockRepository
+	log            *logger.Logger
+}
+
+func NewEventStatusWorker(
+	cfg *config.Config,
+	eventService *services.EventService,
+	lockRepository ports.LockRepository,
+	log *logger.Logger,
+) *EventStatusWorker {
+	return &EventStatusWorker{
+		interval:       cfg.Scheduler.EventStatusInterval,
+		eventService:   eventService,
+		lockRepository: lockRepository,
+		log:            log.With(zap.String("worker", "event_status")),
+	}
+}
+
+func (w *EventStatusWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *EventStatusWorker) tick(ctx context.Context) {
+	changed := 0
+	acquired, err := w.lockRepository.TryWithLock(ctx, eventStatusLockKey, func(ctx context.Context) error {
+		var err error
+		changed, err = w.eventService.SyncEventStatuses(ctx, time.Now())
+		return err
+	})
+
+	if err != nil {
+		if ctx.Err() == nil {
+			w.log.Error("Failed to sync event statuses", zap.Error(err))
+		}
+		return
+	}
+
+	if acquired && changed > 0 {
+		w.log.Info("Synced event statuses", zap.Int("changed", changed))
+	}
+}
+
+func StartEventStatusWorker(lc fx.Lifecycle, worker *EventStatusWorker) {
+	runWorker(lc, worker.Run)
+}
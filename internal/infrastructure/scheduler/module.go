@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("scheduler",
+	fx.Provide(
+		NewEventStatusWorker,
+		NewOutboxWorker,
+		NewEventReminderWorker,
+		NewRateLimitPruneWorker,
+		NewPaymentRefundWorker,
+	),
+	fx.Invoke(
+		StartEventStatusWorker,
+		StartOutboxWorker,
+		StartEventReminderWorker,
+		StartRateLimitPruneWorker,
+		StartPaymentRefundWorker,
+	),
+)
+
+// runWorker runs fn in the background for the lifetime of the application
+// and waits for it to return on shutdown.
+func runWorker(lc fx.Lifecycle, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				fn(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// OutboxWorker drains the outbox. Replicas claim different messages, so it
+// needs no lock.
+type OutboxWorker struct {
+	interval      time.Duration
+	outboxService *services.OutboxService
+	log           *logger.Logger
+}
+
+func NewOutboxWorker(
+	cfg *config.Config,
+	outboxService *services.OutboxService,
+	log *logger.Logger,
+) *OutboxWorker {
+	return &OutboxWorker{
+		interval:      cfg.Outbox.PollInterval,
+		outboxService: outboxService,
+		log:           log.With(zap.String("worker", "outbox")),
+	}
+}
+
+func (w *OutboxWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		// A full batch suggests more is waiting, so keep going without
+		// waiting for the next tick.
+		for w.tick(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick delivers one batch and reports whether anything was delivered.
+func (w *OutboxWorker) tick(ctx context.Context) bool {
+	sent, failed, err := w.outboxService.DeliverDue(ctx, time.Now())
+	if err != nil {
+		if ctx.Err() == nil {
+			w.log.Error("Failed to deliver outbox messages", zap.Error(err))
+		}
+		return false
+	}
+
+	if failed > 0 {
+		w.log.Warn("Some outbox messages failed", zap.Int("sent", sent), zap.Int("failed", failed))
+	} else if sent > 0 {
+		w.log.Info("Delivered outbox messages", zap.Int("sent", sent))
+	}
+
+	return sent > 0 && ctx.Err() == nil
+}
+
+func StartOutboxWorker(lc fx.Lifecycle, worker *OutboxWorker) {
+	runWorker(lc, worker.Run)
+}
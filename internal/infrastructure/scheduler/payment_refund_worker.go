@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/core/services"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// paymentRefundLockKey identifies the advisory lock that lets only one
+// replica retry refunds at a time.
+const paymentRefundLockKey int64 = 0x52464e445254 // "RFNDRT"
+
+// PaymentRefundWorker retries refunds of payments whose ticket could not be
+// issued, until the provider accepts them.
+type PaymentRefundWorker struct {
+	interval       time.Duration
+	paymentService *services.PaymentService
+	lockRepository ports.LockRepository
+	log            *logger.Logger
+}
+
+func NewPaymentRefundWorker(
+	cfg *config.Config,
+	paymentService *services.PaymentService,
+	lockRepository ports.LockRepository,
+	log *logger.Logger,
+) *PaymentRefundWorker {
+	return &PaymentRefundWorker{
+		interval:       cfg.Payments.RefundRetryInterval,
+		paymentService: paymentService,
+		lockRepository: lockRepository,
+		log:            log.With(zap.String("worker", "payment_refund")),
+	}
+}
+
+func (w *PaymentRefundWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *PaymentRefundWorker) tick(ctx context.Context) {
+	refunded := 0
+	_, err := w.lockRepository.TryWithLock(ctx, paymentRefundLockKey, func(ctx context.Context) error {
+		var err error
+		refunded, err = w.paymentService.RetryPendingRefunds(ctx)
+		return err
+	})
+
+	if refunded > 0 {
+		w.log.Info("Refunded unfulfilled orders", zap.Int("refunded", refunded))
+	}
+
+	if err != nil && ctx.Err() == nil {
+		w.log.Error("Failed to refund unfulfilled orders", zap.Error(err))
+	}
+}
+
+func StartPaymentRefundWorker(lc fx.Lifecycle, worker *PaymentRefundWorker) {
+	runWorker(lc, worker.Run)
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+	"github.com/EventFlow-Project/backend/internal/infrastructure/logger"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// RateLimitPruneWorker deletes expired rate limit counters. Deleting twice
+// is harmless, so replicas need no lock.
+type RateLimitPruneWorker struct {
+	interval time.Duration
+	store    ports.RateLimitStore
+	log      *logger.Logger
+}
+
+func NewRateLimitPruneWorker(
+	cfg *config.Config,
+	store ports.RateLimitStore,
+	log *logger.Logger,
+) *RateLimitPruneWorker {
+	return &RateLimitPruneWorker{
+		interval: cfg.RateLimit.PruneInterval,
+		store:    store,
+		log:      log.With(zap.String("worker", "rate_limit_prune")),
+	}
+}
+
+func (w *RateLimitPruneWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := w.store.DeleteExpired(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			w.log.Error("Failed to delete expired rate limit counters", zap.Error(err))
+		}
+	}
+}
+
+func StartRateLimitPruneWorker(lc fx.Lifecycle, worker *RateLimitPruneWorker) {
+	runWorker(lc, worker.Run)
+}
@@ -0,0 +1,109 @@
+package screening
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+const (
+	BannedWordsScreenerName = "banned_words"
+
+	defaultBannedWordScore = 0.5
+)
+
+type bannedWord struct {
+	// pattern is the normalized term surrounded by spaces, without the
+	// trailing space for prefixes, to be matched against words joined by
+	// spaces.
+	pattern string
+	term    string
+	score   float64
+}
+
+// BannedWordsScreener reports banned words and phrases in the title and
+// description of an event, each distinct term once.
+type BannedWordsScreener struct {
+	words []bannedWord
+}
+
+func NewBannedWordsScreener(lists ...io.Reader) (*BannedWordsScreener, error) {
+	screener := &BannedWordsScreener{}
+
+	for _, list := range lists {
+		if err := screener.load(list); err != nil {
+			return nil, err
+		}
+	}
+
+	return screener, nil
+}
+
+// load reads a word list: one term per line, optionally followed by its
+// score. Empty lines and lines starting with # are skipped.
+func (s *BannedWordsScreener) load(list io.Reader) error {
+	scanner := bufio.NewScanner(list)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		score := defaultBannedWordScore
+		if len(fields) > 1 {
+			if value, err := strconv.ParseFloat(fields[len(fields)-1], 64); err == nil {
+				if value <= 0 {
+					return fmt.Errorf("banned words line %d: score must be positive", line)
+				}
+				score = value
+				fields = fields[:len(fields)-1]
+			}
+		}
+
+		term := strings.Join(fields, " ")
+		prefix := strings.HasSuffix(term, "*")
+
+		words := normalizeWords(strings.TrimSuffix(term, "*"))
+		if len(words) == 0 {
+			return fmt.Errorf("banned words line %d: empty term", line)
+		}
+
+		pattern := " " + strings.Join(words, " ")
+		if !prefix {
+			pattern += " "
+		}
+
+		s.words = append(s.words, bannedWord{pattern: pattern, term: term, score: score})
+	}
+
+	return scanner.Err()
+}
+
+func (s *BannedWordsScreener) Name() string {
+	return BannedWordsScreenerName
+}
+
+func (s *BannedWordsScreener) Screen(ctx context.Context, event *models.Event) (models.ScreeningFindings, error) {
+	// A double space between the title and the description keeps phrases
+	// from matching across them.
+	text := " " + strings.Join(normalizeWords(event.Title), " ") + "  " +
+		strings.Join(normalizeWords(event.Description), " ") + " "
+
+	var findings models.ScreeningFindings
+	for _, word := range s.words {
+		if strings.Contains(text, word.pattern) {
+			findings = append(findings, models.ScreeningFinding{
+				Rule:   BannedWordsScreenerName,
+				Detail: fmt.Sprintf("banned word %q", word.term),
+				Score:  word.score,
+			})
+		}
+	}
+
+	return findings, nil
+}
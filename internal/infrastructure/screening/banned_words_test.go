@@ -0,0 +1,96 @@
+package screening
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+const testWordlist = `# comment
+
+казино*
+free money 0.8
+bet
+`
+
+func TestNormalizeWords(t *testing.T) {
+	for _, test := range []struct {
+		text string
+		want []string
+	}{
+		{"Hello, World!", []string{"hello", "world"}},
+		{"Ёлка и ЁЖ", []string{"елка", "и", "еж"}},
+		{"кaзинo", []string{"казино"}},
+		{"Hotel Tokyo", []string{"hotel", "tokyo"}},
+		{"top-10 events", []string{"top", "10", "events"}},
+		{"  ...  ", []string{}},
+	} {
+		if got := normalizeWords(test.text); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("normalizeWords(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestBannedWordsScreener(t *testing.T) {
+	screener, err := NewBannedWordsScreener(strings.NewReader(testWordlist))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name               string
+		title, description string
+		want               []string
+	}{
+		{"clean", "Jazz evening", "Live music in the park", nil},
+		{"exact word", "Казино", "", []string{"казино*"}},
+		{"prefix", "Лучшие казиношки города", "", []string{"казино*"}},
+		{"latin lookalikes", "кaзинo", "", []string{"казино*"}},
+		{"phrase", "Get FREE money now", "", []string{"free money"}},
+		{"phrase across title and description", "Get it free", "money back", nil},
+		{"whole word only", "Betting tips", "", nil},
+		{"description", "Meetup", "Place your bet here", []string{"bet"}},
+		{"each term once", "Bet bet", "казино bet", []string{"казино*", "bet"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			findings, err := screener.Screen(context.Background(), &models.Event{Title: test.title, Description: test.description})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var terms []string
+			for _, finding := range findings {
+				if finding.Rule != BannedWordsScreenerName {
+					t.Errorf("finding rule = %q", finding.Rule)
+				}
+				terms = append(terms, strings.TrimSuffix(strings.TrimPrefix(finding.Detail, `banned word "`), `"`))
+			}
+			if !reflect.DeepEqual(terms, test.want) {
+				t.Errorf("found %q, want %q", terms, test.want)
+			}
+		})
+	}
+}
+
+func TestBannedWordsScores(t *testing.T) {
+	screener, err := NewBannedWordsScreener(strings.NewReader(testWordlist))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, _ := screener.Screen(context.Background(), &models.Event{Title: "казино free money"})
+	if len(findings) != 2 || findings[0].Score != defaultBannedWordScore || findings[1].Score != 0.8 {
+		t.Errorf("findings = %+v, want the default score and 0.8", findings)
+	}
+}
+
+func TestBannedWordsInvalidList(t *testing.T) {
+	for _, list := range []string{"casino 0", "casino -1", "*", "!!! 0.5"} {
+		if _, err := NewBannedWordsScreener(strings.NewReader(list)); err == nil {
+			t.Errorf("list %q was accepted", list)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+const (
+	DuplicateTitleScreenerName = "duplicate_title"
+
+	duplicateTitleScore = 0.3
+	// Duplicates alone never add up to a rejection: an organizer repeating an
+	// event without making it recurring is a nuisance, not a spammer.
+	maxDuplicateTitleScore = 0.9
+	// Titles whose word sets overlap by at least similarTitleThreshold
+	// (Jaccard index) count as duplicates.
+	similarTitleThreshold = 0.8
+)
+
+// DuplicateTitleScreener reports events whose title repeats the titles of
+// other events the organizer created recently.
+type DuplicateTitleScreener struct {
+	eventRepository ports.EventRepository
+	window          time.Duration
+}
+
+func NewDuplicateTitleScreener(eventRepository ports.EventRepository, window time.Duration) *DuplicateTitleScreener {
+	return &DuplicateTitleScreener{
+		eventRepository: eventRepository,
+		window:          window,
+	}
+}
+
+func (s *DuplicateTitleScreener) Name() string {
+	return DuplicateTitleScreenerName
+}
+
+func (s *DuplicateTitleScreener) Screen(ctx context.Context, event *models.Event) (models.ScreeningFindings, error) {
+	title := wordSet(event.Title)
+	if len(title) == 0 {
+		return nil, nil
+	}
+
+	recent, err := s.eventRepository.GetRecentEventsByOrganizer(ctx, event.Organizer, time.Now().Add(-s.window))
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := 0
+	for i := range recent {
+		if recent[i].ID == event.ID {
+			continue
+		}
+		if jaccard(title, wordSet(recent[i].Title)) >= similarTitleThreshold {
+			duplicates++
+		}
+	}
+
+	if duplicates == 0 {
+		return nil, nil
+	}
+
+	return models.ScreeningFindings{{
+		Rule:   DuplicateTitleScreenerName,
+		Detail: fmt.Sprintf("title repeats %d recent events", duplicates),
+		Score:  math.Min(float64(duplicates)*duplicateTitleScore, maxDuplicateTitleScore),
+	}}, nil
+}
+
+func wordSet(text string) map[string]bool {
+	words := normalizeWords(text)
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
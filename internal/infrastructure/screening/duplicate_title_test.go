@@ -0,0 +1,60 @@
+package screening
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+type recentEvents struct {
+	ports.EventRepository
+	titles []string
+}
+
+func (r *recentEvents) GetRecentEventsByOrganizer(ctx context.Context, organizerID string, since time.Time) ([]models.Event, error) {
+	events := []models.Event{{ID: "screened", Title: "Jazz evening in the park"}}
+	for _, title := range r.titles {
+		events = append(events, models.Event{ID: title, Title: title})
+	}
+	return events, nil
+}
+
+func TestDuplicateTitleScreener(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		recent []string
+		score  float64
+	}{
+		{"no other events", nil, 0},
+		{"different titles", []string{"Rock concert", "Jazz lecture"}, 0},
+		{"same title", []string{"Jazz evening in the park"}, duplicateTitleScore},
+		{"same words", []string{"JAZZ EVENING, in the park!"}, duplicateTitleScore},
+		{"one word more", []string{"Jazz evening in the city park"}, duplicateTitleScore},
+		{"one word different", []string{"Jazz morning in the park"}, 0},
+		{"capped", []string{
+			"Jazz evening in the park", "Jazz evening in the park!", "jazz evening in the park",
+			"Jazz Evening In The Park",
+		}, maxDuplicateTitleScore},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			screener := NewDuplicateTitleScreener(&recentEvents{titles: test.recent}, time.Hour)
+
+			findings, err := screener.Screen(context.Background(), &models.Event{ID: "screened", Title: "Jazz evening in the park"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var score float64
+			for _, finding := range findings {
+				score += finding.Score
+			}
+			if math.Abs(score-test.score) > 1e-9 {
+				t.Errorf("score = %v, want %v", score, test.score)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package screening
+
+import "go.uber.org/fx"
+
+var Module = fx.Module("screening",
+	fx.Provide(NewContentScreener),
+)
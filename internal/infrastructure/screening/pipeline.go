@@ -0,0 +1,86 @@
+package screening
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/EventFlow-Project/backend/internal/config"
+	"github.com/EventFlow-Project/backend/internal/core/models"
+	"github.com/EventFlow-Project/backend/internal/core/ports"
+)
+
+//go:embed wordlists/*.txt
+var wordlists embed.FS
+
+// Pipeline runs screeners one after another and collects their findings.
+type Pipeline struct {
+	screeners []ports.ContentScreener
+}
+
+func NewPipeline(screeners ...ports.ContentScreener) *Pipeline {
+	return &Pipeline{screeners: screeners}
+}
+
+func (p *Pipeline) Name() string {
+	return "pipeline"
+}
+
+func (p *Pipeline) Screen(ctx context.Context, event *models.Event) (models.ScreeningFindings, error) {
+	findings := models.ScreeningFindings{}
+	for _, screener := range p.screeners {
+		found, err := screener.Screen(ctx, event)
+		if err != nil {
+			return nil, fmt.Errorf("%s screener: %w", screener.Name(), err)
+		}
+		findings = append(findings, found...)
+	}
+
+	return findings, nil
+}
+
+// NewContentScreener builds the pipeline of built-in screeners.
+func NewContentScreener(cfg *config.Config, eventRepository ports.EventRepository) (ports.ContentScreener, error) {
+	ru, err := loadWordlist(cfg.Screening.BannedWordsRUFile, "wordlists/ru.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	en, err := loadWordlist(cfg.Screening.BannedWordsENFile, "wordlists/en.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	bannedWords, err := NewBannedWordsScreener(ru, en)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPipeline(
+		bannedWords,
+		NewSpamScreener(cfg.Screening.MaxLinks),
+		NewDuplicateTitleScreener(eventRepository, cfg.Screening.DuplicateWindow),
+	), nil
+}
+
+// loadWordlist reads the configured word list file, or the built-in list when
+// none is configured.
+func loadWordlist(path, builtin string) (io.Reader, error) {
+	if path == "" {
+		data, err := wordlists.ReadFile(builtin)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(data), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read banned words: %w", err)
+	}
+
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,133 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+const (
+	SpamScreenerName = "spam"
+
+	linkInTitleScore       = 0.5
+	extraLinkScore         = 0.2
+	maxExtraLinksScore     = 0.8
+	shortenedLinkScore     = 0.3
+	phoneInTitleScore      = 0.5
+	extraPhoneScore        = 0.2
+	maxExtraPhonesScore    = 0.6
+	shoutingTitleScore     = 0.2
+	repeatedPunctuationMin = 3
+	repeatedPunctScore     = 0.1
+
+	// A title is shouting when at least shoutingRatio of its letters, and at
+	// least shoutingMinLetters of them, are capitals.
+	shoutingRatio      = 0.7
+	shoutingMinLetters = 8
+)
+
+var (
+	linkPattern = regexp.MustCompile(`(?i)(?:https?://|www\.)[^\s]+|(?:^|[^\w@.-])((?:[a-z0-9-]+\.)+(?:ru|su|com|net|org|info|biz|io|me|xyz|top|online|site|club|shop|pro|ly|co|cc|рф))(?:/[^\s]*)?`)
+	// phonePattern matches Russian numbers (+7 or 8 followed by ten digits)
+	// and other international numbers, with the usual separators.
+	phonePattern = regexp.MustCompile(`(?:\+7|\b8)[\s(-]*\d{3}[\s)-]*\d{3}[\s-]*\d{2}[\s-]*\d{2}\b|\+\d{1,3}(?:[\s()-]*\d){8,12}\b`)
+
+	repeatedPunctuationPattern = regexp.MustCompile(fmt.Sprintf(`[!?]{%d,}`, repeatedPunctuationMin))
+
+	shortenerDomains = []string{"bit.ly", "clck.ru", "t.co", "tinyurl.com", "goo.su", "cutt.ly", "is.gd", "vk.cc", "u.to"}
+)
+
+// SpamScreener looks for the marks of advertising spam: links and phone
+// numbers in the title, too many of them in the description, link shorteners
+// and shouting.
+type SpamScreener struct {
+	maxLinks int
+}
+
+func NewSpamScreener(maxLinks int) *SpamScreener {
+	return &SpamScreener{maxLinks: maxLinks}
+}
+
+func (s *SpamScreener) Name() string {
+	return SpamScreenerName
+}
+
+func (s *SpamScreener) Screen(ctx context.Context, event *models.Event) (models.ScreeningFindings, error) {
+	var findings models.ScreeningFindings
+	add := func(detail string, score float64) {
+		findings = append(findings, models.ScreeningFinding{
+			Rule:   SpamScreenerName,
+			Detail: detail,
+			Score:  score,
+		})
+	}
+
+	if linkPattern.MatchString(event.Title) {
+		add("link in the title", linkInTitleScore)
+	}
+
+	if phonePattern.MatchString(event.Title) {
+		add("phone number in the title", phoneInTitleScore)
+	}
+
+	links := linkPattern.FindAllString(event.Description, -1)
+	if extra := len(links) - s.maxLinks; extra > 0 {
+		add(fmt.Sprintf("%d links in the description", len(links)), math.Min(float64(extra)*extraLinkScore, maxExtraLinksScore))
+	}
+
+	for _, link := range links {
+		if isShortenedLink(link) {
+			add("shortened link", shortenedLinkScore)
+			break
+		}
+	}
+
+	if phones := phonePattern.FindAllString(event.Description, -1); len(phones) > 1 {
+		add(fmt.Sprintf("%d phone numbers in the description", len(phones)), math.Min(float64(len(phones)-1)*extraPhoneScore, maxExtraPhonesScore))
+	}
+
+	if isShouting(event.Title) {
+		add("title in capitals", shoutingTitleScore)
+	}
+
+	if repeatedPunctuationPattern.MatchString(event.Title) {
+		add("repeated punctuation in the title", repeatedPunctScore)
+	}
+
+	return findings, nil
+}
+
+func isShortenedLink(link string) bool {
+	link = strings.ToLower(strings.TrimLeft(link, " \t\n([{\"'"))
+	link = strings.TrimPrefix(link, "http://")
+	link = strings.TrimPrefix(link, "https://")
+	link = strings.TrimPrefix(link, "www.")
+
+	for _, domain := range shortenerDomains {
+		if strings.HasPrefix(link, domain+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isShouting(title string) bool {
+	letters, upper := 0, 0
+	for _, r := range title {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+
+	return letters >= shoutingMinLetters && float64(upper) >= shoutingRatio*float64(letters)
+}
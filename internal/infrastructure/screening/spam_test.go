@@ -0,0 +1,57 @@
+package screening
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/EventFlow-Project/backend/internal/core/models"
+)
+
+func TestSpamScreener(t *testing.T) {
+	screener := NewSpamScreener(2)
+
+	for _, test := range []struct {
+		name               string
+		title, description string
+		want               []models.ScreeningFinding
+	}{
+		{"clean", "Jazz evening", "Live music, see https://example.com/jazz", nil},
+		{"link in the title", "Tickets at example.ru", "", []models.ScreeningFinding{
+			{Rule: SpamScreenerName, Detail: "link in the title", Score: linkInTitleScore},
+		}},
+		{"email is not a link", "Write to info@example.ru", "", nil},
+		{"phone in the title", "Call +7 (999) 123-45-67", "", []models.ScreeningFinding{
+			{Rule: SpamScreenerName, Detail: "phone number in the title", Score: phoneInTitleScore},
+		}},
+		{"links within the limit", "Meetup", "a.ru b.ru", nil},
+		{"too many links", "Meetup", "a.ru b.ru www.c.com", []models.ScreeningFinding{
+			{Rule: SpamScreenerName, Detail: "3 links in the description", Score: extraLinkScore},
+		}},
+		{"shortened link", "Meetup", "Sign up: (bit.ly/abc)", []models.ScreeningFinding{
+			{Rule: SpamScreenerName, Detail: "shortened link", Score: shortenedLinkScore},
+		}},
+		{"one phone in the description", "Meetup", "Call 8 999 123 45 67", nil},
+		{"several phones in the description", "Meetup", "8-999-123-45-67 or +44 20 7946 0958", []models.ScreeningFinding{
+			{Rule: SpamScreenerName, Detail: "2 phone numbers in the description", Score: extraPhoneScore},
+		}},
+		{"shouting", "BIG SALE TODAY", "", []models.ScreeningFinding{
+			{Rule: SpamScreenerName, Detail: "title in capitals", Score: shoutingTitleScore},
+		}},
+		{"short capitals", "JAZZ BAR", "", nil},
+		{"repeated punctuation", "Party!?!", "", []models.ScreeningFinding{
+			{Rule: SpamScreenerName, Detail: "repeated punctuation in the title", Score: repeatedPunctScore},
+		}},
+		{"two exclamation marks", "Party!!", "", nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			findings, err := screener.Screen(context.Background(), &models.Event{Title: test.title, Description: test.description})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(findings, models.ScreeningFindings(test.want)) {
+				t.Errorf("findings = %+v, want %+v", findings, test.want)
+			}
+		})
+	}
+}
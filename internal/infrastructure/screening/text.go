@@ -0,0 +1,50 @@
+package screening
+
+import (
+	"strings"
+	"unicode"
+)
+
+// latinLookalikes maps Latin letters to the Cyrillic ones they are used to
+// disguise, as in "кaзино" typed with a Latin "a".
+var latinLookalikes = map[rune]rune{
+	'a': 'а', 'c': 'с', 'e': 'е', 'k': 'к', 'm': 'м', 'o': 'о',
+	'p': 'р', 'x': 'х', 'y': 'у', 'h': 'н', 'b': 'в', 't': 'т',
+}
+
+// normalizeWords splits text into lowercase words, folding "ё" into "е" and
+// Latin lookalikes inside Cyrillic words into Cyrillic letters.
+func normalizeWords(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = normalizeWord(word)
+	}
+
+	return words
+}
+
+func normalizeWord(word string) string {
+	word = strings.ReplaceAll(word, "ё", "е")
+
+	cyrillic := false
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			cyrillic = true
+			break
+		}
+	}
+
+	if !cyrillic {
+		return word
+	}
+
+	return strings.Map(func(r rune) rune {
+		if lookalike, ok := latinLookalikes[r]; ok {
+			return lookalike
+		}
+		return r
+	}, word)
+}
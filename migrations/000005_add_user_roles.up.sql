@@ -0,0 +1,6 @@
+UPDATE users SET role = 'participant' WHERE role NOT IN ('participant', 'organizer', 'moderator', 'admin');
+
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'participant';
+ALTER TABLE users ADD CONSTRAINT check_user_role CHECK (role IN ('participant', 'organizer', 'moderator', 'admin'));
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_co_organizers;
+ALTER TABLE events DROP COLUMN IF EXISTS co_organizers;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS registrations;
+ALTER TABLE events DROP COLUMN IF EXISTS capacity;
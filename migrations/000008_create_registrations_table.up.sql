@@ -0,0 +1,19 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS capacity INTEGER CHECK (capacity IS NULL OR capacity > 0);
+
+CREATE TABLE IF NOT EXISTS registrations (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    waitlisted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_registrations_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_registrations_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT unique_registration UNIQUE (event_id, user_id),
+    CONSTRAINT check_registration_status CHECK (status IN ('going', 'maybe', 'declined', 'waitlisted'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_registrations_user_id ON registrations(user_id);
+CREATE INDEX IF NOT EXISTS idx_registrations_event_status ON registrations(event_id, status);
+CREATE INDEX IF NOT EXISTS idx_registrations_waitlist ON registrations(event_id, waitlisted_at) WHERE status = 'waitlisted';
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS ticket_types (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    quota INTEGER NOT NULL CHECK (quota > 0),
+    price BIGINT NOT NULL DEFAULT 0 CHECK (price >= 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'RUB',
+    sales_start TIMESTAMP WITH TIME ZONE,
+    sales_end TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_ticket_types_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT check_ticket_type_sales_window CHECK (sales_start IS NULL OR sales_end IS NULL OR sales_end > sales_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_types_event_id ON ticket_types(event_id);
+
+CREATE TABLE IF NOT EXISTS tickets (
+    id VARCHAR(36) PRIMARY KEY,
+    ticket_type_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    checked_in_at TIMESTAMP WITH TIME ZONE,
+    checked_in_by VARCHAR(36),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_tickets_ticket_type FOREIGN KEY (ticket_type_id) REFERENCES ticket_types(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tickets_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tickets_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT check_ticket_status CHECK (status IN ('valid', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tickets_event_id ON tickets(event_id);
+CREATE INDEX IF NOT EXISTS idx_tickets_user_id ON tickets(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_unique_valid ON tickets(ticket_type_id, user_id) WHERE status = 'valid';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_created_at_id;
+DROP INDEX IF EXISTS idx_events_date_id;
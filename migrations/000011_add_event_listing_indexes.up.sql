@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_events_date_id ON events(date, id);
+CREATE INDEX IF NOT EXISTS idx_events_created_at_id ON events(created_at, id);
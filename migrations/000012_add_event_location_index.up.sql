@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_events_location ON events(lat, lng);
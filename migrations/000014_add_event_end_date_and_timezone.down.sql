@@ -0,0 +1,8 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS duration VARCHAR(50);
+
+UPDATE events SET duration = round(extract(epoch FROM end_date - date) / 60)::int || 'm';
+
+ALTER TABLE events ALTER COLUMN duration SET NOT NULL;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS check_event_end_after_start;
+ALTER TABLE events DROP COLUMN IF EXISTS timezone;
+ALTER TABLE events DROP COLUMN IF EXISTS end_date;
@@ -0,0 +1,23 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS end_date TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Durations were free-form strings; convert the ISO 8601 ("PT2H30M") and
+-- "2h30m" forms, anything else becomes one hour.
+UPDATE events SET end_date = date + CASE
+    WHEN upper(trim(duration)) ~ '^P(\d+W)?(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?$'
+        AND upper(trim(duration)) !~ '(^P|T)$'
+        THEN upper(trim(duration))::interval
+    WHEN lower(trim(duration)) ~ '^(\d+(\.\d+)?h)?(\d+(\.\d+)?m)?(\d+(\.\d+)?s)?$'
+        AND trim(duration) <> ''
+        THEN coalesce(substring(lower(duration) from '([\d.]+)h')::numeric, 0) * interval '1 hour'
+            + coalesce(substring(lower(duration) from '([\d.]+)m')::numeric, 0) * interval '1 minute'
+            + coalesce(substring(lower(duration) from '([\d.]+)s')::numeric, 0) * interval '1 second'
+    ELSE interval '0'
+END
+WHERE end_date IS NULL;
+
+UPDATE events SET end_date = date + interval '1 hour' WHERE end_date <= date;
+
+ALTER TABLE events ALTER COLUMN end_date SET NOT NULL;
+ALTER TABLE events ADD CONSTRAINT check_event_end_after_start CHECK (end_date > date);
+ALTER TABLE events DROP COLUMN IF EXISTS duration;
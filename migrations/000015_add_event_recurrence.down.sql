@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS occurrence_overrides;
+
+ALTER TABLE events DROP COLUMN IF EXISTS recurrence_end;
+ALTER TABLE events DROP COLUMN IF EXISTS recurrence_exceptions;
+ALTER TABLE events DROP COLUMN IF EXISTS recurrence;
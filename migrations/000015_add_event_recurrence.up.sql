@@ -0,0 +1,18 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS recurrence TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS recurrence_exceptions JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS recurrence_end TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS occurrence_overrides (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    occurrence_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    title VARCHAR(255),
+    description TEXT,
+    date TIMESTAMP WITH TIME ZONE,
+    end_date TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_occurrence_overrides_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT unique_occurrence_override UNIQUE (event_id, occurrence_date),
+    CONSTRAINT check_occurrence_override_dates CHECK (date IS NULL OR end_date > date)
+);
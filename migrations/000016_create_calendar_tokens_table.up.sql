@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    user_id VARCHAR(36) PRIMARY KEY,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_calendar_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
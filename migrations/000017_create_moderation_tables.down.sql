@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS moderation_claims;
+DROP TABLE IF EXISTS moderation_actions;
+DROP INDEX IF EXISTS idx_events_moderation_queue;
+ALTER TABLE events DROP COLUMN IF EXISTS submitted_at;
+ALTER TABLE events DROP COLUMN IF EXISTS moderation_reason;
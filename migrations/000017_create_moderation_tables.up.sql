@@ -0,0 +1,26 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS moderation_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMP WITH TIME ZONE;
+UPDATE events SET submitted_at = created_at WHERE submitted_at IS NULL;
+ALTER TABLE events ALTER COLUMN submitted_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_moderation_queue ON events(moderation_status, submitted_at, id);
+
+CREATE TABLE IF NOT EXISTS moderation_actions (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    actor_id VARCHAR(36) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_moderation_actions_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_event ON moderation_actions(event_id, created_at);
+
+CREATE TABLE IF NOT EXISTS moderation_claims (
+    event_id VARCHAR(36) PRIMARY KEY,
+    moderator_id VARCHAR(36) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_moderation_claims_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_moderation_claims_moderator FOREIGN KEY (moderator_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE events DROP COLUMN IF EXISTS screening_findings;
+ALTER TABLE events DROP COLUMN IF EXISTS screening_score;
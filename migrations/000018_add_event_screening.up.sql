@@ -0,0 +1,2 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS screening_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS screening_findings JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS abuse_case_actions;
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS abuse_cases;
+ALTER TABLE users DROP COLUMN IF EXISTS hidden_at;
@@ -0,0 +1,50 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS abuse_cases (
+    id VARCHAR(36) PRIMARY KEY,
+    target_type VARCHAR(20) NOT NULL,
+    target_id VARCHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    reporter_count INTEGER NOT NULL DEFAULT 0,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    assignee_id VARCHAR(36),
+    resolution VARCHAR(20),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_abuse_cases_assignee FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT check_abuse_case_target_type CHECK (target_type IN ('event', 'user', 'comment')),
+    CONSTRAINT check_abuse_case_status CHECK (status IN ('open', 'investigating', 'resolved', 'dismissed'))
+);
+
+-- Reports about a target gather in its single active case.
+CREATE UNIQUE INDEX IF NOT EXISTS unique_active_abuse_case ON abuse_cases(target_type, target_id)
+    WHERE status IN ('open', 'investigating');
+CREATE INDEX IF NOT EXISTS idx_abuse_cases_queue ON abuse_cases(status, created_at, id);
+
+CREATE TABLE IF NOT EXISTS reports (
+    id VARCHAR(36) PRIMARY KEY,
+    case_id VARCHAR(36) NOT NULL,
+    reporter_id VARCHAR(36) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id VARCHAR(36) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_reports_case FOREIGN KEY (case_id) REFERENCES abuse_cases(id) ON DELETE CASCADE,
+    CONSTRAINT fk_reports_reporter FOREIGN KEY (reporter_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT unique_case_reporter UNIQUE (case_id, reporter_id)
+);
+
+CREATE TABLE IF NOT EXISTS abuse_case_actions (
+    id VARCHAR(36) PRIMARY KEY,
+    case_id VARCHAR(36) NOT NULL,
+    actor_id VARCHAR(36) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_abuse_case_actions_case FOREIGN KEY (case_id) REFERENCES abuse_cases(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_abuse_case_actions_case ON abuse_case_actions(case_id, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS event_reminders;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+DROP TABLE IF EXISTS outbox;
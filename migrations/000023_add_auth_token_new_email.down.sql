@@ -0,0 +1,2 @@
+DELETE FROM auth_tokens WHERE purpose = 'email_change';
+ALTER TABLE auth_tokens DROP COLUMN IF EXISTS new_email;
@@ -0,0 +1 @@
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS new_email VARCHAR(255);
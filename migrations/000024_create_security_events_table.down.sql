@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS rate_limit_counters;
+DROP TABLE IF EXISTS security_events;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS security_events (
+    id VARCHAR(36) PRIMARY KEY,
+    type VARCHAR(30) NOT NULL,
+    user_id VARCHAR(36),
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_security_events_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_created ON security_events(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_security_events_user ON security_events(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_security_events_email ON security_events(LOWER(email), created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_security_events_ip ON security_events(ip, created_at DESC);
+
+-- Counters of the Postgres rate limit store (RATE_LIMIT_STORE=postgres).
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_counters (
+    key VARCHAR(512) PRIMARY KEY,
+    count INTEGER NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_counters_expires ON rate_limit_counters(expires_at);
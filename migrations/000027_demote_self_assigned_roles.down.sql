@@ -0,0 +1,6 @@
+-- Users whose role was changed since the demotion keep their current role.
+UPDATE users SET role = demoted_roles.role, updated_at = NOW()
+FROM demoted_roles
+WHERE users.id = demoted_roles.user_id AND users.role = 'participant';
+
+DROP TABLE IF EXISTS demoted_roles;
@@ -0,0 +1,15 @@
+-- Before roles were restricted, clients could pick any role when
+-- registering, so no moderator or admin role can be trusted. Administrators
+-- are appointed again with cmd/grant-admin. The previous roles are kept in
+-- demoted_roles so that the down migration can restore them.
+CREATE TABLE IF NOT EXISTS demoted_roles (
+    user_id VARCHAR(36) PRIMARY KEY,
+    role VARCHAR(50) NOT NULL,
+    CONSTRAINT fk_demoted_roles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO demoted_roles (user_id, role)
+SELECT id, role FROM users WHERE role IN ('moderator', 'admin')
+ON CONFLICT (user_id) DO NOTHING;
+
+UPDATE users SET role = 'participant', updated_at = NOW() WHERE role IN ('moderator', 'admin');
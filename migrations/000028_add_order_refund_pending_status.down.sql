@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_refund_pending;
+
+UPDATE orders SET status = 'paid' WHERE status = 'refund_pending';
+
+ALTER TABLE orders DROP CONSTRAINT check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status CHECK (status IN ('pending', 'paid', 'failed', 'refunded'));
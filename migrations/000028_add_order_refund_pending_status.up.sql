@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP CONSTRAINT check_order_status;
+ALTER TABLE orders ADD CONSTRAINT check_order_status CHECK (status IN ('pending', 'paid', 'failed', 'refunded', 'refund_pending'));
+
+CREATE INDEX idx_orders_refund_pending ON orders(updated_at) WHERE status = 'refund_pending';
@@ -0,0 +1,3 @@
+DELETE FROM auth_tokens WHERE purpose = 'stream_ticket';
+ALTER TABLE auth_tokens DROP COLUMN IF EXISTS access_expires_at;
+ALTER TABLE auth_tokens DROP COLUMN IF EXISTS session_id;
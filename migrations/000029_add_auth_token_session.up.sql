@@ -0,0 +1,2 @@
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS session_id VARCHAR(36);
+ALTER TABLE auth_tokens ADD COLUMN IF NOT EXISTS access_expires_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,8 @@
+ALTER TABLE orders DROP CONSTRAINT fk_orders_user;
+ALTER TABLE orders ADD CONSTRAINT fk_orders_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE orders DROP CONSTRAINT fk_orders_event;
+ALTER TABLE orders ADD CONSTRAINT fk_orders_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE;
+
+ALTER TABLE orders DROP CONSTRAINT fk_orders_ticket_type;
+ALTER TABLE orders ADD CONSTRAINT fk_orders_ticket_type FOREIGN KEY (ticket_type_id) REFERENCES ticket_types(id) ON DELETE CASCADE;
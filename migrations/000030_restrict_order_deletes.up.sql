@@ -0,0 +1,10 @@
+-- Orders record money that changed hands, so deleting an event, ticket type
+-- or user must not silently take them along.
+ALTER TABLE orders DROP CONSTRAINT fk_orders_user;
+ALTER TABLE orders ADD CONSTRAINT fk_orders_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE RESTRICT;
+
+ALTER TABLE orders DROP CONSTRAINT fk_orders_event;
+ALTER TABLE orders ADD CONSTRAINT fk_orders_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE RESTRICT;
+
+ALTER TABLE orders DROP CONSTRAINT fk_orders_ticket_type;
+ALTER TABLE orders ADD CONSTRAINT fk_orders_ticket_type FOREIGN KEY (ticket_type_id) REFERENCES ticket_types(id) ON DELETE RESTRICT;
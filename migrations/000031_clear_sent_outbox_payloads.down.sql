@@ -0,0 +1,2 @@
+-- The cleared payloads are gone, so there is nothing to restore.
+SELECT 1;
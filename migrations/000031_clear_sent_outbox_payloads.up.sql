@@ -0,0 +1,3 @@
+-- Delivered emails carried password reset, verification and email change
+-- links; only their hashes are meant to be stored.
+UPDATE outbox SET payload = '{}' WHERE sent_at IS NOT NULL;